@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"golang.org/x/time/rate"
+)
+
+var (
+	errFormatOTLPAttributes       = fmt.Errorf("value should be of the format key=\"value\"")
+	errDoubleQuotesOTLPAttributes = fmt.Errorf("value should be a string wrapped in double quotes")
+)
+
+const (
+	serviceNameKey = "service.name"
+	// IndexAttributeKey is the attribute whose values are bounded by the attribute cardinality.
+	IndexAttributeKey = "telemetrygen.index"
+)
+
+// Config describes the settings shared by all the signals.
+type Config struct {
+	WorkerCount          int
+	Rate                 int64
+	TotalDuration        time.Duration
+	ServiceName          string
+	AttributeCardinality int
+
+	// OTLP config
+	Endpoint            string
+	Insecure            bool
+	UseHTTP             bool
+	HTTPPath            string
+	Headers             KeyValue
+	ResourceAttributes  KeyValue
+	TelemetryAttributes KeyValue
+}
+
+type KeyValue map[string]string
+
+var _ pflag.Value = (*KeyValue)(nil)
+
+func (v *KeyValue) String() string {
+	return ""
+}
+
+func (v *KeyValue) Set(s string) error {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 {
+		return errFormatOTLPAttributes
+	}
+	val := kv[1]
+	if len(val) < 2 || !strings.HasPrefix(val, "\"") || !strings.HasSuffix(val, "\"") {
+		return errDoubleQuotesOTLPAttributes
+	}
+
+	(*v)[kv[0]] = val[1 : len(val)-1]
+	return nil
+}
+
+func (v *KeyValue) Type() string {
+	return "map[string]string"
+}
+
+// CommonFlags registers the flags shared by all the signals.
+func (c *Config) CommonFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.WorkerCount, "workers", 1, "Number of workers (goroutines) to run")
+	fs.Int64Var(&c.Rate, "rate", 0, "Approximately how many items per second each worker should generate. Zero means no throttling.")
+	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the test")
+	fs.StringVar(&c.ServiceName, "service", "telemetrygen", "Service name to use")
+	fs.IntVar(&c.AttributeCardinality, "attribute-cardinality", 0, "Number of distinct values of the \""+IndexAttributeKey+"\" attribute added to the generated items. Zero means the attribute isn't added.")
+
+	// unfortunately, at this moment, the otel-go client doesn't support configuring OTLP via env vars
+	fs.StringVar(&c.Endpoint, "otlp-endpoint", "localhost:4317", "Target to which the exporter is going to send the telemetry")
+	fs.BoolVar(&c.Insecure, "otlp-insecure", false, "Whether to enable client transport security for the exporter's grpc or http connection")
+	fs.BoolVar(&c.UseHTTP, "otlp-http", false, "Whether to use HTTP exporter rather than a gRPC one")
+	fs.StringVar(&c.HTTPPath, "otlp-http-url-path", "", "Which URL path to write to when using the HTTP exporter. Defaults to the OTLP path of the signal (e.g. /v1/metrics)")
+
+	// custom headers
+	c.Headers = make(map[string]string)
+	fs.Var(&c.Headers, "otlp-header", "Custom header to be passed along with each OTLP request. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple headers (e.g --otlp-header key1=\"value1\" --otlp-header key2=\"value2\")")
+
+	// custom resource attributes
+	c.ResourceAttributes = make(map[string]string)
+	fs.Var(&c.ResourceAttributes, "otlp-attributes", "Custom resource attributes to use. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple attributes (e.g --otlp-attributes key1=\"value1\" --otlp-attributes key2=\"value2\")")
+
+	// custom telemetry attributes
+	c.TelemetryAttributes = make(map[string]string)
+	fs.Var(&c.TelemetryAttributes, "telemetry-attributes", "Custom attributes added to each span, data point or log record. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple attributes (e.g --telemetry-attributes key1=\"value1\" --telemetry-attributes key2=\"value2\")")
+}
+
+// Limit returns the rate limit of each worker.
+func (c *Config) Limit() rate.Limit {
+	if c.Rate == 0 {
+		return rate.Inf
+	}
+	return rate.Limit(c.Rate)
+}
+
+// CopyResourceAttributes sets the service name and the custom resource attributes on the given map.
+func (c *Config) CopyResourceAttributes(attrs pcommon.Map) {
+	// may be overridden by `--otlp-attributes service.name="foo"`
+	attrs.UpsertString(serviceNameKey, c.ServiceName)
+	for k, v := range c.ResourceAttributes {
+		attrs.UpsertString(k, v)
+	}
+}
+
+// CopyTelemetryAttributes sets the custom telemetry attributes on the given map, along with the index
+// attribute of the i-th generated item when an attribute cardinality is configured.
+func (c *Config) CopyTelemetryAttributes(attrs pcommon.Map, i int) {
+	for k, v := range c.TelemetryAttributes {
+		attrs.UpsertString(k, v)
+	}
+	if c.AttributeCardinality > 0 {
+		attrs.UpsertString(IndexAttributeKey, c.IndexAttributeValue(i))
+	}
+}
+
+// IndexAttributeValue returns the value of the index attribute of the i-th generated item.
+func (c *Config) IndexAttributeValue(i int) string {
+	return fmt.Sprintf("value-%d", i%c.AttributeCardinality)
+}
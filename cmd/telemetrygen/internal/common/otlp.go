@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// dialTimeout is how long to wait for the gRPC connection to the OTLP endpoint to be established,
+// and the timeout of the OTLP/HTTP requests.
+const dialTimeout = 10 * time.Second
+
+// Request is an OTLP export request, such as a pmetricotlp.Request or a plogotlp.Request.
+type Request interface {
+	MarshalProto() ([]byte, error)
+}
+
+// GRPCExportFunc sends an OTLP export request over the given gRPC connection.
+type GRPCExportFunc func(ctx context.Context, conn *grpc.ClientConn, req Request) error
+
+// Exporter sends the OTLP export requests of the generated telemetry.
+type Exporter interface {
+	Export(ctx context.Context, req Request) error
+	Shutdown() error
+}
+
+// NewExporter returns an Exporter sending the requests to the OTLP/gRPC endpoint using exportGRPC,
+// or to the OTLP/HTTP endpoint when UseHTTP is set, in which case the requests are sent to the given
+// default path unless a path is configured.
+func (c *Config) NewExporter(ctx context.Context, defaultHTTPPath string, exportGRPC GRPCExportFunc) (Exporter, error) {
+	if c.UseHTTP {
+		return &httpExporter{
+			cfg:    c,
+			url:    c.HTTPURL(defaultHTTPPath),
+			client: &http.Client{Timeout: dialTimeout},
+		}, nil
+	}
+	conn, err := c.DialGRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcExporter{cfg: c, conn: conn, export: exportGRPC}, nil
+}
+
+type grpcExporter struct {
+	cfg    *Config
+	conn   *grpc.ClientConn
+	export GRPCExportFunc
+}
+
+func (e *grpcExporter) Export(ctx context.Context, req Request) error {
+	return e.export(e.cfg.GRPCContext(ctx), e.conn, req)
+}
+
+func (e *grpcExporter) Shutdown() error {
+	return e.conn.Close()
+}
+
+type httpExporter struct {
+	cfg    *Config
+	url    string
+	client *http.Client
+}
+
+func (e *httpExporter) Export(ctx context.Context, req Request) error {
+	body, err := req.MarshalProto()
+	if err != nil {
+		return err
+	}
+	return e.cfg.PostHTTP(ctx, e.client, e.url, body)
+}
+
+func (e *httpExporter) Shutdown() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// DialGRPC opens a gRPC connection to the OTLP endpoint, failing if it can't be established within the dial timeout.
+func (c *Config) DialGRPC(ctx context.Context) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if !c.Insecure {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, c.Endpoint, grpc.WithTransportCredentials(creds), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", c.Endpoint, err)
+	}
+	return conn, nil
+}
+
+// GRPCContext returns a context carrying the custom headers as gRPC metadata.
+func (c *Config) GRPCContext(ctx context.Context) context.Context {
+	if len(c.Headers) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.New(c.Headers))
+}
+
+// HTTPURL returns the URL to which the OTLP requests are sent when using the HTTP exporter.
+func (c *Config) HTTPURL(defaultPath string) string {
+	path := c.HTTPPath
+	if path == "" {
+		path = defaultPath
+	}
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, strings.TrimSuffix(c.Endpoint, "/"), strings.TrimPrefix(path, "/"))
+}
+
+// PostHTTP sends a protobuf encoded OTLP request to the given URL.
+func (c *Config) PostHTTP(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("OTLP endpoint %s returned status %q", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	grpcZap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// NewLogger returns the logger of the telemetry generator, which is also used by gRPC.
+func NewLogger() (*zap.Logger, error) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain logger: %w", err)
+	}
+	grpcZap.ReplaceGrpcLoggerV2(logger.WithOptions(
+		zap.AddCallerSkip(3),
+	))
+	return logger, nil
+}
+
+// Run executes the test scenario: it starts WorkerCount workers, each generating numItems items, or generating
+// items until TotalDuration elapsed, at the configured rate, and waits for them to be done. The items are named
+// after their flag in the logs and errors, e.g. "traces". newGenerator is called for each worker and returns the
+// function generating the i-th item of the worker.
+func (c *Config) Run(flag string, numItems int, logger *zap.Logger, newGenerator func(logger *zap.Logger) func(i int)) error {
+	if c.TotalDuration > 0 {
+		numItems = 0
+	} else if numItems <= 0 {
+		return fmt.Errorf("either `%s` or `duration` must be greater than 0", flag)
+	}
+
+	limit := c.Limit()
+	if c.Rate == 0 {
+		logger.Info("generation of " + flag + " isn't being throttled")
+	} else {
+		logger.Info("generation of "+flag+" is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	wg := sync.WaitGroup{}
+	running := atomic.NewBool(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			flag:           flag,
+			numItems:       numItems,
+			limitPerSecond: limit,
+			running:        running,
+			wg:             &wg,
+			logger:         logger.With(zap.Int("worker", i)),
+		}
+
+		go w.simulate(newGenerator(w.logger))
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
+
+type worker struct {
+	flag           string          // the name of the generated items
+	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numItems       int             // how many items the worker has to generate (only when duration==0)
+	limitPerSecond rate.Limit      // how many items per second to generate
+	wg             *sync.WaitGroup // notify when done
+	logger         *zap.Logger
+}
+
+func (w worker) simulate(generate func(i int)) {
+	defer w.wg.Done()
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	var i int
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		generate(i)
+
+		i++
+		if w.numItems != 0 {
+			if i >= w.numItems {
+				break
+			}
+		}
+	}
+	w.logger.Info(w.flag+" generated", zap.Int(w.flag, i))
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+type rawRequest []byte
+
+func (r rawRequest) MarshalProto() ([]byte, error) {
+	return r, nil
+}
+
+func TestRunFixedNumberOfItems(t *testing.T) {
+	cfg := &Config{WorkerCount: 3}
+	generated := atomic.NewInt64(0)
+	require.NoError(t, cfg.Run("items", 4, zap.NewNop(), func(*zap.Logger) func(i int) {
+		return func(int) {
+			generated.Inc()
+		}
+	}))
+	assert.Equal(t, int64(12), generated.Load())
+
+	assert.EqualError(t, cfg.Run("items", 0, zap.NewNop(), nil), "either `items` or `duration` must be greater than 0")
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var received []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/logs", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("Authorization"))
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		received = body
+	}))
+	defer srv.Close()
+
+	cfg := &Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Insecure: true,
+		UseHTTP:  true,
+		Headers:  KeyValue{"Authorization": "secret"},
+	}
+	exp, err := cfg.NewExporter(context.Background(), "/v1/logs", nil)
+	require.NoError(t, err)
+	require.NoError(t, exp.Export(context.Background(), rawRequest("payload")))
+	require.NoError(t, exp.Shutdown())
+	assert.Equal(t, []byte("payload"), received)
+}
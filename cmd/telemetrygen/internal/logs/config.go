@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// Config describes the test scenario.
+type Config struct {
+	common.Config
+	NumLogs        int
+	Body           string
+	SeverityText   string
+	SeverityNumber int32
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.CommonFlags(fs)
+
+	fs.IntVar(&c.NumLogs, "logs", 1, "Number of logs to generate in each worker (ignored if duration is provided)")
+	fs.StringVar(&c.Body, "body", "the message", "Body of the generated log records")
+	fs.StringVar(&c.SeverityText, "severity-text", "Info", "Severity text of the generated log records")
+	fs.Int32Var(&c.SeverityNumber, "severity-number", 9, "Severity number of the generated log records, between 1 (TRACE) and 24 (FATAL4)")
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// Start starts the log telemetry generator.
+func Start(cfg *Config) error {
+	logger, err := common.NewLogger()
+	if err != nil {
+		return err
+	}
+
+	if cfg.UseHTTP {
+		logger.Info("starting HTTP exporter")
+	} else {
+		logger.Info("starting gRPC exporter")
+	}
+	exp, err := cfg.NewExporter(context.Background(), "/v1/logs", exportGRPC)
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if err := exp.Shutdown(); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
+
+	return Run(cfg, exp, logger)
+}
+
+func exportGRPC(ctx context.Context, conn *grpc.ClientConn, req common.Request) error {
+	_, err := plogotlp.NewClient(conn).Export(ctx, req.(plogotlp.Request))
+	return err
+}
+
+// Run executes the test scenario.
+func Run(c *Config, exp common.Exporter, logger *zap.Logger) error {
+	if c.SeverityNumber < 0 || c.SeverityNumber > 24 {
+		return fmt.Errorf("`severity-number` must be between 0 and 24")
+	}
+
+	return c.Config.Run("logs", c.NumLogs, logger, func(logger *zap.Logger) func(i int) {
+		w := worker{cfg: c, exp: exp, logger: logger}
+		return w.simulate
+	})
+}
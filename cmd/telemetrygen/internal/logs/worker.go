@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	cfg    *Config         // the settings of the generated logs
+	exp    common.Exporter // the exporter of the generated logs
+	logger *zap.Logger
+}
+
+// simulate generates and exports the i-th log record.
+func (w worker) simulate(i int) {
+	if err := w.exp.Export(context.Background(), plogotlp.NewRequestFromLogs(w.generate(i))); err != nil {
+		w.logger.Error("exporter failed", zap.Error(err))
+	}
+}
+
+func (w worker) generate(i int) plog.Logs {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	w.cfg.CopyResourceAttributes(rl.Resource().Attributes())
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("telemetrygen")
+
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(now)
+	lr.SetObservedTimestamp(now)
+	lr.SetSeverityText(w.cfg.SeverityText)
+	lr.SetSeverityNumber(plog.SeverityNumber(w.cfg.SeverityNumber))
+	lr.Body().SetStringVal(w.cfg.Body)
+	w.cfg.CopyTelemetryAttributes(lr.Attributes(), i)
+	return ld
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu   sync.Mutex
+	logs []plog.Logs
+}
+
+func (m *mockExporter) Export(_ context.Context, req common.Request) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, req.(plogotlp.Request).Logs())
+	return nil
+}
+
+func (m *mockExporter) Shutdown() error {
+	return nil
+}
+
+func TestFixedNumberOfLogs(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:          1,
+			ServiceName:          "telemetrygen",
+			AttributeCardinality: 2,
+			TelemetryAttributes:  common.KeyValue{"key": "value"},
+		},
+		NumLogs:        5,
+		Body:           "the message",
+		SeverityText:   "Warn",
+		SeverityNumber: 13,
+	}
+	exp := &mockExporter{}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	require.Len(t, exp.logs, 5)
+	indexes := map[string]bool{}
+	for _, ld := range exp.logs {
+		rl := ld.ResourceLogs().At(0)
+		serviceName, ok := rl.Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		assert.Equal(t, "telemetrygen", serviceName.StringVal())
+
+		lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, "the message", lr.Body().StringVal())
+		assert.Equal(t, "Warn", lr.SeverityText())
+		assert.Equal(t, plog.SeverityNumberWARN, lr.SeverityNumber())
+		attrs := lr.Attributes().AsRaw()
+		assert.Equal(t, "value", attrs["key"])
+		indexes[attrs[common.IndexAttributeKey].(string)] = true
+	}
+	assert.Len(t, indexes, 2)
+}
+
+func TestRateOfLogs(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+	}
+	exp := &mockExporter{}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, len(exp.logs) >= 3, "there should have been more than 3 logs, had %d", len(exp.logs))
+	// the maximum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, len(exp.logs) <= 10, "there should have been less than 10 logs, had %d", len(exp.logs))
+}
+
+func TestInvalidSeverityNumber(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumLogs:        1,
+		SeverityNumber: 25,
+	}
+	assert.EqualError(t, Run(cfg, &mockExporter{}, zap.NewNop()), "`severity-number` must be between 0 and 24")
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+const (
+	metricTypeGauge                = "Gauge"
+	metricTypeSum                  = "Sum"
+	metricTypeHistogram            = "Histogram"
+	metricTypeExponentialHistogram = "ExponentialHistogram"
+	metricTypeSummary              = "Summary"
+)
+
+// Config describes the test scenario.
+type Config struct {
+	common.Config
+	NumMetrics int
+	NumStreams int
+	MetricType metricType
+}
+
+// metricType is the data type of the generated metrics.
+type metricType string
+
+var _ pflag.Value = (*metricType)(nil)
+
+func (t *metricType) String() string {
+	return string(*t)
+}
+
+func (t *metricType) Set(s string) error {
+	switch s {
+	case metricTypeGauge, metricTypeSum, metricTypeHistogram, metricTypeExponentialHistogram, metricTypeSummary:
+		*t = metricType(s)
+		return nil
+	default:
+		return fmt.Errorf("metric type must be one of %s, %s, %s, %s or %s", metricTypeGauge, metricTypeSum,
+			metricTypeHistogram, metricTypeExponentialHistogram, metricTypeSummary)
+	}
+}
+
+func (t *metricType) Type() string {
+	return "metricType"
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.CommonFlags(fs)
+
+	fs.IntVar(&c.NumMetrics, "metrics", 1, "Number of metric batches to generate in each worker, each batch holding a data point for each stream (ignored if duration is provided)")
+	fs.IntVar(&c.NumStreams, "streams", 1, "Number of distinct metrics (streams) in each generated batch")
+
+	c.MetricType = metricTypeGauge
+	fs.Var(&c.MetricType, "metric-type", "Metric type: "+metricTypeGauge+", "+metricTypeSum+", "+metricTypeHistogram+", "+
+		metricTypeExponentialHistogram+" or "+metricTypeSummary)
+}
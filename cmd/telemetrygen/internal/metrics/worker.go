@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	cfg    *Config         // the settings of the generated metrics
+	exp    common.Exporter // the exporter of the generated metrics
+	logger *zap.Logger
+
+	// state of the cumulative data points
+	start        pcommon.Timestamp
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+	expZeroCount uint64
+	expCounts    []uint64
+}
+
+// the explicit bounds of the generated histograms
+var explicitBounds = []float64{1, 10, 100, 1000}
+
+const (
+	// the number of distinct values observed by the histograms and summaries
+	numValues = 1000
+	// the index of the first bucket of the exponential histograms, holding the values in (0.5, 1]
+	expOffset = -1
+)
+
+func newWorker(cfg *Config, exp common.Exporter, logger *zap.Logger) *worker {
+	return &worker{
+		cfg:          cfg,
+		exp:          exp,
+		logger:       logger,
+		start:        pcommon.NewTimestampFromTime(time.Now()),
+		bucketCounts: make([]uint64, len(explicitBounds)+1),
+	}
+}
+
+// simulate generates and exports the i-th batch of metrics.
+func (w *worker) simulate(i int) {
+	req := pmetricotlp.NewRequestFromMetrics(w.generate(i))
+	if err := w.exp.Export(context.Background(), req); err != nil {
+		w.logger.Error("exporter failed", zap.Error(err))
+	}
+}
+
+// generate returns the i-th batch of metrics, containing a data point for each stream.
+func (w *worker) generate(i int) pmetric.Metrics {
+	now := pcommon.NewTimestampFromTime(time.Now())
+	w.observe(float64(i % numValues))
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	w.cfg.CopyResourceAttributes(rm.Resource().Attributes())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("telemetrygen")
+
+	metrics := sm.Metrics()
+	metrics.EnsureCapacity(w.cfg.NumStreams)
+	for s := 0; s < w.cfg.NumStreams; s++ {
+		m := metrics.AppendEmpty()
+		m.SetName("gen." + strconv.Itoa(s))
+
+		switch w.cfg.MetricType {
+		case metricTypeSum:
+			m.SetDataType(pmetric.MetricDataTypeSum)
+			m.Sum().SetIsMonotonic(true)
+			m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+			dp := m.Sum().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(w.start)
+			dp.SetTimestamp(now)
+			dp.SetIntVal(int64(w.count))
+			w.cfg.CopyTelemetryAttributes(dp.Attributes(), i)
+		case metricTypeHistogram:
+			m.SetDataType(pmetric.MetricDataTypeHistogram)
+			m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(w.start)
+			dp.SetTimestamp(now)
+			dp.SetCount(w.count)
+			dp.SetSum(w.sum)
+			dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(explicitBounds))
+			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(w.bucketCounts))
+			w.cfg.CopyTelemetryAttributes(dp.Attributes(), i)
+		case metricTypeExponentialHistogram:
+			m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+			m.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+			dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(w.start)
+			dp.SetTimestamp(now)
+			dp.SetCount(w.count)
+			dp.SetSum(w.sum)
+			dp.SetScale(0)
+			dp.SetZeroCount(w.expZeroCount)
+			dp.Positive().SetOffset(expOffset)
+			dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(w.expCounts))
+			w.cfg.CopyTelemetryAttributes(dp.Attributes(), i)
+		case metricTypeSummary:
+			m.SetDataType(pmetric.MetricDataTypeSummary)
+			dp := m.Summary().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(w.start)
+			dp.SetTimestamp(now)
+			dp.SetCount(w.count)
+			dp.SetSum(w.sum)
+			// the observed values are uniformly distributed between 0 and the largest observed value
+			maxValue := math.Min(float64(w.count-1), numValues-1)
+			for _, q := range []float64{0, 0.5, 0.9, 0.99, 1} {
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(q)
+				qv.SetValue(math.Floor(q * maxValue))
+			}
+			w.cfg.CopyTelemetryAttributes(dp.Attributes(), i)
+		default:
+			m.SetDataType(pmetric.MetricDataTypeGauge)
+			dp := m.Gauge().DataPoints().AppendEmpty()
+			dp.SetTimestamp(now)
+			dp.SetIntVal(int64(i))
+			w.cfg.CopyTelemetryAttributes(dp.Attributes(), i)
+		}
+	}
+	return md
+}
+
+// observe records a value in the cumulative state of the worker.
+func (w *worker) observe(v float64) {
+	w.count++
+	w.sum += v
+
+	bucket := len(explicitBounds)
+	for b, bound := range explicitBounds {
+		if v <= bound {
+			bucket = b
+			break
+		}
+	}
+	w.bucketCounts[bucket]++
+
+	if v == 0 {
+		w.expZeroCount++
+		return
+	}
+	// with a scale of 0, the bucket of index i holds the values in (2^i, 2^(i+1)]
+	frac, exp := math.Frexp(v)
+	index := exp - 1 - expOffset
+	if frac == 0.5 {
+		index--
+	}
+	for len(w.expCounts) <= index {
+		w.expCounts = append(w.expCounts, 0)
+	}
+	w.expCounts[index]++
+}
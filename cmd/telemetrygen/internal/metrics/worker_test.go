@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu      sync.Mutex
+	metrics []pmetric.Metrics
+}
+
+func (m *mockExporter) Export(_ context.Context, req common.Request) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, req.(pmetricotlp.Request).Metrics())
+	return nil
+}
+
+func (m *mockExporter) Shutdown() error {
+	return nil
+}
+
+func TestFixedNumberOfMetrics(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumMetrics: 5,
+		NumStreams: 1,
+		MetricType: metricTypeGauge,
+	}
+	exp := &mockExporter{}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	assert.Len(t, exp.metrics, 5)
+}
+
+func TestRateOfMetrics(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		NumStreams: 1,
+		MetricType: metricTypeSum,
+	}
+	exp := &mockExporter{}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, len(exp.metrics) >= 3, "there should have been more than 3 metrics, had %d", len(exp.metrics))
+	// the maximum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, len(exp.metrics) <= 10, "there should have been less than 10 metrics, had %d", len(exp.metrics))
+}
+
+func TestInvalidConfig(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumMetrics: 1,
+	}
+	assert.EqualError(t, Run(cfg, &mockExporter{}, zap.NewNop()), "`streams` must be greater than 0")
+
+	cfg.NumMetrics = 0
+	cfg.NumStreams = 1
+	assert.EqualError(t, Run(cfg, &mockExporter{}, zap.NewNop()), "either `metrics` or `duration` must be greater than 0")
+}
+
+func TestMetricTypes(t *testing.T) {
+	tests := []struct {
+		metricType string
+		dataType   pmetric.MetricDataType
+	}{
+		{metricType: metricTypeGauge, dataType: pmetric.MetricDataTypeGauge},
+		{metricType: metricTypeSum, dataType: pmetric.MetricDataTypeSum},
+		{metricType: metricTypeHistogram, dataType: pmetric.MetricDataTypeHistogram},
+		{metricType: metricTypeExponentialHistogram, dataType: pmetric.MetricDataTypeExponentialHistogram},
+		{metricType: metricTypeSummary, dataType: pmetric.MetricDataTypeSummary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.metricType, func(t *testing.T) {
+			// prepare
+			var mt metricType
+			require.NoError(t, mt.Set(tt.metricType))
+			cfg := &Config{
+				Config: common.Config{
+					WorkerCount:          1,
+					ServiceName:          "telemetrygen",
+					AttributeCardinality: 2,
+					TelemetryAttributes:  common.KeyValue{"key": "value"},
+				},
+				NumMetrics: 3,
+				NumStreams: 4,
+				MetricType: mt,
+			}
+			exp := &mockExporter{}
+
+			// test
+			require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+			// verify
+			require.Len(t, exp.metrics, 3)
+			indexes := map[string]bool{}
+			for _, md := range exp.metrics {
+				require.Equal(t, 4, md.MetricCount())
+				rm := md.ResourceMetrics().At(0)
+				serviceName, ok := rm.Resource().Attributes().Get("service.name")
+				require.True(t, ok)
+				assert.Equal(t, "telemetrygen", serviceName.StringVal())
+
+				metrics := rm.ScopeMetrics().At(0).Metrics()
+				for i := 0; i < metrics.Len(); i++ {
+					m := metrics.At(i)
+					assert.Equal(t, tt.dataType, m.DataType())
+					attrs := dataPointAttributes(m)
+					assert.Equal(t, "value", attrs["key"])
+					indexes[attrs[common.IndexAttributeKey].(string)] = true
+				}
+			}
+			assert.Len(t, indexes, 2)
+		})
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	w := &worker{cfg: &Config{NumStreams: 1, MetricType: metricTypeHistogram}, bucketCounts: make([]uint64, len(explicitBounds)+1)}
+	for _, v := range []float64{0, 1, 2, 4, 5, 100, 2000} {
+		w.observe(v)
+	}
+
+	assert.Equal(t, uint64(7), w.count)
+	assert.Equal(t, 2112.0, w.sum)
+	assert.Equal(t, []uint64{2, 3, 1, 0, 1}, w.bucketCounts)
+	assert.Equal(t, uint64(1), w.expZeroCount)
+	// buckets (0.5, 1], (1, 2], (2, 4], (4, 8], ... with 100 in (64, 128] and 2000 in (1024, 2048]
+	assert.Equal(t, []uint64{1, 1, 1, 1, 0, 0, 0, 1, 0, 0, 0, 1}, w.expCounts)
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var received pmetric.Metrics
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("Authorization"))
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		otlpReq := pmetricotlp.NewRequest()
+		assert.NoError(t, otlpReq.UnmarshalProto(body))
+		received = otlpReq.Metrics()
+	}))
+	defer srv.Close()
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+			Endpoint:    strings.TrimPrefix(srv.URL, "http://"),
+			Insecure:    true,
+			UseHTTP:     true,
+			Headers:     common.KeyValue{"Authorization": "secret"},
+		},
+		NumMetrics: 1,
+		NumStreams: 1,
+	}
+	exp, err := cfg.NewExporter(context.Background(), "/v1/metrics", exportGRPC)
+	require.NoError(t, err)
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+	require.NoError(t, exp.Shutdown())
+	assert.Equal(t, 1, received.MetricCount())
+}
+
+func dataPointAttributes(m pmetric.Metric) map[string]interface{} {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeSum:
+		return m.Sum().DataPoints().At(0).Attributes().AsRaw()
+	case pmetric.MetricDataTypeHistogram:
+		return m.Histogram().DataPoints().At(0).Attributes().AsRaw()
+	case pmetric.MetricDataTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().At(0).Attributes().AsRaw()
+	case pmetric.MetricDataTypeSummary:
+		return m.Summary().DataPoints().At(0).Attributes().AsRaw()
+	default:
+		return m.Gauge().DataPoints().At(0).Attributes().AsRaw()
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// Start starts the trace telemetry generator.
+func Start(cfg *Config) error {
+	logger, err := common.NewLogger()
+	if err != nil {
+		return err
+	}
+
+	var exp *otlptrace.Exporter
+	if cfg.UseHTTP {
+		logger.Info("starting HTTP exporter")
+		exp, err = otlptracehttp.New(context.Background(), httpExporterOptions(cfg)...)
+	} else {
+		logger.Info("starting gRPC exporter")
+		var conn *grpc.ClientConn
+		if conn, err = cfg.DialGRPC(context.Background()); err != nil {
+			return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+		}
+		// the exporter doesn't close a connection it didn't open
+		defer conn.Close()
+		exp, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithGRPCConn(conn), otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if err = exp.Shutdown(context.Background()); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
+
+	ssp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithBatchTimeout(time.Second))
+	defer func() {
+		logger.Info("stop the batch span processor")
+		if err := ssp.Shutdown(context.Background()); err != nil {
+			logger.Error("failed to stop the batch span processor", zap.Error(err))
+		}
+	}()
+
+	var attributes []attribute.KeyValue
+	// may be overridden by `--otlp-attributes service.name="foo"`
+	attributes = append(attributes, semconv.ServiceNameKey.String(cfg.ServiceName))
+	for k, v := range cfg.ResourceAttributes {
+		attributes = append(attributes, attribute.String(k, v))
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
+	)
+	tracerProvider.RegisterSpanProcessor(ssp)
+	otel.SetTracerProvider(tracerProvider)
+
+	return Run(cfg, logger)
+}
+
+func httpExporterOptions(cfg *Config) []otlptracehttp.Option {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.HTTPPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath("/"+strings.TrimPrefix(cfg.HTTPPath, "/")))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	return opts
+}
+
+// Run executes the test scenario.
+func Run(c *Config, logger *zap.Logger) error {
+	return c.Config.Run("traces", c.NumTraces, logger, func(*zap.Logger) func(i int) {
+		w := worker{cfg: c, propagateContext: c.PropagateContext}
+		return w.simulate
+	})
+}
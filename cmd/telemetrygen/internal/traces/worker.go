@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2018 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	cfg              *Config // the settings of the generated spans
+	propagateContext bool    // whether the worker needs to propagate the trace context via HTTP headers
+}
+
+const (
+	fakeIP string = "1.2.3.4"
+
+	fakeSpanDuration = 123 * time.Microsecond
+)
+
+// simulate generates the i-th trace, made of a client and a server span.
+func (w worker) simulate(i int) {
+	tracer := otel.Tracer("telemetrygen")
+	attrs := w.attributes(i)
+	ctx, sp := tracer.Start(context.Background(), "lets-go", trace.WithAttributes(
+		append([]attribute.KeyValue{
+			attribute.String("span.kind", "client"), // is there a semantic convention for this?
+			semconv.NetPeerIPKey.String(fakeIP),
+			semconv.PeerServiceKey.String("telemetrygen-server"),
+		}, attrs...)...,
+	))
+
+	childCtx := ctx
+	if w.propagateContext {
+		header := propagation.HeaderCarrier{}
+		// simulates going remote
+		otel.GetTextMapPropagator().Inject(childCtx, header)
+
+		// simulates getting a request from a client
+		childCtx = otel.GetTextMapPropagator().Extract(childCtx, header)
+	}
+
+	_, child := tracer.Start(childCtx, "okey-dokey", trace.WithAttributes(
+		append([]attribute.KeyValue{
+			attribute.String("span.kind", "server"),
+			semconv.NetPeerIPKey.String(fakeIP),
+			semconv.PeerServiceKey.String("telemetrygen-client"),
+		}, attrs...)...,
+	))
+
+	opt := trace.WithTimestamp(time.Now().Add(fakeSpanDuration))
+	child.End(opt)
+	sp.End(opt)
+}
+
+func (w worker) attributes(i int) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(w.cfg.TelemetryAttributes)+1)
+	for k, v := range w.cfg.TelemetryAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	if w.cfg.AttributeCardinality > 0 {
+		attrs = append(attrs, attribute.String(common.IndexAttributeKey, w.cfg.IndexAttributeValue(i)))
+	}
+	return attrs
+}
@@ -37,6 +37,10 @@ type Config struct {
 	DSN string `mapstructure:"dsn"`
 	// LogsTableName is the table name for logs. default is `otel_logs`.
 	LogsTableName string `mapstructure:"logs_table_name"`
+	// TracesTableName is the table name for traces. default is `otel_traces`.
+	TracesTableName string `mapstructure:"traces_table_name"`
+	// MetricsTableName is the prefix of the table names for metrics, one table per metric type. default is `otel_metrics`.
+	MetricsTableName string `mapstructure:"metrics_table_name"`
 	// TTLDays is The data time-to-live in days, 0 means no ttl.
 	TTLDays uint `mapstructure:"ttl_days"`
 }
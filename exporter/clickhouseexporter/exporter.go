@@ -45,6 +45,11 @@ func newExporter(logger *zap.Logger, cfg *Config) (*clickhouseExporter, error) {
 		return nil, err
 	}
 
+	if err = createLogsTable(cfg, client); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
 	insertLogsSQL := renderInsertLogsSQL(cfg)
 
 	return &clickhouseExporter{
@@ -189,17 +194,23 @@ func newClickhouseClient(cfg *Config) (*sql.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open:%w", err)
 	}
-	// create table
-	query := fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, "")
-	if cfg.TTLDays > 0 {
-		query = fmt.Sprintf(createLogsTableSQL,
-			cfg.LogsTableName,
-			fmt.Sprintf(`TTL Timestamp + INTERVAL %d DAY`, cfg.TTLDays))
-	}
+	return db, nil
+}
+
+func createLogsTable(cfg *Config, db *sql.DB) error {
+	query := fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, renderTTL(cfg, "Timestamp"))
 	if _, err := db.Exec(query); err != nil {
-		return nil, fmt.Errorf("exec create table sql: %w", err)
+		return fmt.Errorf("exec create logs table sql: %w", err)
 	}
-	return db, nil
+	return nil
+}
+
+// renderTTL returns the TTL clause of the tables, based on the given time column, or an empty string when there is no ttl.
+func renderTTL(cfg *Config, column string) string {
+	if cfg.TTLDays == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`TTL %s + INTERVAL %d DAY`, column, cfg.TTLDays)
 }
 
 func renderInsertLogsSQL(cfg *Config) string {
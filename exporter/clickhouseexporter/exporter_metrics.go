@@ -0,0 +1,413 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// metricsTable describes the table holding the data points of a metric data type.
+type metricsTable struct {
+	dataType pmetric.MetricDataType
+	// suffix is appended to the metrics table name to build the name of the table.
+	suffix string
+	// columns is the definition of the columns specific to the data type.
+	columns string
+	// fields lists the names of the columns specific to the data type, in the order of values.
+	fields []string
+	// values returns the values of the specific columns for each data point of the metric.
+	values func(metric pmetric.Metric) [][]interface{}
+}
+
+type metricsExporter struct {
+	client     *sql.DB
+	insertSQLs map[pmetric.MetricDataType]string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createMetricsTables(cfg, client); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	insertSQLs := make(map[pmetric.MetricDataType]string, len(metricsTables))
+	for _, table := range metricsTables {
+		insertSQLs[table.dataType] = renderInsertMetricsSQL(cfg, table)
+	}
+
+	return &metricsExporter{
+		client:     client,
+		insertSQLs: insertSQLs,
+		logger:     logger,
+		cfg:        cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	start := time.Now()
+	present := metricDataTypes(md)
+	written := map[pmetric.MetricDataType]bool{}
+	var err error
+	// each table is written in its own transaction
+	for _, table := range metricsTables {
+		if !present[table.dataType] {
+			continue
+		}
+		if err = e.insertTable(ctx, md, table); err != nil {
+			// the tables written so far are committed, so only the metrics of the other tables are retried
+			err = consumererror.NewMetrics(err, metricsWithout(md, written))
+			break
+		}
+		written[table.dataType] = true
+	}
+	duration := time.Since(start)
+	e.logger.Debug("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	return err
+}
+
+func (e *metricsExporter) insertTable(ctx context.Context, md pmetric.Metrics, table metricsTable) error {
+	return doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+		statement, err := tx.PrepareContext(ctx, e.insertSQLs[table.dataType])
+		if err != nil {
+			return fmt.Errorf("PrepareContext:%w", err)
+		}
+		defer func() {
+			_ = statement.Close()
+		}()
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			metrics := md.ResourceMetrics().At(i)
+			res := metrics.Resource()
+			resourceKeys, resourceValues := attributesToSlice(res.Attributes())
+			for j := 0; j < metrics.ScopeMetrics().Len(); j++ {
+				scope := metrics.ScopeMetrics().At(j).Scope()
+				rs := metrics.ScopeMetrics().At(j).Metrics()
+				for k := 0; k < rs.Len(); k++ {
+					r := rs.At(k)
+					if r.DataType() != table.dataType {
+						continue
+					}
+					for _, values := range table.values(r) {
+						args := append([]interface{}{
+							resourceKeys,
+							resourceValues,
+							scope.Name(),
+							scope.Version(),
+							r.Name(),
+							r.Description(),
+							r.Unit(),
+						}, values...)
+						if _, err = statement.ExecContext(ctx, args...); err != nil {
+							return fmt.Errorf("ExecContext:%w", err)
+						}
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// metricDataTypes returns the data types of the metrics, to skip the tables without any data point.
+func metricDataTypes(md pmetric.Metrics) map[pmetric.MetricDataType]bool {
+	present := map[pmetric.MetricDataType]bool{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				present[ms.At(k).DataType()] = true
+			}
+		}
+	}
+	return present
+}
+
+// metricsWithout returns a copy of the metrics, without the metrics of the given data types.
+func metricsWithout(md pmetric.Metrics, dataTypes map[pmetric.MetricDataType]bool) pmetric.Metrics {
+	out := md.Clone()
+	for i := 0; i < out.ResourceMetrics().Len(); i++ {
+		sms := out.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sms.At(j).Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return dataTypes[m.DataType()]
+			})
+		}
+	}
+	return out
+}
+
+// pointValues returns the values of the columns common to the data points of all types:
+// the attributes, start time, time and flags.
+func pointValues(attributes pcommon.Map, startTimestamp, timestamp pcommon.Timestamp, flags pmetric.MetricDataPointFlags) []interface{} {
+	attrKeys, attrValues := attributesToSlice(attributes)
+	return []interface{}{
+		attrKeys,
+		attrValues,
+		startTimestamp.AsTime(),
+		timestamp.AsTime(),
+		uint32(flags),
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+var metricsTables = []metricsTable{
+	{
+		dataType: pmetric.MetricDataTypeGauge,
+		suffix:   "gauge",
+		columns: `
+     Value Float64 CODEC(ZSTD(1)),`,
+		fields: []string{"Value"},
+		values: func(metric pmetric.Metric) [][]interface{} {
+			dps := metric.Gauge().DataPoints()
+			rows := make([][]interface{}, 0, dps.Len())
+			for i := 0; i < dps.Len(); i++ {
+				dp := dps.At(i)
+				rows = append(rows, append(pointValues(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags()),
+					numberValue(dp)))
+			}
+			return rows
+		},
+	},
+	{
+		dataType: pmetric.MetricDataTypeSum,
+		suffix:   "sum",
+		columns: `
+     Value Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),
+     IsMonotonic UInt8 CODEC(Delta, ZSTD(1)),`,
+		fields: []string{"Value", "AggTemp", "IsMonotonic"},
+		values: func(metric pmetric.Metric) [][]interface{} {
+			sum := metric.Sum()
+			var isMonotonic uint8
+			if sum.IsMonotonic() {
+				isMonotonic = 1
+			}
+			dps := sum.DataPoints()
+			rows := make([][]interface{}, 0, dps.Len())
+			for i := 0; i < dps.Len(); i++ {
+				dp := dps.At(i)
+				rows = append(rows, append(pointValues(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags()),
+					numberValue(dp),
+					int32(sum.AggregationTemporality()),
+					isMonotonic))
+			}
+			return rows
+		},
+	},
+	{
+		dataType: pmetric.MetricDataTypeHistogram,
+		suffix:   "histogram",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     ExplicitBounds Array(Float64) CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`,
+		fields: []string{"Count", "Sum", "BucketCounts", "ExplicitBounds", "Min", "Max", "AggTemp"},
+		values: func(metric pmetric.Metric) [][]interface{} {
+			histogram := metric.Histogram()
+			dps := histogram.DataPoints()
+			rows := make([][]interface{}, 0, dps.Len())
+			for i := 0; i < dps.Len(); i++ {
+				dp := dps.At(i)
+				rows = append(rows, append(pointValues(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags()),
+					dp.Count(),
+					dp.Sum(),
+					dp.BucketCounts().AsRaw(),
+					dp.ExplicitBounds().AsRaw(),
+					dp.Min(),
+					dp.Max(),
+					int32(histogram.AggregationTemporality())))
+			}
+			return rows
+		},
+	},
+	{
+		dataType: pmetric.MetricDataTypeExponentialHistogram,
+		suffix:   "exponential_histogram",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     Scale Int32 CODEC(ZSTD(1)),
+     ZeroCount UInt64 CODEC(ZSTD(1)),
+     PositiveOffset Int32 CODEC(ZSTD(1)),
+     PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     NegativeOffset Int32 CODEC(ZSTD(1)),
+     NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),`,
+		fields: []string{"Count", "Sum", "Scale", "ZeroCount", "PositiveOffset", "PositiveBucketCounts",
+			"NegativeOffset", "NegativeBucketCounts", "Min", "Max", "AggTemp"},
+		values: func(metric pmetric.Metric) [][]interface{} {
+			histogram := metric.ExponentialHistogram()
+			dps := histogram.DataPoints()
+			rows := make([][]interface{}, 0, dps.Len())
+			for i := 0; i < dps.Len(); i++ {
+				dp := dps.At(i)
+				rows = append(rows, append(pointValues(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags()),
+					dp.Count(),
+					dp.Sum(),
+					dp.Scale(),
+					dp.ZeroCount(),
+					dp.Positive().Offset(),
+					dp.Positive().BucketCounts().AsRaw(),
+					dp.Negative().Offset(),
+					dp.Negative().BucketCounts().AsRaw(),
+					dp.Min(),
+					dp.Max(),
+					int32(histogram.AggregationTemporality())))
+			}
+			return rows
+		},
+	},
+	{
+		dataType: pmetric.MetricDataTypeSummary,
+		suffix:   "summary",
+		columns: `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     ValueAtQuantiles Nested
+     (
+         Quantile Float64,
+         Value Float64
+     ) CODEC(ZSTD(1)),`,
+		fields: []string{"Count", "Sum", "ValueAtQuantiles.Quantile", "ValueAtQuantiles.Value"},
+		values: func(metric pmetric.Metric) [][]interface{} {
+			dps := metric.Summary().DataPoints()
+			rows := make([][]interface{}, 0, dps.Len())
+			for i := 0; i < dps.Len(); i++ {
+				dp := dps.At(i)
+				quantiles := make([]float64, 0, dp.QuantileValues().Len())
+				values := make([]float64, 0, dp.QuantileValues().Len())
+				for j := 0; j < dp.QuantileValues().Len(); j++ {
+					quantiles = append(quantiles, dp.QuantileValues().At(j).Quantile())
+					values = append(values, dp.QuantileValues().At(j).Value())
+				}
+				rows = append(rows, append(pointValues(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags()),
+					dp.Count(),
+					dp.Sum(),
+					quantiles,
+					values))
+			}
+			return rows
+		},
+	},
+}
+
+// metricsCommonFields lists the names of the columns shared by all the metrics tables, in the order of values.
+var metricsCommonFields = []string{
+	"ResourceAttributes.Key",
+	"ResourceAttributes.Value",
+	"ScopeName",
+	"ScopeVersion",
+	"MetricName",
+	"MetricDescription",
+	"MetricUnit",
+	"Attributes.Key",
+	"Attributes.Value",
+	"StartTimeUnix",
+	"TimeUnix",
+	"Flags",
+}
+
+const (
+	// language=ClickHouse SQL
+	createMetricsTableSQL = `
+CREATE TABLE IF NOT EXISTS %s_%s (
+     ResourceAttributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     ScopeName String CODEC(ZSTD(1)),
+     ScopeVersion String CODEC(ZSTD(1)),
+     MetricName String CODEC(ZSTD(1)),
+     MetricDescription String CODEC(ZSTD(1)),
+     MetricUnit String CODEC(ZSTD(1)),
+     Attributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),%s
+     INDEX idx_res_keys ResourceAttributes.Key TYPE bloom_filter(0.01) GRANULARITY 64,
+     INDEX idx_attr_keys Attributes.Key TYPE bloom_filter(0.01) GRANULARITY 64
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (MetricName, toUnixTimestamp64Nano(TimeUnix));
+`
+	// language=ClickHouse SQL
+	insertMetricsSQLTemplate = `INSERT INTO %s_%s (%s) VALUES (%s)`
+)
+
+// createMetricsTables creates a table for each metric data type.
+func createMetricsTables(cfg *Config, db *sql.DB) error {
+	for _, table := range metricsTables {
+		query := fmt.Sprintf(createMetricsTableSQL, cfg.MetricsTableName, table.suffix, table.columns,
+			renderTTL(cfg, "toDateTime(TimeUnix)"))
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("exec create metrics table sql: %w", err)
+		}
+	}
+	return nil
+}
+
+func renderInsertMetricsSQL(cfg *Config, table metricsTable) string {
+	fields := append(append([]string{}, metricsCommonFields...), table.fields...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(fields)), ",")
+	return fmt.Sprintf(insertMetricsSQLTemplate, cfg.MetricsTableName, table.suffix, strings.Join(fields, ","), placeholders)
+}
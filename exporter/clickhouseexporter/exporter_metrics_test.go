@@ -0,0 +1,219 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	exporter, err := newMetricsExporter(zap.NewNop(), withDefaultConfig())
+	require.Nil(t, exporter)
+	require.ErrorIs(t, err, errConfigNoDSN)
+}
+
+func TestMetricsExporter_createTables(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+
+	newTestMetricsExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.MetricsTableName = "metrics"
+		cfg.TTLDays = 3
+	})
+
+	require.Len(t, queries, 5)
+	for i, table := range []string{"gauge", "sum", "histogram", "exponential_histogram", "summary"} {
+		require.Contains(t, queries[i], "CREATE TABLE IF NOT EXISTS metrics_"+table+" (")
+		require.Contains(t, queries[i], "TTL toDateTime(TimeUnix) + INTERVAL 3 DAY")
+	}
+}
+
+func TestMetricsExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := map[string]int{}
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				require.Equal(t, strings.Count(query, "?"), len(values))
+				items[strings.Fields(query)[2]]++
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("skip tables without data points", func(t *testing.T) {
+		var queries []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				queries = append(queries, query)
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		m.Gauge().DataPoints().AppendEmpty().SetIntVal(42)
+		mustPushMetricsData(t, exporter, md)
+
+		require.Len(t, queries, 1)
+		require.True(t, strings.HasPrefix(queries[0], "INSERT INTO otel_metrics_gauge "))
+	})
+	t.Run("retry only the tables not written", func(t *testing.T) {
+		errInsert := errors.New("insert failed")
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics_histogram ") {
+				return errInsert
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		err := exporter.pushMetricsData(context.TODO(), simpleMetrics(1))
+		require.ErrorIs(t, err, errInsert)
+
+		var metricsErr consumererror.Metrics
+		require.True(t, errors.As(err, &metricsErr))
+		require.Equal(t, map[pmetric.MetricDataType]bool{
+			pmetric.MetricDataTypeHistogram:            true,
+			pmetric.MetricDataTypeExponentialHistogram: true,
+			pmetric.MetricDataTypeSummary:              true,
+		}, metricDataTypes(metricsErr.GetMetrics()))
+	})
+	t.Run("check values", func(t *testing.T) {
+		values := map[string][]driver.Value{}
+		initClickhouseTestServer(t, func(query string, vs []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				values[strings.Fields(query)[2]] = vs
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+
+		require.Equal(t, "gauge", values["otel_metrics_gauge"][4])
+		require.Equal(t, float64(42), values["otel_metrics_gauge"][12])
+		require.Equal(t, 1.5, values["otel_metrics_sum"][12])
+		require.Equal(t, int32(pmetric.MetricAggregationTemporalityCumulative), values["otel_metrics_sum"][13])
+		require.Equal(t, uint8(1), values["otel_metrics_sum"][14])
+		require.Equal(t, []uint64{1, 2}, values["otel_metrics_histogram"][14])
+		require.Equal(t, []float64{10}, values["otel_metrics_histogram"][15])
+		require.Equal(t, int32(2), values["otel_metrics_exponential_histogram"][14])
+		require.Equal(t, []uint64{1, 1}, values["otel_metrics_exponential_histogram"][17])
+		require.Equal(t, []float64{0.5}, values["otel_metrics_summary"][14])
+		require.Equal(t, []float64{3}, values["otel_metrics_summary"][15])
+	})
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+// simpleMetrics returns metrics with a metric of each type, each with count data points.
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "test-service")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("sum")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("exponential_histogram")
+	expHistogram.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary")
+	summary.SetDataType(pmetric.MetricDataTypeSummary)
+
+	for i := 0; i < count; i++ {
+		dp := gauge.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetIntVal(42)
+		dp.Attributes().InsertString("k", "v")
+
+		sdp := sum.Sum().DataPoints().AppendEmpty()
+		sdp.SetTimestamp(ts)
+		sdp.SetDoubleVal(1.5)
+
+		hdp := histogram.Histogram().DataPoints().AppendEmpty()
+		hdp.SetTimestamp(ts)
+		hdp.SetCount(3)
+		hdp.SetSum(12)
+		hdp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+		hdp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{10}))
+
+		edp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+		edp.SetTimestamp(ts)
+		edp.SetCount(2)
+		edp.SetScale(2)
+		edp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 1}))
+
+		qdp := summary.Summary().DataPoints().AppendEmpty()
+		qdp.SetTimestamp(ts)
+		qdp.SetCount(2)
+		qdp.SetSum(6)
+		quantile := qdp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(0.5)
+		quantile.SetValue(3)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
@@ -20,6 +20,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -142,11 +143,17 @@ func mustPushLogsData(t *testing.T, exporter *clickhouseExporter, ld plog.Logs)
 
 const testDriverName = "clickhouse-test"
 
+var (
+	testDriver         = &testClickhouseDriver{}
+	registerTestDriver sync.Once
+)
+
 func initClickhouseTestServer(_ *testing.T, recorder recorder) {
 	driverName = testDriverName
-	sql.Register(testDriverName, &testClickhouseDriver{
-		recorder: recorder,
+	registerTestDriver.Do(func() {
+		sql.Register(testDriverName, testDriver)
 	})
+	testDriver.recorder = recorder
 }
 
 type recorder func(query string, values []driver.Value) error
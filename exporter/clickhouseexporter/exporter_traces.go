@@ -0,0 +1,320 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+type tracesExporter struct {
+	client          *sql.DB
+	insertTracesSQL string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newTracesExporter(logger *zap.Logger, cfg *Config) (*tracesExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createTracesTables(cfg, client); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &tracesExporter{
+		client:          client,
+		insertTracesSQL: renderInsertTracesSQL(cfg),
+		logger:          logger,
+		cfg:             cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *tracesExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) error {
+	start := time.Now()
+	err := doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+		statement, err := tx.PrepareContext(ctx, e.insertTracesSQL)
+		if err != nil {
+			return fmt.Errorf("PrepareContext:%w", err)
+		}
+		defer func() {
+			_ = statement.Close()
+		}()
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			spans := td.ResourceSpans().At(i)
+			res := spans.Resource()
+			resourceKeys, resourceValues := attributesToSlice(res.Attributes())
+			var serviceName string
+			if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+				serviceName = v.StringVal()
+			}
+			for j := 0; j < spans.ScopeSpans().Len(); j++ {
+				rs := spans.ScopeSpans().At(j).Spans()
+				for k := 0; k < rs.Len(); k++ {
+					r := rs.At(k)
+					attrKeys, attrValues := attributesToSlice(r.Attributes())
+					events := convertEvents(r.Events())
+					links := convertLinks(r.Links())
+					_, err = statement.ExecContext(ctx,
+						r.StartTimestamp().AsTime(),
+						r.TraceID().HexString(),
+						r.SpanID().HexString(),
+						r.ParentSpanID().HexString(),
+						string(r.TraceState()),
+						r.Name(),
+						r.Kind().String(),
+						serviceName,
+						resourceKeys,
+						resourceValues,
+						attrKeys,
+						attrValues,
+						r.EndTimestamp().AsTime().Sub(r.StartTimestamp().AsTime()).Nanoseconds(),
+						r.Status().Code().String(),
+						r.Status().Message(),
+						events.timestamps,
+						events.names,
+						events.attrKeys,
+						events.attrValues,
+						links.traceIDs,
+						links.spanIDs,
+						links.traceStates,
+						links.attrKeys,
+						links.attrValues,
+					)
+					if err != nil {
+						return fmt.Errorf("ExecContext:%w", err)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	duration := time.Since(start)
+	e.logger.Debug("insert traces", zap.Int("records", td.SpanCount()),
+		zap.String("cost", duration.String()))
+	return err
+}
+
+// spanEvents holds the values of the Events nested columns of a span.
+type spanEvents struct {
+	timestamps []time.Time
+	names      []string
+	attrKeys   [][]string
+	attrValues [][]string
+}
+
+func convertEvents(events ptrace.SpanEventSlice) spanEvents {
+	var converted spanEvents
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		keys, values := attributesToSlice(event.Attributes())
+		converted.timestamps = append(converted.timestamps, event.Timestamp().AsTime())
+		converted.names = append(converted.names, event.Name())
+		converted.attrKeys = append(converted.attrKeys, keys)
+		converted.attrValues = append(converted.attrValues, values)
+	}
+	return converted
+}
+
+// spanLinks holds the values of the Links nested columns of a span.
+type spanLinks struct {
+	traceIDs    []string
+	spanIDs     []string
+	traceStates []string
+	attrKeys    [][]string
+	attrValues  [][]string
+}
+
+func convertLinks(links ptrace.SpanLinkSlice) spanLinks {
+	var converted spanLinks
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		keys, values := attributesToSlice(link.Attributes())
+		converted.traceIDs = append(converted.traceIDs, link.TraceID().HexString())
+		converted.spanIDs = append(converted.spanIDs, link.SpanID().HexString())
+		converted.traceStates = append(converted.traceStates, string(link.TraceState()))
+		converted.attrKeys = append(converted.attrKeys, keys)
+		converted.attrValues = append(converted.attrValues, values)
+	}
+	return converted
+}
+
+const (
+	// language=ClickHouse SQL
+	createTracesTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (
+     Timestamp DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TraceId String CODEC(ZSTD(1)),
+     SpanId String CODEC(ZSTD(1)),
+     ParentSpanId String CODEC(ZSTD(1)),
+     TraceState String CODEC(ZSTD(1)),
+     SpanName LowCardinality(String) CODEC(ZSTD(1)),
+     SpanKind LowCardinality(String) CODEC(ZSTD(1)),
+     ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+     ResourceAttributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     SpanAttributes Nested
+     (
+         Key LowCardinality(String),
+         Value String
+     ) CODEC(ZSTD(1)),
+     Duration Int64 CODEC(ZSTD(1)),
+     StatusCode LowCardinality(String) CODEC(ZSTD(1)),
+     StatusMessage String CODEC(ZSTD(1)),
+     Events Nested
+     (
+         Timestamp DateTime64(9),
+         Name LowCardinality(String),
+         AttributesKey Array(LowCardinality(String)),
+         AttributesValue Array(String)
+     ) CODEC(ZSTD(1)),
+     Links Nested
+     (
+         TraceId String,
+         SpanId String,
+         TraceState String,
+         AttributesKey Array(LowCardinality(String)),
+         AttributesValue Array(String)
+     ) CODEC(ZSTD(1)),
+     INDEX idx_trace_id TraceId TYPE bloom_filter(0.001) GRANULARITY 1,
+     INDEX idx_res_keys ResourceAttributes.Key TYPE bloom_filter(0.01) GRANULARITY 64,
+     INDEX idx_attr_keys SpanAttributes.Key TYPE bloom_filter(0.01) GRANULARITY 64,
+     INDEX idx_duration Duration TYPE minmax GRANULARITY 1
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(Timestamp)
+ORDER BY (ServiceName, SpanName, toUnixTimestamp(Timestamp), TraceId);
+`
+	// language=ClickHouse SQL
+	createTraceIDTsTableSQL = `
+CREATE TABLE IF NOT EXISTS %s_trace_id_ts (
+     TraceId String CODEC(ZSTD(1)),
+     Start DateTime64(9) CODEC(Delta, ZSTD(1)),
+     End DateTime64(9) CODEC(Delta, ZSTD(1)),
+     INDEX idx_trace_id TraceId TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%s
+ORDER BY (TraceId, toUnixTimestamp(Start));
+`
+	// language=ClickHouse SQL
+	createTraceIDTsMaterializedViewSQL = `
+CREATE MATERIALIZED VIEW IF NOT EXISTS %s_trace_id_ts_mv
+TO %s_trace_id_ts
+AS SELECT
+     TraceId,
+     min(Timestamp) as Start,
+     max(Timestamp) as End
+FROM %s
+WHERE TraceId != ''
+GROUP BY TraceId;
+`
+	// language=ClickHouse SQL
+	insertTracesSQLTemplate = `INSERT INTO %s (
+                        Timestamp,
+                        TraceId,
+                        SpanId,
+                        ParentSpanId,
+                        TraceState,
+                        SpanName,
+                        SpanKind,
+                        ServiceName,
+                        ResourceAttributes.Key,
+                        ResourceAttributes.Value,
+                        SpanAttributes.Key,
+                        SpanAttributes.Value,
+                        Duration,
+                        StatusCode,
+                        StatusMessage,
+                        Events.Timestamp,
+                        Events.Name,
+                        Events.AttributesKey,
+                        Events.AttributesValue,
+                        Links.TraceId,
+                        Links.SpanId,
+                        Links.TraceState,
+                        Links.AttributesKey,
+                        Links.AttributesValue
+                        ) VALUES (
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?,
+                                  ?
+                                  )`
+)
+
+// createTracesTables creates the spans table, and the table with the time range of each trace
+// filled by a materialized view, used to look up the spans of a trace by its ID.
+func createTracesTables(cfg *Config, db *sql.DB) error {
+	queries := []string{
+		fmt.Sprintf(createTracesTableSQL, cfg.TracesTableName, renderTTL(cfg, "toDateTime(Timestamp)")),
+		fmt.Sprintf(createTraceIDTsTableSQL, cfg.TracesTableName, renderTTL(cfg, "toDateTime(Start)")),
+		fmt.Sprintf(createTraceIDTsMaterializedViewSQL, cfg.TracesTableName, cfg.TracesTableName, cfg.TracesTableName),
+	}
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("exec create traces table sql: %w", err)
+		}
+	}
+	return nil
+}
+
+func renderInsertTracesSQL(cfg *Config) string {
+	return fmt.Sprintf(insertTracesSQLTemplate, cfg.TracesTableName)
+}
@@ -0,0 +1,142 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTracesExporter_New(t *testing.T) {
+	exporter, err := newTracesExporter(zap.NewNop(), withDefaultConfig())
+	require.Nil(t, exporter)
+	require.ErrorIs(t, err, errConfigNoDSN)
+}
+
+func TestTracesExporter_createTables(t *testing.T) {
+	var queries []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		queries = append(queries, query)
+		return nil
+	})
+
+	newTestTracesExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.TTLDays = 3
+	})
+
+	require.Len(t, queries, 3)
+	require.Contains(t, queries[0], "CREATE TABLE IF NOT EXISTS otel_traces (")
+	require.Contains(t, queries[0], "TTL toDateTime(Timestamp) + INTERVAL 3 DAY")
+	require.Contains(t, queries[1], "CREATE TABLE IF NOT EXISTS otel_traces_trace_id_ts (")
+	require.Contains(t, queries[1], "TTL toDateTime(Start) + INTERVAL 3 DAY")
+	require.Contains(t, queries[2], "CREATE MATERIALIZED VIEW IF NOT EXISTS otel_traces_trace_id_ts_mv")
+	require.Contains(t, queries[2], "TO otel_traces_trace_id_ts")
+}
+
+func TestTracesExporter_pushTraceData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		var items int
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			t.Logf("%d, values:%+v", items, values)
+			if strings.HasPrefix(query, "INSERT") {
+				items++
+			}
+			return nil
+		})
+
+		exporter := newTestTracesExporter(t, defaultDSN)
+		mustPushTracesData(t, exporter, simpleTraces(1))
+		mustPushTracesData(t, exporter, simpleTraces(2))
+
+		require.Equal(t, 3, items)
+	})
+	t.Run("check values", func(t *testing.T) {
+		var values []driver.Value
+		initClickhouseTestServer(t, func(query string, vs []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				values = vs
+			}
+			return nil
+		})
+
+		exporter := newTestTracesExporter(t, defaultDSN)
+		mustPushTracesData(t, exporter, simpleTraces(1))
+
+		require.Len(t, values, 24)
+		require.Equal(t, "0102030405060708090a0b0c0d0e0f10", values[1])
+		require.Equal(t, "0102030405060708", values[2])
+		require.Equal(t, "span", values[5])
+		require.Equal(t, "SPAN_KIND_SERVER", values[6])
+		require.Equal(t, "test-service", values[7])
+		require.Equal(t, time.Second.Nanoseconds(), values[12])
+		require.Equal(t, "STATUS_CODE_ERROR", values[13])
+		require.Equal(t, "error", values[14])
+		require.Equal(t, []string{"event"}, values[16])
+		require.Equal(t, [][]string{{"event_attr"}}, values[17])
+		require.Equal(t, []string{"1112131415161718191a1b1c1d1e1f20"}, values[19])
+		require.Equal(t, [][]string{{"link_attr"}}, values[22])
+	})
+}
+
+func newTestTracesExporter(t *testing.T, dsn string, fns ...func(*Config)) *tracesExporter {
+	exporter, err := newTracesExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleTraces(count int) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "test-service")
+	ss := rs.ScopeSpans().AppendEmpty()
+	for i := 0; i < count; i++ {
+		s := ss.Spans().AppendEmpty()
+		s.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		s.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		s.SetName("span")
+		s.SetKind(ptrace.SpanKindServer)
+		now := time.Now()
+		s.SetStartTimestamp(pcommon.NewTimestampFromTime(now))
+		s.SetEndTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Second)))
+		s.Status().SetCode(ptrace.StatusCodeError)
+		s.Status().SetMessage("error")
+		s.Attributes().InsertString("k", "v")
+		event := s.Events().AppendEmpty()
+		event.SetName("event")
+		event.SetTimestamp(pcommon.NewTimestampFromTime(now))
+		event.Attributes().InsertString("event.attr", "v")
+		link := s.Links().AppendEmpty()
+		link.SetTraceID(pcommon.NewTraceID([16]byte{17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}))
+		link.SetSpanID(pcommon.NewSpanID([8]byte{9, 10, 11, 12, 13, 14, 15, 16}))
+		link.Attributes().InsertString("link.attr", "v")
+	}
+	return traces
+}
+
+func mustPushTracesData(t *testing.T, exporter *tracesExporter, td ptrace.Traces) {
+	err := exporter.pushTraceData(context.TODO(), td)
+	require.NoError(t, err)
+}
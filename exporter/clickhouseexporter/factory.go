@@ -26,8 +26,11 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "clickhouse"
-	// The stability level of the exporter.
+	// The stability level of the exporter for logs.
 	stability = component.StabilityLevelAlpha
+	// The stability level of the exporter for traces and metrics.
+	tracesStability  = component.StabilityLevelInDevelopment
+	metricsStability = component.StabilityLevelInDevelopment
 )
 
 // NewFactory creates a factory for Elastic exporter.
@@ -36,6 +39,8 @@ func NewFactory() component.ExporterFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithLogsExporterAndStabilityLevel(createLogsExporter, stability),
+		component.WithTracesExporterAndStabilityLevel(createTracesExporter, tracesStability),
+		component.WithMetricsExporterAndStabilityLevel(createMetricsExporter, metricsStability),
 	)
 }
 
@@ -46,6 +51,8 @@ func createDefaultConfig() config.Exporter {
 		QueueSettings:    QueueSettings{QueueSize: exporterhelper.NewDefaultQueueSettings().QueueSize},
 		RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
 		LogsTableName:    "otel_logs",
+		TracesTableName:  "otel_traces",
+		MetricsTableName: "otel_metrics",
 	}
 }
 
@@ -72,3 +79,51 @@ func createLogsExporter(
 		exporterhelper.WithRetry(c.RetrySettings),
 	)
 }
+
+// createTracesExporter creates a new exporter for traces.
+// Spans are directly insert into clickhouse.
+func createTracesExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	c := cfg.(*Config)
+	exporter, err := newTracesExporter(set.Logger, c)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure clickhouse traces exporter: %w", err)
+	}
+
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		exporter.pushTraceData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+		exporterhelper.WithTimeout(c.TimeoutSettings),
+		exporterhelper.WithQueue(c.enforcedQueueSettings()),
+		exporterhelper.WithRetry(c.RetrySettings),
+	)
+}
+
+// createMetricsExporter creates a new exporter for metrics.
+// Data points are directly insert into the clickhouse table of their metric type.
+func createMetricsExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	c := cfg.(*Config)
+	exporter, err := newMetricsExporter(set.Logger, c)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure clickhouse metrics exporter: %w", err)
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+		exporterhelper.WithTimeout(c.TimeoutSettings),
+		exporterhelper.WithQueue(c.enforcedQueueSettings()),
+		exporterhelper.WithRetry(c.RetrySettings),
+	)
+}
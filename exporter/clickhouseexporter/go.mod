@@ -5,7 +5,6 @@ go 1.17
 require (
 	go.opentelemetry.io/collector v0.56.0
 	go.uber.org/zap v1.21.0
-
 )
 
 require go.uber.org/multierr v1.8.0
@@ -14,6 +13,7 @@ require (
 	github.com/ClickHouse/clickhouse-go v1.5.4
 	github.com/stretchr/testify v1.8.0
 	go.opentelemetry.io/collector/pdata v0.56.0
+	go.opentelemetry.io/collector/semconv v0.56.0
 )
 
 require (
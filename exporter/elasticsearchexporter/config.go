@@ -52,6 +52,19 @@ type Config struct {
 	// This setting is required.
 	Index string `mapstructure:"index"`
 
+	// LogsDynamicIndex configures whether the index of the logs is built from the
+	// prefix and suffix held by their attributes.
+	LogsDynamicIndex DynamicIndexSetting `mapstructure:"logs_dynamic_index"`
+
+	// TracesIndex configures the index, index alias, or data stream name spans should be indexed in.
+	//
+	// This setting is required when the exporter is used in a traces pipeline.
+	TracesIndex string `mapstructure:"traces_index"`
+
+	// TracesDynamicIndex configures whether the index of the spans is built from the
+	// prefix and suffix held by their attributes.
+	TracesDynamicIndex DynamicIndexSetting `mapstructure:"traces_dynamic_index"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -65,6 +78,13 @@ type Config struct {
 	Mapping            MappingsSettings  `mapstructure:"mapping"`
 }
 
+// DynamicIndexSetting configures the dynamic index naming. When enabled, the index is prefixed and suffixed
+// with the values of the `elasticsearch.index.prefix` and `elasticsearch.index.suffix` attributes,
+// looked up in the attributes of the record, then of its resource.
+type DynamicIndexSetting struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
 type HTTPClientSettings struct {
 	Authentication AuthenticationSettings `mapstructure:",squash"`
 
@@ -172,6 +192,7 @@ var (
 	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
 	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
 	errConfigNoIndex       = errors.New("index must be specified")
+	errConfigNoTracesIndex = errors.New("traces_index must be specified")
 )
 
 func (m MappingMode) String() string {
@@ -49,7 +49,14 @@ func TestLoadConfig(t *testing.T) {
 		Endpoints:        []string{"https://elastic.example.com:9200"},
 		CloudID:          "TRNMxjXlNJEt",
 		Index:            "myindex",
-		Pipeline:         "mypipeline",
+		LogsDynamicIndex: DynamicIndexSetting{
+			Enabled: true,
+		},
+		TracesIndex: "mytracesindex",
+		TracesDynamicIndex: DynamicIndexSetting{
+			Enabled: true,
+		},
+		Pipeline: "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
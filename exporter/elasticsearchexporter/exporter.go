@@ -31,6 +31,7 @@ import (
 	esutil7 "github.com/elastic/go-elasticsearch/v7/esutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -46,8 +47,9 @@ type esBulkIndexerResponseItem = esutil7.BulkIndexerResponseItem
 type elasticsearchExporter struct {
 	logger *zap.Logger
 
-	index       string
-	maxAttempts int
+	index        string
+	dynamicIndex bool
+	maxAttempts  int
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
@@ -58,7 +60,27 @@ var retryOnStatus = []int{500, 502, 503, 504, 429}
 
 const createAction = "create"
 
+const (
+	// indexPrefix and indexSuffix are the attributes holding the prefix and suffix of the dynamic index.
+	indexPrefix = "elasticsearch.index.prefix"
+	indexSuffix = "elasticsearch.index.suffix"
+)
+
+// newExporter creates an exporter indexing the logs.
 func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error) {
+	return newElasticsearchExporter(logger, cfg, cfg.Index, cfg.LogsDynamicIndex.Enabled)
+}
+
+// newTracesExporter creates an exporter indexing the spans.
+func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error) {
+	// the traces index is only checked here, so that exporters used for logs only don't need one
+	if cfg.TracesIndex == "" {
+		return nil, errConfigNoTracesIndex
+	}
+	return newElasticsearchExporter(logger, cfg, cfg.TracesIndex, cfg.TracesDynamicIndex.Enabled)
+}
+
+func newElasticsearchExporter(logger *zap.Logger, cfg *Config, index string, dynamicIndex bool) (*elasticsearchExporter, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
@@ -86,9 +108,10 @@ func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.Index,
-		maxAttempts: maxAttempts,
-		model:       model,
+		index:        index,
+		dynamicIndex: dynamicIndex,
+		maxAttempts:  maxAttempts,
+		model:        model,
 	}, nil
 }
 
@@ -126,13 +149,65 @@ func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pcom
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return e.pushEvent(ctx, document)
+	return e.pushEvent(ctx, e.indexFor(record.Attributes(), resource.Attributes()), document)
+}
+
+func (e *elasticsearchExporter) pushTraceData(ctx context.Context, td ptrace.Traces) error {
+	var errs []error
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource()
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if err := e.pushTraceRecord(ctx, resource, spans.At(k)); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, span ptrace.Span) error {
+	document, err := e.model.encodeSpan(resource, span)
+	if err != nil {
+		return fmt.Errorf("Failed to encode trace record: %w", err)
+	}
+	return e.pushEvent(ctx, e.indexFor(span.Attributes(), resource.Attributes()), document)
+}
+
+// indexFor returns the index of a record. With the dynamic index, the index is prefixed and suffixed with
+// the values of the attributes, looked up in the given maps in order.
+func (e *elasticsearchExporter) indexFor(attributes ...pcommon.Map) string {
+	if !e.dynamicIndex {
+		return e.index
+	}
+	return getFromAttributes(indexPrefix, attributes...) + e.index + getFromAttributes(indexSuffix, attributes...)
+}
+
+// getFromAttributes returns the value of the attribute in the first map holding it.
+func getFromAttributes(name string, attributes ...pcommon.Map) string {
+	for _, attrs := range attributes {
+		if value, ok := attrs.Get(name); ok {
+			return value.AsString()
+		}
+	}
+	return ""
 }
 
-func (e *elasticsearchExporter) pushEvent(ctx context.Context, document []byte) error {
+func (e *elasticsearchExporter) pushEvent(ctx context.Context, index string, document []byte) error {
 	attempts := 1
 	body := bytes.NewReader(document)
-	item := esBulkIndexerItem{Action: createAction, Index: e.index, Body: body}
+	item := esBulkIndexerItem{Action: createAction, Index: index, Body: body}
 
 	// Setup error handler. The handler handles the per item response status based on the
 	// selective ACKing in the bulk response.
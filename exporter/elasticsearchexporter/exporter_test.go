@@ -27,6 +27,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -90,6 +93,13 @@ func TestExporter_New(t *testing.T) {
 			}),
 			want: failWithMessage("cannot parse CloudID"),
 		},
+		"no traces index for logs": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.TracesIndex = ""
+			}),
+			want: success,
+		},
 		"fail if endpoint and cloudid are set": {
 			config: withDefaultConfig(func(cfg *Config) {
 				cfg.Endpoints = []string{"test:9200"}
@@ -302,6 +312,137 @@ func TestExporter_PushEvent(t *testing.T) {
 	})
 }
 
+func TestExporter_PushTraceData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+	t.Run("publish with success", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestTracesExporter(t, server.URL)
+		mustSendTraces(t, exporter, newTestTraces("span1", "span2"))
+
+		rec.WaitItems(2)
+		for _, item := range rec.Items() {
+			assert.JSONEq(t, `{"create":{"_index":"traces-generic-default"}}`, string(item.Action))
+		}
+	})
+
+	t.Run("encode span with events and links", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestTracesExporter(t, server.URL)
+		mustSendTraces(t, exporter, newTestTraces("span1"))
+
+		rec.WaitItems(1)
+		assert.JSONEq(t, `{
+			"@timestamp": "2022-08-01T10:00:00.000000000Z",
+			"EndTimestamp": "2022-08-01T10:00:01.000000000Z",
+			"TraceId": "0102030405060708090a0b0c0d0e0f10",
+			"SpanId": "0102030405060708",
+			"Name": "span1",
+			"Kind": "SPAN_KIND_SERVER",
+			"Duration": 1000000000,
+			"StatusCode": "STATUS_CODE_ERROR",
+			"StatusMessage": "failure",
+			"Attributes.http.method": "GET",
+			"Resource.service.name": "checkout",
+			"Events": [
+				{"Timestamp": "2022-08-01T10:00:00.500000000Z", "Name": "exception", "Attributes": {"exception": {"type": "Error"}}}
+			],
+			"Links": [
+				{"TraceId": "1112131415161718191a1b1c1d1e1f20", "SpanId": "1112131415161718", "Attributes": {"reason": "retry"}}
+			]
+		}`, string(rec.Items()[0].Document))
+	})
+
+	t.Run("retry single item", func(t *testing.T) {
+		var attempts int
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			attempts++
+
+			if attempts == 1 {
+				return itemsReportStatus(docs, http.StatusTooManyRequests)
+			}
+
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestTracesExporter(t, server.URL)
+		mustSendTraces(t, exporter, newTestTraces("span1"))
+
+		rec.WaitItems(1)
+	})
+
+	t.Run("publish with dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestTracesExporter(t, server.URL, func(cfg *Config) {
+			cfg.TracesDynamicIndex.Enabled = true
+		})
+		traces := newTestTraces("span1", "span2")
+		rs := traces.ResourceSpans().At(0)
+		rs.Resource().Attributes().InsertString(indexPrefix, "resource-")
+		rs.Resource().Attributes().InsertString(indexSuffix, "-resource")
+		// the attributes of the span take precedence over the resource attributes
+		rs.ScopeSpans().At(0).Spans().At(1).Attributes().InsertString(indexSuffix, "-span")
+		mustSendTraces(t, exporter, traces)
+
+		rec.WaitItems(2)
+		var indices []string
+		for _, item := range rec.Items() {
+			var action struct {
+				Create struct {
+					Index string `json:"_index"`
+				} `json:"create"`
+			}
+			require.NoError(t, json.Unmarshal(item.Action, &action))
+			indices = append(indices, action.Create.Index)
+		}
+		assert.ElementsMatch(t, []string{
+			"resource-traces-generic-default-resource",
+			"resource-traces-generic-default-span",
+		}, indices)
+	})
+}
+
+func TestExporter_PushLogsDataDynamicIndex(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+	rec := newBulkRecorder()
+	server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+		rec.Record(docs)
+		return itemsAllOK(docs)
+	})
+
+	exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.LogsDynamicIndex.Enabled = true
+	})
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(indexPrefix, "team-")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString(indexSuffix, "-2022.08")
+	require.NoError(t, exporter.pushLogsData(context.TODO(), logs))
+
+	rec.WaitItems(1)
+	assert.JSONEq(t, `{"create":{"_index":"team-logs-generic-default-2022.08"}}`, string(rec.Items()[0].Action))
+}
+
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchExporter {
 	exporter, err := newExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
 	require.NoError(t, err)
@@ -323,7 +464,52 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 	}
 }
 
+func newTestTracesExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchExporter {
+	exporter, err := newTracesExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+// newTestTraces creates spans with the given names, each with an event and a link.
+func newTestTraces(names ...string) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	start := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	for _, name := range names {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		span.SetName(name)
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("failure")
+		span.Attributes().InsertString("http.method", "GET")
+
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(500 * time.Millisecond)))
+		event.Attributes().InsertString("exception.type", "Error")
+
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(pcommon.NewTraceID([16]byte{17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}))
+		link.SetSpanID(pcommon.NewSpanID([8]byte{17, 18, 19, 20, 21, 22, 23, 24}))
+		link.Attributes().InsertString("reason", "retry")
+	}
+	return traces
+}
+
+func mustSendTraces(t *testing.T, exporter *elasticsearchExporter, td ptrace.Traces) {
+	err := exporter.pushTraceData(context.TODO(), td)
+	require.NoError(t, err)
+}
+
 func mustSend(t *testing.T, exporter *elasticsearchExporter, contents string) {
-	err := exporter.pushEvent(context.TODO(), []byte(contents))
+	err := exporter.pushEvent(context.TODO(), exporter.index, []byte(contents))
 	require.NoError(t, err)
 }
@@ -29,6 +29,8 @@ const (
 	typeStr = "elasticsearch"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+	// The stability level of the exporter for traces.
+	tracesStability = component.StabilityLevelInDevelopment
 )
 
 // NewFactory creates a factory for Elastic exporter.
@@ -37,6 +39,7 @@ func NewFactory() component.ExporterFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithLogsExporterAndStabilityLevel(createLogsExporter, stability),
+		component.WithTracesExporterAndStabilityLevel(createTracesExporter, tracesStability),
 	)
 }
 
@@ -46,7 +49,8 @@ func createDefaultConfig() config.Exporter {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index: "logs-generic-default",
+		Index:       "logs-generic-default",
+		TracesIndex: "traces-generic-default",
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -81,3 +85,24 @@ func createLogsExporter(
 		exporterhelper.WithShutdown(exporter.Shutdown),
 	)
 }
+
+// createTracesExporter creates a new exporter for traces.
+//
+// Spans are directly indexed into Elasticsearch.
+func createTracesExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	exporter, err := newTracesExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch traces exporter: %w", err)
+	}
+
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		exporter.pushTraceData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
@@ -44,6 +44,36 @@ func TestFactory_CreateLogsExporter(t *testing.T) {
 	require.NoError(t, exporter.Shutdown(context.TODO()))
 }
 
+func TestFactory_CreateTracesExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+	})
+	params := componenttest.NewNopExporterCreateSettings()
+	exporter, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+
+	require.NoError(t, exporter.Shutdown(context.TODO()))
+}
+
+func TestFactory_CreateTracesExporter_NoTracesIndex(t *testing.T) {
+	factory := NewFactory()
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+		cfg.TracesIndex = ""
+	})
+	params := componenttest.NewNopExporterCreateSettings()
+	_, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.ErrorIs(t, err, errConfigNoTracesIndex)
+
+	// exporters used for logs only don't need a traces index
+	exporter, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+	require.NoError(t, exporter.Shutdown(context.TODO()))
+}
+
 func TestFactory_CreateMetricsExporter_Fail(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -430,6 +430,23 @@ func TestValue_Serialize(t *testing.T) {
 			}(),
 			want: `{"a":"b"}`,
 		},
+		"object value": {
+			value: func() Value {
+				doc := Document{}
+				doc.AddInt("a", 1)
+				return ObjectValue(doc)
+			}(),
+			want: `{"a":1}`,
+		},
+		"array of objects": {
+			value: func() Value {
+				var first, second Document
+				first.AddString("a", "b")
+				second.AddString("a", "c")
+				return ArrValue(ObjectValue(first), ObjectValue(second))
+			}(),
+			want: `[{"a":"b"},{"a":"c"}]`,
+		},
 		"empty object": {
 			value: Value{kind: KindObject, doc: Document{}},
 			want:  "null",
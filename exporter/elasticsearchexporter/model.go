@@ -19,12 +19,14 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
 )
 
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
+	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -50,6 +52,59 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
 
+	return m.serialize(&document)
+}
+
+// encodeSpan encodes the span as a document, with its events and links as arrays of nested objects.
+func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream template is used.
+	document.AddTimestamp("EndTimestamp", span.EndTimestamp())
+	document.AddID("TraceId", span.TraceID())
+	document.AddID("SpanId", span.SpanID())
+	document.AddID("ParentSpanId", span.ParentSpanID())
+	document.AddString("TraceState", string(span.TraceState()))
+	document.AddString("Name", span.Name())
+	document.AddString("Kind", span.Kind().String())
+	document.AddInt("Duration", int64(span.EndTimestamp()-span.StartTimestamp()))
+	document.AddString("StatusCode", span.Status().Code().String())
+	document.AddString("StatusMessage", span.Status().Message())
+	document.AddAttributes("Attributes", span.Attributes())
+	document.AddAttributes("Resource", resource.Attributes())
+	document.Add("Events", encodeSpanEvents(span.Events()))
+	document.Add("Links", encodeSpanLinks(span.Links()))
+
+	return m.serialize(&document)
+}
+
+func encodeSpanEvents(events ptrace.SpanEventSlice) objmodel.Value {
+	values := make([]objmodel.Value, 0, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		var document objmodel.Document
+		document.AddTimestamp("Timestamp", event.Timestamp())
+		document.AddString("Name", event.Name())
+		document.AddAttributes("Attributes", event.Attributes())
+		values = append(values, objmodel.ObjectValue(document))
+	}
+	return objmodel.ArrValue(values...)
+}
+
+func encodeSpanLinks(links ptrace.SpanLinkSlice) objmodel.Value {
+	values := make([]objmodel.Value, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		var document objmodel.Document
+		document.AddID("TraceId", link.TraceID())
+		document.AddID("SpanId", link.SpanID())
+		document.AddString("TraceState", string(link.TraceState()))
+		document.AddAttributes("Attributes", link.Attributes())
+		values = append(values, objmodel.ObjectValue(document))
+	}
+	return objmodel.ArrValue(values...)
+}
+
+func (m *encodeModel) serialize(document *objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -16,16 +16,62 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// formatTypeJSON writes each message as a line of Protobuf-JSON.
+	formatTypeJSON = "json"
+	// formatTypeProto writes each message as Protobuf, prefixed with its size as a varint.
+	formatTypeProto = "proto"
+
+	// compressionGzip compresses each message as a gzip member.
+	compressionGzip = "gzip"
+	// compressionZstd compresses each message as a zstd frame.
+	compressionZstd = "zstd"
+)
+
 // Config defines configuration for file exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// Path of the file to write to. Path is relative to current directory.
 	Path string `mapstructure:"path"`
+
+	// Rotation defines an option about rotation of telemetry files. The file is never rotated when it is not set.
+	Rotation *Rotation `mapstructure:"rotation"`
+
+	// FormatType defines the data format of the encoded telemetry data: "json" (default) or "proto".
+	FormatType string `mapstructure:"format"`
+
+	// Compression defines the compression algorithm of each exported message: "gzip" or "zstd".
+	// No compression is applied by default.
+	Compression string `mapstructure:"compression"`
+}
+
+// Rotation defines the rotation of the file, and the retention of the rotated files.
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of the file before it gets rotated. It defaults to 100 megabytes.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxDays is the maximum number of days to retain the rotated files. Rotated files are not removed
+	// based on their age by default.
+	MaxDays int `mapstructure:"max_days"`
+
+	// MaxBackups is the maximum number of rotated files to retain. All the rotated files are retained by default,
+	// although MaxDays may still cause them to be removed.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// Interval is the period after which the file is rotated, regardless of its size. The file is only rotated
+	// based on its size by default.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// LocalTime determines whether the timestamps in the names of the rotated files use the local time
+	// instead of UTC.
+	LocalTime bool `mapstructure:"localtime"`
 }
 
 var _ config.Exporter = (*Config)(nil)
@@ -36,5 +82,32 @@ func (cfg *Config) Validate() error {
 		return errors.New("path must be non-empty")
 	}
 
+	switch cfg.FormatType {
+	case "", formatTypeJSON, formatTypeProto:
+	default:
+		return fmt.Errorf("format type %q is not supported, must be either %q or %q", cfg.FormatType, formatTypeJSON, formatTypeProto)
+	}
+
+	switch cfg.Compression {
+	case "", compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("compression %q is not supported, must be either %q or %q", cfg.Compression, compressionGzip, compressionZstd)
+	}
+
+	if cfg.Rotation != nil {
+		if cfg.Rotation.MaxMegabytes < 0 {
+			return errors.New("the maximum size of the file must be positive")
+		}
+		if cfg.Rotation.MaxDays < 0 {
+			return errors.New("the maximum number of days to retain the rotated files must be positive")
+		}
+		if cfg.Rotation.MaxBackups < 0 {
+			return errors.New("the maximum number of rotated files to retain must be positive")
+		}
+		if cfg.Rotation.Interval < 0 {
+			return errors.New("the rotation interval must be positive")
+		}
+	}
+
 	return nil
 }
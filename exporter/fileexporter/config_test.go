@@ -17,6 +17,7 @@ package fileexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,5 +44,80 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
 			Path:             "./filename.json",
+			FormatType:       formatTypeJSON,
 		})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+			Path:             "./filename.pb",
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				MaxDays:      7,
+				MaxBackups:   3,
+				Interval:     time.Hour,
+				LocalTime:    true,
+			},
+			FormatType:  formatTypeProto,
+			Compression: compressionZstd,
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg: &Config{
+				Path:        "./filename.pb",
+				Rotation:    &Rotation{MaxMegabytes: 10, Interval: time.Hour},
+				FormatType:  formatTypeProto,
+				Compression: compressionGzip,
+			},
+		},
+		{
+			name:    "unsupported format",
+			cfg:     &Config{Path: "./filename", FormatType: "xml"},
+			wantErr: `format type "xml" is not supported, must be either "json" or "proto"`,
+		},
+		{
+			name:    "unsupported compression",
+			cfg:     &Config{Path: "./filename", Compression: "lz4"},
+			wantErr: `compression "lz4" is not supported, must be either "gzip" or "zstd"`,
+		},
+		{
+			name:    "negative size",
+			cfg:     &Config{Path: "./filename", Rotation: &Rotation{MaxMegabytes: -1}},
+			wantErr: "the maximum size of the file must be positive",
+		},
+		{
+			name:    "negative days",
+			cfg:     &Config{Path: "./filename", Rotation: &Rotation{MaxDays: -1}},
+			wantErr: "the maximum number of days to retain the rotated files must be positive",
+		},
+		{
+			name:    "negative backups",
+			cfg:     &Config{Path: "./filename", Rotation: &Rotation{MaxBackups: -1}},
+			wantErr: "the maximum number of rotated files to retain must be positive",
+		},
+		{
+			name:    "negative interval",
+			cfg:     &Config{Path: "./filename", Rotation: &Rotation{Interval: -time.Second}},
+			wantErr: "the rotation interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
 }
@@ -44,6 +44,7 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		FormatType:       formatTypeJSON,
 	}
 }
 
@@ -53,7 +54,7 @@ func createTracesExporter(
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(cfg.(*Config))
 	})
 	return exporterhelper.NewTracesExporter(
 		cfg,
@@ -70,7 +71,7 @@ func createMetricsExporter(
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(cfg.(*Config))
 	})
 	return exporterhelper.NewMetricsExporter(
 		cfg,
@@ -87,7 +88,7 @@ func createLogsExporter(
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(cfg.(*Config))
 	})
 	return exporterhelper.NewLogsExporter(
 		cfg,
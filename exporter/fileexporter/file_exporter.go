@@ -16,28 +16,74 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"encoding/binary"
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// Marshaler configuration used for marhsaling Protobuf to JSON.
-var tracesMarshaler = ptrace.NewJSONMarshaler()
-var metricsMarshaler = pmetric.NewJSONMarshaler()
-var logsMarshaler = plog.NewJSONMarshaler()
+// Marshalers used for marshaling the telemetry data, for each format type.
+var tracesMarshalers = map[string]ptrace.Marshaler{
+	formatTypeJSON:  ptrace.NewJSONMarshaler(),
+	formatTypeProto: ptrace.NewProtoMarshaler(),
+}
+var metricsMarshalers = map[string]pmetric.Marshaler{
+	formatTypeJSON:  pmetric.NewJSONMarshaler(),
+	formatTypeProto: pmetric.NewProtoMarshaler(),
+}
+var logsMarshalers = map[string]plog.Marshaler{
+	formatTypeJSON:  plog.NewJSONMarshaler(),
+	formatTypeProto: plog.NewProtoMarshaler(),
+}
+
+// exportFunc writes a message to the file.
+type exportFunc func(e *fileExporter, buf []byte) error
 
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// in Protobuf-JSON format, or in Protobuf format.
 type fileExporter struct {
 	path  string
 	file  io.WriteCloser
 	mutex sync.Mutex
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+
+	compressor compressFunc
+	exporter   exportFunc
+
+	rotation *Rotation
+	stopCh   chan struct{}
+	stopWg   sync.WaitGroup
+}
+
+func newFileExporter(conf *Config) *fileExporter {
+	formatType := conf.FormatType
+	if formatType == "" {
+		formatType = formatTypeJSON
+	}
+	exporter := exportMessageAsLine
+	if formatType == formatTypeProto {
+		exporter = exportMessageAsBuffer
+	}
+	return &fileExporter{
+		path:             conf.Path,
+		tracesMarshaler:  tracesMarshalers[formatType],
+		metricsMarshaler: metricsMarshalers[formatType],
+		logsMarshaler:    logsMarshalers[formatType],
+		compressor:       buildCompressor(conf.Compression),
+		exporter:         exporter,
+		rotation:         conf.Rotation,
+	}
 }
 
 func (e *fileExporter) Capabilities() consumer.Capabilities {
@@ -45,49 +91,110 @@ func (e *fileExporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
-	buf, err := tracesMarshaler.MarshalTraces(td)
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.exporter(e, buf)
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
-	buf, err := metricsMarshaler.MarshalMetrics(md)
+	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.exporter(e, buf)
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
-	buf, err := logsMarshaler.MarshalLogs(ld)
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.exporter(e, buf)
 }
 
 func exportMessageAsLine(e *fileExporter, buf []byte) error {
+	line := make([]byte, 0, len(buf)+1)
+	line = append(line, buf...)
+	line = append(line, '\n')
+	return e.write(line)
+}
+
+// exportMessageAsBuffer writes the message prefixed with its size as a varint, the same way as
+// the length-delimited messages written by the Protobuf libraries.
+func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
+	data := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(buf))
+	n := binary.PutUvarint(data, uint64(len(buf)))
+	data = append(data[:n], buf...)
+	return e.write(data)
+}
+
+// write compresses the message when configured, and writes it to the file at once,
+// so that a message is never split across rotated files.
+func (e *fileExporter) write(data []byte) error {
+	var err error
+	if e.compressor != nil {
+		if data, err = e.compressor(data); err != nil {
+			return err
+		}
+	}
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	_, err = e.file.Write(data)
+	return err
+}
+
+func (e *fileExporter) Start(context.Context, component.Host) error {
+	if e.rotation == nil {
+		var err error
+		e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
-		return err
+
+	logger := &lumberjack.Logger{
+		Filename:   e.path,
+		MaxSize:    e.rotation.MaxMegabytes,
+		MaxAge:     e.rotation.MaxDays,
+		MaxBackups: e.rotation.MaxBackups,
+		LocalTime:  e.rotation.LocalTime,
+	}
+	e.file = logger
+	if e.rotation.Interval > 0 {
+		e.stopCh = make(chan struct{})
+		e.stopWg.Add(1)
+		go e.rotateEvery(logger, e.rotation.Interval)
 	}
 	return nil
 }
 
-func (e *fileExporter) Start(context.Context, component.Host) error {
-	var err error
-	e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	return err
+// rotateEvery rotates the file periodically, until the exporter is shut down.
+func (e *fileExporter) rotateEvery(logger *lumberjack.Logger, interval time.Duration) {
+	defer e.stopWg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.mutex.Lock()
+			// the error would be reported by the next write as well
+			_ = logger.Rotate()
+			e.mutex.Unlock()
+		case <-e.stopCh:
+			return
+		}
+	}
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (e *fileExporter) Shutdown(context.Context) error {
+	if e.stopCh != nil {
+		close(e.stopCh)
+		e.stopWg.Wait()
+	}
+	if e.file == nil {
+		return nil
+	}
 	return e.file.Close()
 }
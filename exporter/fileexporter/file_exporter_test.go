@@ -14,12 +14,19 @@
 package fileexporter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -31,7 +38,7 @@ import (
 )
 
 func TestFileTracesExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(&Config{Path: tempFileName(t)})
 	require.NotNil(t, fe)
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
@@ -49,8 +56,9 @@ func TestFileTracesExporter(t *testing.T) {
 
 func TestFileTracesExporterError(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(&Config{})
 	require.NotNil(t, fe)
+	fe.file = mf
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
 	// Cannot call Start since we inject directly the WriterCloser.
@@ -59,7 +67,7 @@ func TestFileTracesExporterError(t *testing.T) {
 }
 
 func TestFileMetricsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(&Config{Path: tempFileName(t)})
 	require.NotNil(t, fe)
 
 	md := testdata.GenerateMetricsTwoMetrics()
@@ -77,8 +85,9 @@ func TestFileMetricsExporter(t *testing.T) {
 
 func TestFileMetricsExporterError(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(&Config{})
 	require.NotNil(t, fe)
+	fe.file = mf
 
 	md := testdata.GenerateMetricsTwoMetrics()
 	// Cannot call Start since we inject directly the WriterCloser.
@@ -87,7 +96,7 @@ func TestFileMetricsExporterError(t *testing.T) {
 }
 
 func TestFileLogsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(&Config{Path: tempFileName(t)})
 	require.NotNil(t, fe)
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
@@ -105,8 +114,9 @@ func TestFileLogsExporter(t *testing.T) {
 
 func TestFileLogsExporterErrors(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(&Config{})
 	require.NotNil(t, fe)
+	fe.file = mf
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
 	// Cannot call Start since we inject directly the WriterCloser.
@@ -114,6 +124,159 @@ func TestFileLogsExporterErrors(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 }
 
+func TestFileExporterProtoFormat(t *testing.T) {
+	fe := newFileExporter(&Config{Path: tempFileName(t), FormatType: formatTypeProto})
+	require.NotNil(t, fe)
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	md := testdata.GenerateMetricsTwoMetrics()
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.ConsumeMetrics(context.Background(), md))
+	assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	buf, err := ioutil.ReadFile(fe.path)
+	assert.NoError(t, err)
+	messages := readLengthDelimited(t, buf)
+	require.Len(t, messages, 3)
+
+	gotTraces, err := ptrace.NewProtoUnmarshaler().UnmarshalTraces(messages[0])
+	assert.NoError(t, err)
+	assert.EqualValues(t, td, gotTraces)
+	gotMetrics, err := pmetric.NewProtoUnmarshaler().UnmarshalMetrics(messages[1])
+	assert.NoError(t, err)
+	assert.EqualValues(t, md, gotMetrics)
+	gotLogs, err := plog.NewProtoUnmarshaler().UnmarshalLogs(messages[2])
+	assert.NoError(t, err)
+	assert.EqualValues(t, ld, gotLogs)
+}
+
+func TestFileExporterCompression(t *testing.T) {
+	tests := []struct {
+		compression string
+		decompress  func(t *testing.T, buf []byte) []byte
+	}{
+		{
+			compression: compressionGzip,
+			decompress: func(t *testing.T, buf []byte) []byte {
+				r, err := gzip.NewReader(bytes.NewReader(buf))
+				require.NoError(t, err)
+				decompressed, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				return decompressed
+			},
+		},
+		{
+			compression: compressionZstd,
+			decompress: func(t *testing.T, buf []byte) []byte {
+				r, err := zstd.NewReader(bytes.NewReader(buf))
+				require.NoError(t, err)
+				defer r.Close()
+				decompressed, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				return decompressed
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.compression, func(t *testing.T) {
+			fe := newFileExporter(&Config{Path: tempFileName(t), Compression: tt.compression})
+			require.NotNil(t, fe)
+
+			ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+			assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+			assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+			assert.NoError(t, fe.Shutdown(context.Background()))
+
+			buf, err := ioutil.ReadFile(fe.path)
+			assert.NoError(t, err)
+			// each message is compressed on its own, the file is decompressed as a whole
+			lines := bytes.Split(bytes.TrimSpace(tt.decompress(t, buf)), []byte("\n"))
+			require.Len(t, lines, 2)
+			for _, line := range lines {
+				got, err := plog.NewJSONUnmarshaler().UnmarshalLogs(line)
+				assert.NoError(t, err)
+				assert.EqualValues(t, ld, got)
+			}
+		})
+	}
+}
+
+func TestFileExporterRotationBySize(t *testing.T) {
+	dir := t.TempDir()
+	fe := newFileExporter(&Config{
+		Path:     filepath.Join(dir, "telemetry.json"),
+		Rotation: &Rotation{MaxMegabytes: 1, MaxBackups: 2},
+	})
+	require.NotNil(t, fe)
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	// each message is about half a megabyte
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().
+		Body().SetStringVal(strings.Repeat("a", 512*1024))
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	}
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	// the retention of the rotated files is applied in the background
+	assert.Eventually(t, func() bool {
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		return len(files) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	for _, file := range files {
+		assert.LessOrEqual(t, file.Size(), int64(1024*1024))
+		buf, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		require.NoError(t, err)
+		// messages are never split across the files
+		for _, line := range bytes.Split(bytes.TrimSpace(buf), []byte("\n")) {
+			got, err := plog.NewJSONUnmarshaler().UnmarshalLogs(line)
+			assert.NoError(t, err)
+			assert.EqualValues(t, ld, got)
+		}
+	}
+}
+
+func TestFileExporterRotationByInterval(t *testing.T) {
+	dir := t.TempDir()
+	fe := newFileExporter(&Config{
+		Path:     filepath.Join(dir, "telemetry.json"),
+		Rotation: &Rotation{Interval: 10 * time.Millisecond},
+	})
+	require.NotNil(t, fe)
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	assert.Eventually(t, func() bool {
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		return len(files) > 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, fe.Shutdown(context.Background()))
+}
+
+// readLengthDelimited splits the buffer into the messages prefixed with their size as a varint.
+func readLengthDelimited(t *testing.T, buf []byte) [][]byte {
+	var messages [][]byte
+	for len(buf) > 0 {
+		size, n := binary.Uvarint(buf)
+		require.Greater(t, n, 0)
+		require.GreaterOrEqual(t, uint64(len(buf)-n), size)
+		messages = append(messages, buf[n:n+int(size)])
+		buf = buf[n+int(size):]
+	}
+	return messages
+}
+
 // tempFileName provides a temporary file name for testing.
 func tempFileName(t *testing.T) string {
 	tmpfile, err := ioutil.TempFile("", "*.json")
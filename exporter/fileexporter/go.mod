@@ -3,11 +3,13 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileex
 go 1.17
 
 require (
+	github.com/klauspost/compress v1.15.9
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.56.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent v0.56.0
 	github.com/stretchr/testify v1.8.0
 	go.opentelemetry.io/collector v0.56.0
 	go.opentelemetry.io/collector/pdata v0.56.0
+	gopkg.in/natefinch/lumberjack.v2 v2.0.0
 )
 
 require (
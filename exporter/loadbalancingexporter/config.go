@@ -15,8 +15,13 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 // Config defines configuration for the exporter.
@@ -24,6 +29,36 @@ type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
+
+	// RoutingKey determines how the data points of the metrics are distributed among the backends:
+	// by their resource attributes ("resource", the default), or by their metric name and attributes ("metric").
+	RoutingKey string `mapstructure:"routing_key"`
+}
+
+const (
+	// resourceRoutingKey routes the metrics by the attributes of their resource.
+	resourceRoutingKey = "resource"
+	// metricRoutingKey routes the data points by their resource attributes, metric name and attributes.
+	metricRoutingKey = "metric"
+)
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	// the service account is used when no auth_type is set
+	if cfg.Resolver.K8s != nil && cfg.Resolver.K8s.AuthType != "" {
+		if err := cfg.Resolver.K8s.APIConfig.Validate(); err != nil {
+			return err
+		}
+	}
+
+	switch cfg.RoutingKey {
+	case "", resourceRoutingKey, metricRoutingKey:
+		return nil
+	default:
+		return fmt.Errorf("unsupported routing_key %q, must be either %q or %q", cfg.RoutingKey, resourceRoutingKey, metricRoutingKey)
+	}
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -35,6 +70,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8s    *K8sResolver    `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -42,6 +78,29 @@ type StaticResolver struct {
 	Hostnames []string `mapstructure:"hostnames"`
 }
 
+// K8sResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sResolver struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+	// Service is the name of the service to watch, as "name" or "name.namespace". The default namespace is "default".
+	Service string `mapstructure:"service"`
+	// Ports is the list of ports to use for each endpoint of the service. The default is 4317.
+	Ports []int32 `mapstructure:"ports"`
+
+	// For mocking.
+	makeClient func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error)
+}
+
+func (r *K8sResolver) getK8sClient() (kubernetes.Interface, error) {
+	if r.makeClient == nil {
+		r.makeClient = k8sconfig.MakeClient
+	}
+	apiConfig := r.APIConfig
+	if apiConfig.AuthType == "" {
+		apiConfig.AuthType = k8sconfig.AuthTypeServiceAccount
+	}
+	return r.makeClient(apiConfig)
+}
+
 // DNSResolver defines the configuration for the DNS resolver
 type DNSResolver struct {
 	Hostname string `mapstructure:"hostname"`
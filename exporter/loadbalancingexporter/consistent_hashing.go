@@ -51,8 +51,13 @@ func newHashRing(endpoints []string) *hashRing {
 // endpointFor calculates which backend is responsible for the given traceID
 func (h *hashRing) endpointFor(traceID pcommon.TraceID) string {
 	b := traceID.Bytes()
+	return h.endpointForKey(b[:])
+}
+
+// endpointForKey calculates which backend is responsible for the given routing key
+func (h *hashRing) endpointForKey(key []byte) string {
 	hasher := crc32.NewIEEE()
-	hasher.Write(b[:])
+	hasher.Write(key)
 	hash := hasher.Sum32()
 	pos := hash % maxPositions
 
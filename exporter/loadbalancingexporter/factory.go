@@ -29,6 +29,8 @@ const (
 	typeStr = "loadbalancing"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+	// The stability level of the metrics exporter.
+	metricsStability = component.StabilityLevelInDevelopment
 )
 
 // NewFactory creates a factory for the exporter.
@@ -40,6 +42,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		component.WithTracesExporterAndStabilityLevel(createTracesExporter, stability),
 		component.WithLogsExporterAndStabilityLevel(createLogsExporter, stability),
+		component.WithMetricsExporterAndStabilityLevel(createMetricsExporter, metricsStability),
 	)
 }
 
@@ -62,3 +65,7 @@ func createTracesExporter(_ context.Context, params component.ExporterCreateSett
 func createLogsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
 	return newLogsExporter(params, cfg)
 }
+
+func createMetricsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	return newMetricsExporter(params, cfg)
+}
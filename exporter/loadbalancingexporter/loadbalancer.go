@@ -44,6 +44,7 @@ type componentFactory func(ctx context.Context, endpoint string) (component.Expo
 type loadBalancer interface {
 	component.Component
 	Endpoint(traceID pcommon.TraceID) string
+	EndpointForKey(key []byte) string
 	Exporter(endpoint string) (component.Exporter, error)
 }
 
@@ -65,7 +66,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporter, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -87,6 +88,19 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		}
 	}
 
+	if oCfg.Resolver.K8s != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s"))
+
+		client, err := oCfg.Resolver.K8s.getK8sClient()
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sResolver(client, k8sLogger, oCfg.Resolver.K8s.Service, oCfg.Resolver.K8s.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -99,6 +113,20 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 	}, nil
 }
 
+func countResolvers(settings ResolverSettings) int {
+	count := 0
+	if settings.Static != nil {
+		count++
+	}
+	if settings.DNS != nil {
+		count++
+	}
+	if settings.K8s != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
@@ -181,6 +209,13 @@ func (lb *loadBalancerImp) Endpoint(traceID pcommon.TraceID) string {
 	return lb.ring.endpointFor(traceID)
 }
 
+func (lb *loadBalancerImp) EndpointForKey(key []byte) string {
+	lb.updateLock.RLock()
+	defer lb.updateLock.RUnlock()
+
+	return lb.ring.endpointForKey(key)
+}
+
 func (lb *loadBalancerImp) Exporter(endpoint string) (component.Exporter, error) {
 	// NOTE: make rolling updates of next tier of collectors work. currently, this may cause
 	// data loss because the latest batches sent to outdated backend will never find their way out.
@@ -27,6 +27,10 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -111,6 +115,57 @@ func TestWithDNSResolver(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestWithK8sResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8s: &K8sResolver{
+				Service: "lb.observability",
+				Ports:   []int32{55690},
+				makeClient: func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error) {
+					assert.Equal(t, k8sconfig.AuthTypeServiceAccount, apiConf.AuthType)
+					return fake.NewSimpleClientset(newEndpoints("lb", "observability", "10.0.0.1")), nil
+				},
+			},
+		},
+	}
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*k8sResolver)
+
+	// verify
+	assert.NotNil(t, res)
+	assert.True(t, ok)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, "observability", res.svcNs)
+	assert.Equal(t, []int32{55690}, res.port)
+}
+
+func TestNewLoadBalancerK8sClientFailure(t *testing.T) {
+	// prepare
+	expectedErr := errors.New("some expected error")
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8s: &K8sResolver{
+				Service: "lb",
+				makeClient: func(k8sconfig.APIConfig) (kubernetes.Interface, error) {
+					return nil, expectedErr
+				},
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, expectedErr, err)
+}
+
 func TestMultipleResolvers(t *testing.T) {
 	cfg := &Config{
 		Resolver: ResolverSettings{
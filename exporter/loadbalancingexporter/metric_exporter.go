@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+var _ component.MetricsExporter = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   string
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params component.ExporterCreateSettings, cfg config.Exporter) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routingKey := cfg.(*Config).RoutingKey
+	if routingKey == "" {
+		routingKey = resourceRoutingKey
+	}
+
+	return &metricExporterImp{
+		loadBalancer: lb,
+		routingKey:   routingKey,
+	}, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var batches map[string]pmetric.Metrics
+	if e.routingKey == metricRoutingKey {
+		batches = e.splitByMetric(md)
+	} else {
+		batches = e.splitByResource(md)
+	}
+
+	// sort the endpoints for a deterministic order of the exports
+	endpoints := make([]string, 0, len(batches))
+	for endpoint := range batches {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	var errs error
+	for _, endpoint := range endpoints {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batches[endpoint]))
+	}
+
+	return errs
+}
+
+// splitByResource groups the resource metrics by the backend responsible for their resource attributes.
+func (e *metricExporterImp) splitByResource(md pmetric.Metrics) map[string]pmetric.Metrics {
+	batches := map[string]pmetric.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		endpoint := e.loadBalancer.EndpointForKey([]byte(attributesKey(rm.Resource().Attributes())))
+
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = pmetric.NewMetrics()
+			batches[endpoint] = batch
+		}
+		rm.CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitByMetric groups the data points by the backend responsible for their stream, identified by the
+// resource attributes, the metric name and the data point attributes.
+func (e *metricExporterImp) splitByMetric(md pmetric.Metrics) map[string]pmetric.Metrics {
+	batches := map[string]*metricsBatch{}
+	batchFor := func(endpoint string) *metricsBatch {
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = newMetricsBatch()
+			batches[endpoint] = batch
+		}
+		return batch
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := attributesKey(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				metricKey := resourceKey + "\x00" + m.Name()
+				endpointFor := func(attrs pcommon.Map) string {
+					return e.loadBalancer.EndpointForKey([]byte(metricKey + "\x00" + attributesKey(attrs)))
+				}
+				target := func(endpoint string) pmetric.Metric {
+					return batchFor(endpoint).metric(md, i, j, k)
+				}
+
+				switch m.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(endpointFor(dps.At(l).Attributes())).Gauge().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeSum:
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(endpointFor(dps.At(l).Attributes())).Sum().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(endpointFor(dps.At(l).Attributes())).Histogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(endpointFor(dps.At(l).Attributes())).ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricDataTypeSummary:
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(endpointFor(dps.At(l).Attributes())).Summary().DataPoints().AppendEmpty())
+					}
+				}
+			}
+		}
+	}
+
+	result := make(map[string]pmetric.Metrics, len(batches))
+	for endpoint, batch := range batches {
+		result[endpoint] = batch.md
+	}
+	return result
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(component.MetricsExporter)
+	if !ok {
+		expectType := (*component.MetricsExporter)(nil)
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected %T but got %T", expectType, exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+
+	if err == nil {
+		sCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "true"))
+		stats.Record(sCtx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(fCtx, mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// metricsBatch holds the data points sent to a backend, keeping the resources, scopes and metrics
+// of the original batch, without their data points.
+type metricsBatch struct {
+	md        pmetric.Metrics
+	resources map[int]pmetric.ResourceMetrics
+	scopes    map[[2]int]pmetric.ScopeMetrics
+	metrics   map[[3]int]pmetric.Metric
+}
+
+func newMetricsBatch() *metricsBatch {
+	return &metricsBatch{
+		md:        pmetric.NewMetrics(),
+		resources: map[int]pmetric.ResourceMetrics{},
+		scopes:    map[[2]int]pmetric.ScopeMetrics{},
+		metrics:   map[[3]int]pmetric.Metric{},
+	}
+}
+
+// metric returns the copy of the k-th metric of the j-th scope of the i-th resource of the original batch,
+// to which the data points are appended.
+func (b *metricsBatch) metric(orig pmetric.Metrics, i, j, k int) pmetric.Metric {
+	if m, ok := b.metrics[[3]int{i, j, k}]; ok {
+		return m
+	}
+
+	origRM := orig.ResourceMetrics().At(i)
+	rm, ok := b.resources[i]
+	if !ok {
+		rm = b.md.ResourceMetrics().AppendEmpty()
+		origRM.Resource().CopyTo(rm.Resource())
+		rm.SetSchemaUrl(origRM.SchemaUrl())
+		b.resources[i] = rm
+	}
+
+	origSM := origRM.ScopeMetrics().At(j)
+	sm, ok := b.scopes[[2]int{i, j}]
+	if !ok {
+		sm = rm.ScopeMetrics().AppendEmpty()
+		origSM.Scope().CopyTo(sm.Scope())
+		sm.SetSchemaUrl(origSM.SchemaUrl())
+		b.scopes[[2]int{i, j}] = sm
+	}
+
+	origM := origSM.Metrics().At(k)
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(origM.Name())
+	m.SetDescription(origM.Description())
+	m.SetUnit(origM.Unit())
+	m.SetDataType(origM.DataType())
+	switch origM.DataType() {
+	case pmetric.MetricDataTypeSum:
+		m.Sum().SetAggregationTemporality(origM.Sum().AggregationTemporality())
+		m.Sum().SetIsMonotonic(origM.Sum().IsMonotonic())
+	case pmetric.MetricDataTypeHistogram:
+		m.Histogram().SetAggregationTemporality(origM.Histogram().AggregationTemporality())
+	case pmetric.MetricDataTypeExponentialHistogram:
+		m.ExponentialHistogram().SetAggregationTemporality(origM.ExponentialHistogram().AggregationTemporality())
+	}
+	b.metrics[[3]int{i, j, k}] = m
+	return m
+}
+
+// attributesKey returns a string identifying the given attributes, regardless of their order.
+func attributesKey(attrs pcommon.Map) string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(v.AsString())
+		b.WriteByte('\x00')
+	}
+	return b.String()
+}
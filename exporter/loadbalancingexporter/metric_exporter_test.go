@@ -0,0 +1,403 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:errcheck
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{},
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterStart(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		me   *metricExporterImp
+		err  error
+	}{
+		{
+			"ok",
+			func() *metricExporterImp {
+				p, _ := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+				return p
+			}(),
+			nil,
+		},
+		{
+			"error",
+			func() *metricExporterImp {
+				lb, _ := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), nil)
+				p, _ := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+
+				lb.res = &mockResolver{
+					onStart: func(context.Context) error {
+						return errors.New("some expected err")
+					},
+				}
+				p.loadBalancer = lb
+
+				return p
+			}(),
+			errors.New("some expected err"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := tt.me
+
+			// test
+			res := p.Start(context.Background(), componenttest.NewNopHost())
+			defer p.Shutdown(context.Background())
+
+			// verify
+			require.Equal(t, tt.err, res)
+		})
+	}
+}
+
+func TestMetricsExporterShutdown(t *testing.T) {
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(context.Background())
+
+	// verify
+	assert.Nil(t, res)
+}
+
+func TestConsumeMetricsExporterNotFound(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockMetricsExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("couldn't find the exporter for the endpoint %q", "endpoint-1"))
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockExporter()
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func TestConsumeMetricsRoutedByResource(t *testing.T) {
+	// prepare
+	p, sinks := newMetricsExporterWithSinks(t, resourceRoutingKey)
+
+	md := pmetric.NewMetrics()
+	for i := 0; i < 20; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().UpsertString("service.name", fmt.Sprintf("service-%d", i%5))
+		appendGauge(rm, "metric-1", "value-1", "value-2")
+	}
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	services := map[string]string{}
+	dataPoints := 0
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllMetrics() {
+			dataPoints += received.DataPointCount()
+			rms := received.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				service, _ := rms.At(i).Resource().Attributes().Get("service.name")
+				if previous, ok := services[service.StringVal()]; ok {
+					assert.Equal(t, previous, endpoint, "the metrics of a resource were sent to several backends")
+				}
+				services[service.StringVal()] = endpoint
+			}
+		}
+	}
+	assert.Len(t, services, 5)
+	assert.Equal(t, md.DataPointCount(), dataPoints)
+}
+
+func TestConsumeMetricsRoutedByMetric(t *testing.T) {
+	// prepare
+	p, sinks := newMetricsExporterWithSinks(t, metricRoutingKey)
+
+	md := pmetric.NewMetrics()
+	for i := 0; i < 3; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().UpsertString("service.name", "service")
+		for j := 0; j < 10; j++ {
+			appendGauge(rm, fmt.Sprintf("metric-%d", j), "value-1", "value-2", "value-3")
+		}
+	}
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	streams := map[string]string{}
+	usedEndpoints := map[string]bool{}
+	dataPoints := 0
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllMetrics() {
+			dataPoints += received.DataPointCount()
+			rms := received.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+				for j := 0; j < ms.Len(); j++ {
+					m := ms.At(j)
+					assert.Equal(t, pmetric.MetricDataTypeGauge, m.DataType())
+					dps := m.Gauge().DataPoints()
+					for k := 0; k < dps.Len(); k++ {
+						attr, _ := dps.At(k).Attributes().Get("attr")
+						stream := m.Name() + "/" + attr.StringVal()
+						if previous, ok := streams[stream]; ok {
+							assert.Equal(t, previous, endpoint, "the data points of a stream were sent to several backends")
+						}
+						streams[stream] = endpoint
+						usedEndpoints[endpoint] = true
+					}
+				}
+			}
+		}
+	}
+	assert.Len(t, streams, 30)
+	assert.Len(t, usedEndpoints, 2, "the streams should have been spread across the backends")
+	assert.Equal(t, md.DataPointCount(), dataPoints)
+}
+
+func TestMetricsBatchKeepsMetadata(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("resource-schema")
+	rm.Resource().Attributes().UpsertString("service.name", "service")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("sum")
+	m.SetUnit("1")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+
+	// test
+	batch := newMetricsBatch()
+	copied := batch.metric(md, 0, 0, 0)
+
+	// verify
+	assert.Equal(t, copied, batch.metric(md, 0, 0, 0))
+	assert.Equal(t, "sum", copied.Name())
+	assert.Equal(t, "1", copied.Unit())
+	assert.True(t, copied.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, copied.Sum().AggregationTemporality())
+	assert.Equal(t, "resource-schema", batch.md.ResourceMetrics().At(0).SchemaUrl())
+	assert.Equal(t, "scope", batch.md.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, 1, batch.md.ResourceMetrics().Len())
+}
+
+func TestAttributesKey(t *testing.T) {
+	first := pcommon.NewMap()
+	first.UpsertString("a", "1")
+	first.UpsertInt("b", 2)
+
+	second := pcommon.NewMap()
+	second.UpsertInt("b", 2)
+	second.UpsertString("a", "1")
+
+	third := pcommon.NewMap()
+	third.UpsertString("a", "1")
+
+	assert.Equal(t, attributesKey(first), attributesKey(second))
+	assert.NotEqual(t, attributesKey(first), attributesKey(third))
+}
+
+func TestConfigValidateRoutingKey(t *testing.T) {
+	cfg := simpleConfig()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RoutingKey = metricRoutingKey
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RoutingKey = "traceID"
+	assert.EqualError(t, cfg.Validate(), `unsupported routing_key "traceID", must be either "resource" or "metric"`)
+}
+
+func newMetricsExporterWithSinks(t *testing.T, routingKey string) (*metricExporterImp, map[string]*consumertest.MetricsSink) {
+	sinks := map[string]*consumertest.MetricsSink{
+		"endpoint-1:4317": new(consumertest.MetricsSink),
+		"endpoint-2:4317": new(consumertest.MetricsSink),
+	}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newMockMetricsExporter(sinks[endpoint].ConsumeMetrics), nil
+	}
+
+	cfg := simpleConfig()
+	cfg.RoutingKey = routingKey
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NoError(t, err)
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1:4317", "endpoint-2:4317"}, nil
+		},
+	}
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		p.Shutdown(context.Background())
+	})
+	return p, sinks
+}
+
+func appendGauge(rm pmetric.ResourceMetrics, name string, attrValues ...string) {
+	if rm.ScopeMetrics().Len() == 0 {
+		rm.ScopeMetrics().AppendEmpty()
+	}
+	m := rm.ScopeMetrics().At(0).Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pmetric.MetricDataTypeGauge)
+	for i, v := range attrValues {
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetIntVal(int64(i))
+		dp.Attributes().UpsertString("attr", v)
+	}
+}
+
+func simpleMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().UpsertString("service.name", "service")
+	appendGauge(rm, "metric", "value")
+	return md
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func newNopMockMetricsExporter() component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component: mockComponent{},
+		consumeMetricsFn: func(ctx context.Context, md pmetric.Metrics) error {
+			return nil
+		},
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace   = "default"
+	defaultK8sSyncTimeout = 5 * time.Second
+)
+
+var (
+	errNoSvc       = errors.New("no service specified to watch the backends")
+	errInvalidPort = errors.New("the ports of the k8s resolver must be between 1 and 65535")
+	errSyncTimeout = errors.New("timed out waiting for the endpoints of the service to be synced")
+)
+
+// k8sResolver watches the endpoints of a Kubernetes service, using the ready addresses as backends.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName     string
+	svcNs       string
+	port        []int32
+	informer    cache.SharedInformer
+	syncTimeout time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	stopOnce           sync.Once
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(client kubernetes.Interface, logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+	for _, port := range ports {
+		if port < 1 || port > 65535 {
+			return nil, errInvalidPort
+		}
+	}
+
+	// the service is either "name" or "name.namespace"
+	name, namespace := service, defaultK8sNamespace
+	if idx := strings.Index(service, "."); idx >= 0 {
+		name, namespace = service[:idx], service[idx+1:]
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	listWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return client.CoreV1().Endpoints(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return client.CoreV1().Endpoints(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	return &k8sResolver{
+		logger:      logger,
+		svcName:     name,
+		svcNs:       namespace,
+		port:        ports,
+		informer:    cache.NewSharedInformer(listWatcher, &v1.Endpoints{}, 0),
+		syncTimeout: defaultK8sSyncTimeout,
+		stopCh:      make(chan struct{}),
+	}, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	// any change to the endpoints of the service triggers a new resolution
+	r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { r.onEndpointsChange() },
+		UpdateFunc: func(interface{}, interface{}) { r.onEndpointsChange() },
+		DeleteFunc: func(interface{}) { r.onEndpointsChange() },
+	})
+	go r.informer.Run(r.stopCh)
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), r.informer.HasSynced) {
+		// stop the informer, the exporter may not be shut down when it fails to start
+		r.stop()
+		return fmt.Errorf("%w: service %q in namespace %q", errSyncTimeout, r.svcName, r.svcNs)
+	}
+
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	r.stop()
+	r.shutdownWg.Wait()
+	return nil
+}
+
+// stop stops the informer, it is safe to call it more than once.
+func (r *k8sResolver) stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+func (r *k8sResolver) onEndpointsChange() {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	// the context to use for all metrics in this function
+	mCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("resolver"), "k8s"))
+
+	obj, found, err := r.informer.GetStore().GetByKey(r.svcNs + "/" + r.svcName)
+	if err != nil {
+		failedCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(failedCtx, mNumResolutions.M(1))
+		return nil, err
+	}
+
+	// from this point, we don't fail anymore
+	successCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "true"))
+	stats.Record(successCtx, mNumResolutions.M(1))
+
+	var backends []string
+	if found {
+		endpoints, ok := obj.(*v1.Endpoints)
+		if !ok {
+			return nil, fmt.Errorf("expected *v1.Endpoints but got %T", obj)
+		}
+		backends = r.backendsFor(endpoints)
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	// resolutions are triggered by the informer as well, so the callbacks are kept under the lock
+	// to prevent an outdated list of backends from being propagated after a newer one
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+	if equalStringSlice(r.endpoints, backends) {
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	stats.Record(mCtx, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+// backendsFor returns the backends for the ready addresses of the endpoints, on each of the configured ports.
+func (r *k8sResolver) backendsFor(endpoints *v1.Endpoints) []string {
+	ports := r.port
+	if len(ports) == 0 {
+		defaultPortNumber, _ := strconv.Atoi(defaultPort)
+		ports = []int32{int32(defaultPortNumber)}
+	}
+
+	var backends []string
+	for _, subset := range endpoints.Subsets {
+		for _, addr := range subset.Addresses {
+			for _, port := range ports {
+				backends = append(backends, net.JoinHostPort(addr.IP, strconv.Itoa(int(port))))
+			}
+		}
+	}
+	return backends
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:errcheck
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestInitialK8sResolution(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(newEndpoints("lb", "observability", "10.0.0.2", "10.0.0.1"))
+	res, err := newK8sResolver(client, zap.NewNop(), "lb.observability", nil)
+	require.NoError(t, err)
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317"}, resolved)
+}
+
+func TestInitialK8sResolutionWithPorts(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(newEndpoints("lb", "default", "10.0.0.1"))
+	res, err := newK8sResolver(client, zap.NewNop(), "lb", []int32{4317, 55690})
+	require.NoError(t, err)
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.1:55690"}, resolved)
+}
+
+func TestK8sResolutionOtherService(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(
+		newEndpoints("lb", "default", "10.0.0.1"),
+		newEndpoints("other", "default", "10.0.0.2"),
+		newEndpoints("lb", "other-namespace", "10.0.0.3"),
+	)
+	res, err := newK8sResolver(client, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317"}, res.endpoints)
+}
+
+func TestK8sResolutionOnChange(t *testing.T) {
+	// prepare
+	endpoints := newEndpoints("lb", "default", "10.0.0.1")
+	client := fake.NewSimpleClientset(endpoints)
+	res, err := newK8sResolver(client, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	current := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+	require.Equal(t, []string{"10.0.0.1:4317"}, current())
+
+	// test: a new backend becomes ready
+	updated := newEndpoints("lb", "default", "10.0.0.1", "10.0.0.2")
+	_, err = client.CoreV1().Endpoints("default").Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.1:4317", "10.0.0.2:4317"}, current())
+	}, time.Second, 10*time.Millisecond)
+
+	// test: the service is deleted
+	err = client.CoreV1().Endpoints("default").Delete(context.Background(), "lb", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(current()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolutionNotReadyAddresses(t *testing.T) {
+	// prepare
+	endpoints := newEndpoints("lb", "default", "10.0.0.1")
+	endpoints.Subsets[0].NotReadyAddresses = []v1.EndpointAddress{{IP: "10.0.0.2"}}
+	client := fake.NewSimpleClientset(endpoints)
+	res, err := newK8sResolver(client, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, []string{"10.0.0.1:4317"}, res.endpoints)
+}
+
+func TestK8sResolverSyncTimeout(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "endpoints", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("the server is unavailable")
+	})
+	res, err := newK8sResolver(client, zap.NewNop(), "lb.observability", nil)
+	require.NoError(t, err)
+	res.syncTimeout = 50 * time.Millisecond
+
+	// test
+	err = res.start(context.Background())
+
+	// verify
+	assert.ErrorIs(t, err, errSyncTimeout)
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestK8sResolverShutdownTwice(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(newEndpoints("lb", "observability", "10.0.0.1"))
+	res, err := newK8sResolver(client, zap.NewNop(), "lb.observability", nil)
+	require.NoError(t, err)
+	require.NoError(t, res.start(context.Background()))
+
+	// test and verify
+	assert.NoError(t, res.shutdown(context.Background()))
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestK8sResolverNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		service   string
+		name      string
+		namespace string
+	}{
+		{service: "lb", name: "lb", namespace: "default"},
+		{service: "lb.observability", name: "lb", namespace: "observability"},
+	} {
+		t.Run(tt.service, func(t *testing.T) {
+			res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), tt.service, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, res.svcName)
+			assert.Equal(t, tt.namespace, res.svcNs)
+		})
+	}
+}
+
+func TestK8sResolverNoService(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sResolverInvalidPort(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", []int32{4317, 0})
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errInvalidPort, err)
+}
+
+func newEndpoints(name, namespace string, ips ...string) *v1.Endpoints {
+	var addresses []v1.EndpointAddress
+	for _, ip := range ips {
+		addresses = append(addresses, v1.EndpointAddress{IP: ip})
+	}
+	return &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []v1.EndpointSubset{{Addresses: addresses}},
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"`
+
+	// Path is the directory that the Parquet files are written to.
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used to compress the column data.
+	// It is one of "none", "snappy", "gzip" or "zstd", and defaults to "snappy".
+	Compression string `mapstructure:"compression"`
+
+	// Rotation defines when the file being written is closed and a new file is started.
+	Rotation RotationSettings `mapstructure:"rotation"`
+}
+
+// RotationSettings defines the limits at which files are rolled over.
+type RotationSettings struct {
+	// MaxMegabytes is the approximate maximum size of a file in megabytes.
+	// Setting it to 0 disables size based rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxDuration is the maximum amount of time a file is written to.
+	// Setting it to 0 disables time based rotation.
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	switch cfg.Compression {
+	case compressionNone, compressionSnappy, compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("unsupported compression %q", cfg.Compression)
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("rotation max_megabytes must not be negative")
+	}
+	if cfg.Rotation.MaxDuration < 0 {
+		return errors.New("rotation max_duration must not be negative")
+	}
+	return nil
+}
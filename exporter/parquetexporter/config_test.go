@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters[config.NewComponentID(typeStr)]
+	assert.Equal(t, e0,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+			Path:             "/var/output/",
+			Compression:      compressionSnappy,
+			Rotation: RotationSettings{
+				MaxMegabytes: defaultMaxMegabytes,
+				MaxDuration:  defaultMaxDuration,
+			},
+		})
+
+	e1 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "2")]
+	assert.Equal(t, e1,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
+			Path:             "/var/output/",
+			Compression:      compressionZstd,
+			Rotation: RotationSettings{
+				MaxMegabytes: 10,
+				MaxDuration:  5 * time.Minute,
+			},
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "missing path",
+			modify:  func(cfg *Config) { cfg.Path = "" },
+			wantErr: "path must be non-empty",
+		},
+		{
+			name:    "unknown compression",
+			modify:  func(cfg *Config) { cfg.Compression = "lzo" },
+			wantErr: `unsupported compression "lzo"`,
+		},
+		{
+			name:    "negative size",
+			modify:  func(cfg *Config) { cfg.Rotation.MaxMegabytes = -1 },
+			wantErr: "rotation max_megabytes must not be negative",
+		},
+		{
+			name:    "negative duration",
+			modify:  func(cfg *Config) { cfg.Rotation.MaxDuration = -time.Second },
+			wantErr: "rotation max_duration must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = t.TempDir()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -16,33 +16,100 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"os"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 )
 
+// rotationCheckInterval is how often open files are checked against the max duration
+// so that files are completed even when no new data is being written.
+const rotationCheckInterval = time.Second
+
 type parquetExporter struct {
-	path string
+	path        string
+	maxDuration time.Duration
+	logger      *zap.Logger
+	writers     map[fileKind]*rollingWriter
+
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, logger *zap.Logger, kinds ...fileKind) *parquetExporter {
+	e := &parquetExporter{
+		path:        cfg.Path,
+		maxDuration: cfg.Rotation.MaxDuration,
+		logger:      logger,
+		writers:     make(map[fileKind]*rollingWriter, len(kinds)),
+		done:        make(chan struct{}),
+	}
+	for _, kind := range kinds {
+		e.writers[kind] = newRollingWriter(cfg.Path, kind, cfg)
+	}
+	return e
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(ctx context.Context, host component.Host) error {
+	if err := os.MkdirAll(e.path, 0700); err != nil {
+		return err
+	}
+	if e.maxDuration > 0 {
+		e.wg.Add(1)
+		go e.rotateOnInterval()
+	}
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func (e *parquetExporter) rotateOnInterval() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(rotationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+			for kind, w := range e.writers {
+				if err := w.rotateExpired(); err != nil {
+					e.logger.Error("Failed to rotate Parquet file", zap.String("kind", string(kind)), zap.Error(err))
+				}
+			}
+		}
+	}
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.done) })
+	e.wg.Wait()
+
+	var errs error
+	for _, w := range e.writers {
+		errs = multierr.Append(errs, w.close())
+	}
+	return errs
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	for kind, records := range metricRecords(md) {
+		errs = multierr.Append(errs, e.writers[kind].write(records))
+	}
+	return errs
+}
+
+func (e *parquetExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.writers[kindSpans].write(spanRecords(td))
+}
+
+func (e *parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	return e.writers[kindLogs].write(logRecords(ld))
 }
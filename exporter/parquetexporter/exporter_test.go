@@ -0,0 +1,322 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newTestConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return cfg
+}
+
+// readRecords reads back all the rows of the completed files of a kind.
+func readRecords(t *testing.T, dir string, kind fileKind, newRecord func() interface{}, out interface{}) []string {
+	files, err := filepath.Glob(filepath.Join(dir, string(kind), "*.parquet"))
+	require.NoError(t, err)
+	for _, name := range files {
+		fr, err := local.NewLocalFileReader(name)
+		require.NoError(t, err)
+		pr, err := reader.NewParquetReader(fr, newRecord(), 1)
+		require.NoError(t, err)
+		require.NoError(t, pr.Read(out))
+		pr.ReadStop()
+		require.NoError(t, fr.Close())
+	}
+	return files
+}
+
+func TestExporterTraces(t *testing.T) {
+	cfg := newTestConfig(t)
+	e := newParquetExporter(cfg, zap.NewNop(), kindSpans)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	rs.Resource().Attributes().InsertInt("shard", 3)
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("otelhttp")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetStartTimestamp(pcommon.Timestamp(1000))
+	span.SetEndTimestamp(pcommon.Timestamp(2500))
+	span.Attributes().InsertString("http.method", "GET")
+	span.Status().SetCode(ptrace.StatusCodeError)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().InsertString("exception.message", "boom")
+	span.Links().AppendEmpty().SetSpanID(pcommon.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+
+	require.NoError(t, e.consumeTraces(context.Background(), td))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	records := make([]spanRecord, 1)
+	files := readRecords(t, cfg.Path, kindSpans, func() interface{} { return new(spanRecord) }, &records)
+	require.Len(t, files, 1)
+
+	got := records[0]
+	assert.Equal(t, map[string]string{"service.name": "checkout", "shard": "3"}, got.ResourceAttributes)
+	assert.Equal(t, "otelhttp", got.ScopeName)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", got.TraceID)
+	assert.Equal(t, "0102030405060708", got.SpanID)
+	assert.Equal(t, "", got.ParentSpanID)
+	assert.Equal(t, "GET /cart", got.Name)
+	assert.Equal(t, "SPAN_KIND_SERVER", got.Kind)
+	assert.Equal(t, int64(1500), got.DurationNano)
+	assert.Equal(t, map[string]string{"http.method": "GET"}, got.Attributes)
+	assert.Equal(t, "STATUS_CODE_ERROR", got.StatusCode)
+	require.Len(t, got.Events, 1)
+	assert.Equal(t, "exception", got.Events[0].Name)
+	assert.Equal(t, map[string]string{"exception.message": "boom"}, got.Events[0].Attributes)
+	require.Len(t, got.Links, 1)
+	assert.Equal(t, "0807060504030201", got.Links[0].SpanID)
+}
+
+func TestExporterLogs(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Compression = compressionGzip
+	e := newParquetExporter(cfg, zap.NewNop(), kindLogs)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "edge-1")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.Timestamp(42))
+	lr.SetSeverityNumber(plog.SeverityNumberERROR)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStringVal("disk full")
+	lr.Attributes().InsertBool("retry", true)
+
+	require.NoError(t, e.consumeLogs(context.Background(), ld))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	records := make([]logRecord, 1)
+	readRecords(t, cfg.Path, kindLogs, func() interface{} { return new(logRecord) }, &records)
+
+	got := records[0]
+	assert.Equal(t, map[string]string{"host.name": "edge-1"}, got.ResourceAttributes)
+	assert.Equal(t, int64(42), got.TimeUnixNano)
+	assert.Equal(t, int32(plog.SeverityNumberERROR), got.SeverityNumber)
+	assert.Equal(t, "ERROR", got.SeverityText)
+	assert.Equal(t, "disk full", got.Body)
+	assert.Equal(t, map[string]string{"retry": "true"}, got.Attributes)
+	assert.Equal(t, "", got.TraceID)
+}
+
+func TestExporterMetrics(t *testing.T) {
+	cfg := newTestConfig(t)
+	e := newParquetExporter(cfg, zap.NewNop(), kindGauges, kindSums, kindHistograms, kindExponentialHistograms, kindSummaries)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("cpu.utilization")
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(0.5)
+
+	sum := ms.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetIntVal(7)
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12)
+	hdp.SetMBucketCounts([]uint64{1, 2})
+	hdp.SetMExplicitBounds([]float64{5})
+
+	exponential := ms.AppendEmpty()
+	exponential.SetName("latency.exp")
+	exponential.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	edp := exponential.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetScale(2)
+	edp.Positive().SetOffset(1)
+	edp.Positive().SetMBucketCounts([]uint64{4, 5})
+
+	summary := ms.AppendEmpty()
+	summary.SetName("gc.pause")
+	summary.SetDataType(pmetric.MetricDataTypeSummary)
+	qv := summary.Summary().DataPoints().AppendEmpty().QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(3)
+
+	require.NoError(t, e.consumeMetrics(context.Background(), md))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	gauges := make([]numberDataPointRecord, 1)
+	readRecords(t, cfg.Path, kindGauges, func() interface{} { return new(numberDataPointRecord) }, &gauges)
+	assert.Equal(t, "cpu.utilization", gauges[0].MetricName)
+	require.NotNil(t, gauges[0].DoubleValue)
+	assert.Equal(t, 0.5, *gauges[0].DoubleValue)
+	assert.Nil(t, gauges[0].IntValue)
+
+	sums := make([]numberDataPointRecord, 1)
+	readRecords(t, cfg.Path, kindSums, func() interface{} { return new(numberDataPointRecord) }, &sums)
+	assert.True(t, sums[0].IsMonotonic)
+	assert.Equal(t, "AGGREGATION_TEMPORALITY_CUMULATIVE", sums[0].AggregationTemporality)
+	require.NotNil(t, sums[0].IntValue)
+	assert.Equal(t, int64(7), *sums[0].IntValue)
+
+	histograms := make([]histogramDataPointRecord, 1)
+	readRecords(t, cfg.Path, kindHistograms, func() interface{} { return new(histogramDataPointRecord) }, &histograms)
+	assert.Equal(t, int64(3), histograms[0].Count)
+	require.NotNil(t, histograms[0].Sum)
+	assert.Equal(t, 12.0, *histograms[0].Sum)
+	assert.Nil(t, histograms[0].Min)
+	assert.Equal(t, []int64{1, 2}, histograms[0].BucketCounts)
+	assert.Equal(t, []float64{5}, histograms[0].ExplicitBounds)
+
+	exponentials := make([]exponentialHistogramDataPointRecord, 1)
+	readRecords(t, cfg.Path, kindExponentialHistograms, func() interface{} { return new(exponentialHistogramDataPointRecord) }, &exponentials)
+	assert.Equal(t, int32(2), exponentials[0].Scale)
+	assert.Equal(t, int32(1), exponentials[0].PositiveOffset)
+	assert.Equal(t, []int64{4, 5}, exponentials[0].PositiveBucketCounts)
+
+	summaries := make([]summaryDataPointRecord, 1)
+	readRecords(t, cfg.Path, kindSummaries, func() interface{} { return new(summaryDataPointRecord) }, &summaries)
+	assert.Equal(t, []quantileValueRecord{{Quantile: 0.99, Value: 3}}, summaries[0].QuantileValues)
+}
+
+func TestRollingWriterRotation(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Rotation.MaxDuration = time.Minute
+
+	now := time.Date(2022, 7, 27, 10, 0, 0, 0, time.UTC)
+	w := newRollingWriter(cfg.Path, kindLogs, cfg)
+	w.now = func() time.Time { return now }
+
+	record := &logRecord{Body: "first"}
+	require.NoError(t, w.write([]interface{}{record}))
+
+	pending, err := filepath.Glob(filepath.Join(cfg.Path, string(kindLogs), ".*.tmp"))
+	require.NoError(t, err)
+	assert.Len(t, pending, 1, "Must write to a hidden file until it is complete")
+
+	require.NoError(t, w.rotateExpired())
+	completed, err := filepath.Glob(filepath.Join(cfg.Path, string(kindLogs), "*.parquet"))
+	require.NoError(t, err)
+	assert.Len(t, completed, 0, "Must not rotate before the max duration")
+
+	now = now.Add(time.Minute)
+	require.NoError(t, w.rotateExpired())
+	completed, err = filepath.Glob(filepath.Join(cfg.Path, string(kindLogs), "*.parquet"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(cfg.Path, "logs", "logs-20220727T100000.000000000Z.parquet")}, completed)
+
+	now = now.Add(time.Second)
+	require.NoError(t, w.write([]interface{}{record}))
+	require.NoError(t, w.close())
+
+	entries, err := os.ReadDir(filepath.Join(cfg.Path, string(kindLogs)))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "Must have started a new file after rotation")
+}
+
+func TestRollingWriterSizeRotation(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Compression = compressionNone
+	cfg.Rotation.MaxMegabytes = 1
+
+	w := newRollingWriter(cfg.Path, kindLogs, cfg)
+	seq := 0
+	w.now = func() time.Time {
+		seq++
+		return time.Unix(int64(seq), 0)
+	}
+
+	records := make([]interface{}, 0, 2048)
+	for i := 0; i < cap(records); i++ {
+		records = append(records, &logRecord{Body: string(make([]byte, 1024))})
+	}
+	require.NoError(t, w.write(records))
+	require.NoError(t, w.close())
+
+	completed, err := filepath.Glob(filepath.Join(cfg.Path, string(kindLogs), "*.parquet"))
+	require.NoError(t, err)
+	assert.Greater(t, len(completed), 1, "Must roll over files once the size limit is reached")
+}
+
+func TestRollingWriterKeepsRowsOnWriteError(t *testing.T) {
+	cfg := newTestConfig(t)
+
+	w := newRollingWriter(cfg.Path, kindLogs, cfg)
+	seq := 0
+	w.now = func() time.Time {
+		seq++
+		return time.Unix(int64(seq), 0)
+	}
+
+	require.NoError(t, w.write([]interface{}{&logRecord{Body: "first"}}))
+	assert.Error(t, w.write([]interface{}{&logRecord{Body: "second"}, "not a log record"}))
+	require.NoError(t, w.write([]interface{}{&logRecord{Body: "third"}}))
+	require.NoError(t, w.close())
+
+	var bodies []string
+	files, err := filepath.Glob(filepath.Join(cfg.Path, string(kindLogs), "*.parquet"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "Must roll over to a new file after a failed write")
+	for _, name := range files {
+		fr, err := local.NewLocalFileReader(name)
+		require.NoError(t, err)
+		pr, err := reader.NewParquetReader(fr, new(logRecord), 1)
+		require.NoError(t, err)
+		logs := make([]logRecord, pr.GetNumRows())
+		require.NoError(t, pr.Read(&logs))
+		pr.ReadStop()
+		require.NoError(t, fr.Close())
+		for _, l := range logs {
+			bodies = append(bodies, l.Body)
+		}
+	}
+	assert.Equal(t, []string{"first", "third"}, bodies)
+}
+
+func TestExporterShutdownTwice(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Rotation.MaxDuration = time.Minute
+	e := newParquetExporter(cfg, zap.NewNop(), kindLogs)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.NoError(t, e.shutdown(context.Background()))
+}
@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,12 +28,10 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelInDevelopment
-)
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
+	defaultMaxMegabytes = 100
+	defaultMaxDuration  = time.Hour
+)
 
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
@@ -47,6 +46,11 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Compression:      compressionSnappy,
+		Rotation: RotationSettings{
+			MaxMegabytes: defaultMaxMegabytes,
+			MaxDuration:  defaultMaxDuration,
+		},
 	}
 }
 
@@ -55,7 +59,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, kindSpans)
 	return exporterhelper.NewTracesExporter(
 		cfg,
 		set,
@@ -70,7 +74,15 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(
+		cfg.(*Config),
+		set.Logger,
+		kindGauges,
+		kindSums,
+		kindHistograms,
+		kindExponentialHistograms,
+		kindSummaries,
+	)
 	return exporterhelper.NewMetricsExporter(
 		cfg,
 		set,
@@ -85,7 +97,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, kindLogs)
 	return exporterhelper.NewLogsExporter(
 		cfg,
 		set,
@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// fileKind identifies the set of files that share the same schema.
+type fileKind string
+
+const (
+	kindSpans                 fileKind = "spans"
+	kindLogs                  fileKind = "logs"
+	kindGauges                fileKind = "gauges"
+	kindSums                  fileKind = "sums"
+	kindHistograms            fileKind = "histograms"
+	kindExponentialHistograms fileKind = "exponential_histograms"
+	kindSummaries             fileKind = "summaries"
+)
+
+// schemas maps each file kind to the record that defines its schema.
+var schemas = map[fileKind]interface{}{
+	kindSpans:                 new(spanRecord),
+	kindLogs:                  new(logRecord),
+	kindGauges:                new(numberDataPointRecord),
+	kindSums:                  new(numberDataPointRecord),
+	kindHistograms:            new(histogramDataPointRecord),
+	kindExponentialHistograms: new(exponentialHistogramDataPointRecord),
+	kindSummaries:             new(summaryDataPointRecord),
+}
+
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func traceIDToString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.HexString()
+}
+
+func spanIDToString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.HexString()
+}
+
+func optionalDouble(ok bool, v float64) *float64 {
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func bucketCounts(counts []uint64) []int64 {
+	out := make([]int64, len(counts))
+	for i, c := range counts {
+		out[i] = int64(c)
+	}
+	return out
+}
+
+func spanRecords(td ptrace.Traces) []interface{} {
+	records := make([]interface{}, 0, td.SpanCount())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		resource := attributesToMap(rs.Resource().Attributes())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				record := &spanRecord{
+					ResourceAttributes:     resource,
+					ResourceSchemaURL:      rs.SchemaUrl(),
+					ScopeName:              ss.Scope().Name(),
+					ScopeVersion:           ss.Scope().Version(),
+					TraceID:                traceIDToString(span.TraceID()),
+					SpanID:                 spanIDToString(span.SpanID()),
+					ParentSpanID:           spanIDToString(span.ParentSpanID()),
+					TraceState:             string(span.TraceState()),
+					Name:                   span.Name(),
+					Kind:                   span.Kind().String(),
+					StartTimeUnixNano:      int64(span.StartTimestamp()),
+					EndTimeUnixNano:        int64(span.EndTimestamp()),
+					DurationNano:           int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					Attributes:             attributesToMap(span.Attributes()),
+					DroppedAttributesCount: int64(span.DroppedAttributesCount()),
+					Events:                 make([]spanEventRecord, 0, span.Events().Len()),
+					DroppedEventsCount:     int64(span.DroppedEventsCount()),
+					Links:                  make([]spanLinkRecord, 0, span.Links().Len()),
+					DroppedLinksCount:      int64(span.DroppedLinksCount()),
+					StatusCode:             span.Status().Code().String(),
+					StatusMessage:          span.Status().Message(),
+				}
+				for e := 0; e < span.Events().Len(); e++ {
+					event := span.Events().At(e)
+					record.Events = append(record.Events, spanEventRecord{
+						TimeUnixNano:           int64(event.Timestamp()),
+						Name:                   event.Name(),
+						Attributes:             attributesToMap(event.Attributes()),
+						DroppedAttributesCount: int64(event.DroppedAttributesCount()),
+					})
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					link := span.Links().At(l)
+					record.Links = append(record.Links, spanLinkRecord{
+						TraceID:                traceIDToString(link.TraceID()),
+						SpanID:                 spanIDToString(link.SpanID()),
+						TraceState:             string(link.TraceState()),
+						Attributes:             attributesToMap(link.Attributes()),
+						DroppedAttributesCount: int64(link.DroppedAttributesCount()),
+					})
+				}
+				records = append(records, record)
+			}
+		}
+	}
+	return records
+}
+
+func logRecords(ld plog.Logs) []interface{} {
+	records := make([]interface{}, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		resource := attributesToMap(rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				records = append(records, &logRecord{
+					ResourceAttributes:     resource,
+					ResourceSchemaURL:      rl.SchemaUrl(),
+					ScopeName:              sl.Scope().Name(),
+					ScopeVersion:           sl.Scope().Version(),
+					TimeUnixNano:           int64(lr.Timestamp()),
+					ObservedTimeUnixNano:   int64(lr.ObservedTimestamp()),
+					SeverityNumber:         int32(lr.SeverityNumber()),
+					SeverityText:           lr.SeverityText(),
+					Body:                   lr.Body().AsString(),
+					Attributes:             attributesToMap(lr.Attributes()),
+					DroppedAttributesCount: int64(lr.DroppedAttributesCount()),
+					Flags:                  int64(lr.Flags()),
+					TraceID:                traceIDToString(lr.TraceID()),
+					SpanID:                 spanIDToString(lr.SpanID()),
+				})
+			}
+		}
+	}
+	return records
+}
+
+// metricRecords splits the data points by their metric type
+// since each type is written to its own set of files.
+func metricRecords(md pmetric.Metrics) map[fileKind][]interface{} {
+	records := make(map[fileKind][]interface{})
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resource := attributesToMap(rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				switch m.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					for p := 0; p < m.Gauge().DataPoints().Len(); p++ {
+						record := numberDataPointToRecord(m.Gauge().DataPoints().At(p))
+						record.ResourceAttributes, record.ResourceSchemaURL = resource, rm.SchemaUrl()
+						record.ScopeName, record.ScopeVersion = sm.Scope().Name(), sm.Scope().Version()
+						record.MetricName, record.MetricDescription, record.MetricUnit = m.Name(), m.Description(), m.Unit()
+						records[kindGauges] = append(records[kindGauges], record)
+					}
+				case pmetric.MetricDataTypeSum:
+					for p := 0; p < m.Sum().DataPoints().Len(); p++ {
+						record := numberDataPointToRecord(m.Sum().DataPoints().At(p))
+						record.ResourceAttributes, record.ResourceSchemaURL = resource, rm.SchemaUrl()
+						record.ScopeName, record.ScopeVersion = sm.Scope().Name(), sm.Scope().Version()
+						record.MetricName, record.MetricDescription, record.MetricUnit = m.Name(), m.Description(), m.Unit()
+						record.AggregationTemporality = m.Sum().AggregationTemporality().String()
+						record.IsMonotonic = m.Sum().IsMonotonic()
+						records[kindSums] = append(records[kindSums], record)
+					}
+				case pmetric.MetricDataTypeHistogram:
+					for p := 0; p < m.Histogram().DataPoints().Len(); p++ {
+						dp := m.Histogram().DataPoints().At(p)
+						records[kindHistograms] = append(records[kindHistograms], &histogramDataPointRecord{
+							ResourceAttributes:     resource,
+							ResourceSchemaURL:      rm.SchemaUrl(),
+							ScopeName:              sm.Scope().Name(),
+							ScopeVersion:           sm.Scope().Version(),
+							MetricName:             m.Name(),
+							MetricDescription:      m.Description(),
+							MetricUnit:             m.Unit(),
+							AggregationTemporality: m.Histogram().AggregationTemporality().String(),
+							Attributes:             attributesToMap(dp.Attributes()),
+							StartTimeUnixNano:      int64(dp.StartTimestamp()),
+							TimeUnixNano:           int64(dp.Timestamp()),
+							Count:                  int64(dp.Count()),
+							Sum:                    optionalDouble(dp.HasSum(), dp.Sum()),
+							Min:                    optionalDouble(dp.HasMin(), dp.Min()),
+							Max:                    optionalDouble(dp.HasMax(), dp.Max()),
+							BucketCounts:           bucketCounts(dp.BucketCounts().AsRaw()),
+							ExplicitBounds:         append([]float64{}, dp.ExplicitBounds().AsRaw()...),
+							Flags:                  int64(dp.Flags()),
+						})
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					for p := 0; p < m.ExponentialHistogram().DataPoints().Len(); p++ {
+						dp := m.ExponentialHistogram().DataPoints().At(p)
+						records[kindExponentialHistograms] = append(records[kindExponentialHistograms], &exponentialHistogramDataPointRecord{
+							ResourceAttributes:     resource,
+							ResourceSchemaURL:      rm.SchemaUrl(),
+							ScopeName:              sm.Scope().Name(),
+							ScopeVersion:           sm.Scope().Version(),
+							MetricName:             m.Name(),
+							MetricDescription:      m.Description(),
+							MetricUnit:             m.Unit(),
+							AggregationTemporality: m.ExponentialHistogram().AggregationTemporality().String(),
+							Attributes:             attributesToMap(dp.Attributes()),
+							StartTimeUnixNano:      int64(dp.StartTimestamp()),
+							TimeUnixNano:           int64(dp.Timestamp()),
+							Count:                  int64(dp.Count()),
+							Sum:                    optionalDouble(dp.HasSum(), dp.Sum()),
+							Min:                    optionalDouble(dp.HasMin(), dp.Min()),
+							Max:                    optionalDouble(dp.HasMax(), dp.Max()),
+							Scale:                  dp.Scale(),
+							ZeroCount:              int64(dp.ZeroCount()),
+							PositiveOffset:         dp.Positive().Offset(),
+							PositiveBucketCounts:   bucketCounts(dp.Positive().BucketCounts().AsRaw()),
+							NegativeOffset:         dp.Negative().Offset(),
+							NegativeBucketCounts:   bucketCounts(dp.Negative().BucketCounts().AsRaw()),
+							Flags:                  int64(dp.Flags()),
+						})
+					}
+				case pmetric.MetricDataTypeSummary:
+					for p := 0; p < m.Summary().DataPoints().Len(); p++ {
+						dp := m.Summary().DataPoints().At(p)
+						record := &summaryDataPointRecord{
+							ResourceAttributes: resource,
+							ResourceSchemaURL:  rm.SchemaUrl(),
+							ScopeName:          sm.Scope().Name(),
+							ScopeVersion:       sm.Scope().Version(),
+							MetricName:         m.Name(),
+							MetricDescription:  m.Description(),
+							MetricUnit:         m.Unit(),
+							Attributes:         attributesToMap(dp.Attributes()),
+							StartTimeUnixNano:  int64(dp.StartTimestamp()),
+							TimeUnixNano:       int64(dp.Timestamp()),
+							Count:              int64(dp.Count()),
+							Sum:                dp.Sum(),
+							QuantileValues:     make([]quantileValueRecord, 0, dp.QuantileValues().Len()),
+							Flags:              int64(dp.Flags()),
+						}
+						for q := 0; q < dp.QuantileValues().Len(); q++ {
+							qv := dp.QuantileValues().At(q)
+							record.QuantileValues = append(record.QuantileValues, quantileValueRecord{
+								Quantile: qv.Quantile(),
+								Value:    qv.Value(),
+							})
+						}
+						records[kindSummaries] = append(records[kindSummaries], record)
+					}
+				}
+			}
+		}
+	}
+	return records
+}
+
+func numberDataPointToRecord(dp pmetric.NumberDataPoint) *numberDataPointRecord {
+	record := &numberDataPointRecord{
+		Attributes:        attributesToMap(dp.Attributes()),
+		StartTimeUnixNano: int64(dp.StartTimestamp()),
+		TimeUnixNano:      int64(dp.Timestamp()),
+		Flags:             int64(dp.Flags()),
+	}
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		v := dp.DoubleVal()
+		record.DoubleValue = &v
+	case pmetric.NumberDataPointValueTypeInt:
+		v := dp.IntVal()
+		record.IntValue = &v
+	}
+	return record
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+// The records below define the schema of each of the Parquet files written by the exporter.
+// Each row holds a single span, log record or metric data point along with the
+// resource and instrumentation scope that it was reported with.
+// Attribute values that are not strings are stored using their JSON representation.
+// Renaming or retyping a column is a breaking change for anyone reading the files.
+
+type spanRecord struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID           string            `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState             string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name                   string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Kind                   string            `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	EndTimeUnixNano        int64             `parquet:"name=end_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DurationNano           int64             `parquet:"name=duration_nano, type=INT64"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+	Events                 []spanEventRecord `parquet:"name=events, type=LIST"`
+	DroppedEventsCount     int64             `parquet:"name=dropped_events_count, type=INT64"`
+	Links                  []spanLinkRecord  `parquet:"name=links, type=LIST"`
+	DroppedLinksCount      int64             `parquet:"name=dropped_links_count, type=INT64"`
+	StatusCode             string            `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StatusMessage          string            `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+type spanEventRecord struct {
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Name                   string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+}
+
+type spanLinkRecord struct {
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState             string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+}
+
+type logRecord struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ObservedTimeUnixNano   int64             `parquet:"name=observed_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	SeverityNumber         int32             `parquet:"name=severity_number, type=INT32"`
+	SeverityText           string            `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Body                   string            `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+type numberDataPointRecord struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	IsMonotonic            bool              `parquet:"name=is_monotonic, type=BOOLEAN"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DoubleValue            *float64          `parquet:"name=double_value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	IntValue               *int64            `parquet:"name=int_value, type=INT64, repetitiontype=OPTIONAL"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+}
+
+type histogramDataPointRecord struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Count                  int64             `parquet:"name=count, type=INT64"`
+	Sum                    *float64          `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min                    *float64          `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max                    *float64          `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+	BucketCounts           []int64           `parquet:"name=bucket_counts, type=LIST, valuetype=INT64"`
+	ExplicitBounds         []float64         `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+}
+
+type exponentialHistogramDataPointRecord struct {
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Count                  int64             `parquet:"name=count, type=INT64"`
+	Sum                    *float64          `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Min                    *float64          `parquet:"name=min, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Max                    *float64          `parquet:"name=max, type=DOUBLE, repetitiontype=OPTIONAL"`
+	Scale                  int32             `parquet:"name=scale, type=INT32"`
+	ZeroCount              int64             `parquet:"name=zero_count, type=INT64"`
+	PositiveOffset         int32             `parquet:"name=positive_offset, type=INT32"`
+	PositiveBucketCounts   []int64           `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64"`
+	NegativeOffset         int32             `parquet:"name=negative_offset, type=INT32"`
+	NegativeBucketCounts   []int64           `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+}
+
+type summaryDataPointRecord struct {
+	ResourceAttributes map[string]string     `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ResourceSchemaURL  string                `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeName          string                `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string                `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricName         string                `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription  string                `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit         string                `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes         map[string]string     `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimeUnixNano  int64                 `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TimeUnixNano       int64                 `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Count              int64                 `parquet:"name=count, type=INT64"`
+	Sum                float64               `parquet:"name=sum, type=DOUBLE"`
+	QuantileValues     []quantileValueRecord `parquet:"name=quantile_values, type=LIST"`
+	Flags              int64                 `parquet:"name=flags, type=INT64"`
+}
+
+type quantileValueRecord struct {
+	Quantile float64 `parquet:"name=quantile, type=DOUBLE"`
+	Value    float64 `parquet:"name=value, type=DOUBLE"`
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+)
+
+// fileTimeFormat is used to name files so that they sort by the time they were started.
+const fileTimeFormat = "20060102T150405.000000000Z"
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+}
+
+// countingWriter keeps track of the number of bytes written to the file
+// since the Parquet writer only exposes an estimate of the buffered data.
+type countingWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.written += int64(n)
+	return n, err
+}
+
+// rollingWriter writes records of a single file kind into a directory,
+// starting a new file once the current one reaches the configured size or age.
+// Files are written with a hidden temporary name and renamed once they are
+// complete, so that readers never observe a file without its footer.
+type rollingWriter struct {
+	dir         string
+	kind        fileKind
+	compression parquet.CompressionCodec
+	maxBytes    int64
+	maxDuration time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	file    *os.File
+	counter *countingWriter
+	pw      *writer.ParquetWriter
+	name    string
+	opened  time.Time
+}
+
+func newRollingWriter(dir string, kind fileKind, cfg *Config) *rollingWriter {
+	return &rollingWriter{
+		dir:         filepath.Join(dir, string(kind)),
+		kind:        kind,
+		compression: compressionCodecs[cfg.Compression],
+		maxBytes:    int64(cfg.Rotation.MaxMegabytes) * 1024 * 1024,
+		maxDuration: cfg.Rotation.MaxDuration,
+		now:         time.Now,
+	}
+}
+
+func (w *rollingWriter) write(records []interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rotateExpiredLocked(); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if w.pw == nil {
+			if err := w.openLocked(); err != nil {
+				return err
+			}
+		}
+		if err := w.pw.Write(record); err != nil {
+			return multierr.Append(err, w.rollOverLocked())
+		}
+		if w.maxBytes > 0 && w.sizeLocked() >= w.maxBytes {
+			if err := w.closeLocked(); err != nil {
+				return err
+			}
+		}
+	}
+	if w.pw == nil {
+		return nil
+	}
+	// Encode the rows right away, so that they are not pending in the Parquet
+	// writer anymore when a later write fails.
+	if err := w.pw.Flush(false); err != nil {
+		return multierr.Append(err, w.rollOverLocked())
+	}
+	return nil
+}
+
+// rotateExpired closes the current file if it has been open longer than the max duration.
+func (w *rollingWriter) rotateExpired() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotateExpiredLocked()
+}
+
+func (w *rollingWriter) rotateExpiredLocked() error {
+	if w.pw == nil || w.maxDuration <= 0 || w.now().Sub(w.opened) < w.maxDuration {
+		return nil
+	}
+	return w.closeLocked()
+}
+
+func (w *rollingWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeLocked()
+}
+
+// sizeLocked estimates the final size of the file by combining the bytes
+// already written with the data buffered for the current row group.
+func (w *rollingWriter) sizeLocked() int64 {
+	return w.counter.written + w.pw.Size + w.pw.ObjsSize
+}
+
+func (w *rollingWriter) openLocked() error {
+	now := w.now()
+	if err := os.MkdirAll(w.dir, 0700); err != nil {
+		return err
+	}
+	w.name = fmt.Sprintf("%s-%s.parquet", w.kind, now.UTC().Format(fileTimeFormat))
+	f, err := os.OpenFile(w.tempPath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	counter := &countingWriter{w: f}
+	pw, err := writer.NewParquetWriterFromWriter(counter, schemas[w.kind], 1)
+	if err != nil {
+		return multierr.Append(err, multierr.Append(f.Close(), os.Remove(f.Name())))
+	}
+	pw.CompressionType = w.compression
+	if w.maxBytes > 0 && w.maxBytes < pw.RowGroupSize {
+		pw.RowGroupSize = w.maxBytes
+	}
+	w.file, w.counter, w.pw, w.opened = f, counter, pw, now
+	return nil
+}
+
+func (w *rollingWriter) closeLocked() error {
+	if w.pw == nil {
+		return nil
+	}
+	if err := w.pw.WriteStop(); err != nil {
+		return multierr.Append(err, w.abortLocked())
+	}
+	err := w.file.Close()
+	if err == nil {
+		err = os.Rename(w.tempPath(), filepath.Join(w.dir, w.name))
+	}
+	w.file, w.counter, w.pw = nil, nil, nil
+	return err
+}
+
+// rollOverLocked completes the current file after the Parquet writer failed to
+// write the rows of the current write, so that the next write starts a new file.
+// The rows of the previous writes have already been encoded and are kept, while
+// the failed rows still pending in the writer are dropped: they would make it
+// fail again when writing the footer.
+func (w *rollingWriter) rollOverLocked() error {
+	if pending := int64(len(w.pw.Objs)); w.pw.NumRows >= pending {
+		w.pw.NumRows -= pending
+	}
+	w.pw.Objs = w.pw.Objs[:0]
+	w.pw.ObjsSize = 0
+	return w.closeLocked()
+}
+
+// abortLocked discards the current file when it can not be completed,
+// since a Parquet file without its footer can't be read.
+func (w *rollingWriter) abortLocked() error {
+	err := multierr.Append(w.file.Close(), os.Remove(w.tempPath()))
+	w.file, w.counter, w.pw = nil, nil, nil
+	return err
+}
+
+func (w *rollingWriter) tempPath() string {
+	return filepath.Join(w.dir, "."+w.name+".tmp")
+}
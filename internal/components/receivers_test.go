@@ -270,6 +270,9 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "prometheus_simple",
 		},
+		{
+			receiver: "prometheusremotewrite",
+		},
 		{
 			receiver: "skywalking",
 		},
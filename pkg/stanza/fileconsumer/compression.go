@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+
+	gzipExtension = ".gz"
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionGzip:
+		return nil
+	}
+	return fmt.Errorf("invalid compression '%s'", compression)
+}
+
+// isCompressed returns true if the file at path should be decompressed before being read
+func (c *readerConfig) isCompressed(path string) bool {
+	return c.compression == compressionGzip && strings.HasSuffix(path, gzipExtension)
+}
+
+// gzipReader decompresses a gzip file which may still be in the process of being written.
+// A truncated stream is reported as io.EOF so that it is read again once more data is available.
+type gzipReader struct {
+	gz       *gzip.Reader
+	read     int64
+	complete bool
+}
+
+func newGzipReader(r io.Reader) (*gzipReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipReader{gz: gz}, nil
+}
+
+func (g *gzipReader) Read(dst []byte) (int, error) {
+	n, err := g.gz.Read(dst)
+	g.read += int64(n)
+	switch {
+	case errors.Is(err, io.EOF):
+		g.complete = true
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		err = io.EOF
+	}
+	return n, err
+}
+
+// newGzipFingerprint creates a fingerprint from the first decompressed bytes of a gzip file
+func newGzipFingerprint(file *os.File, size int) (*Fingerprint, error) {
+	gz, err := newGzipReader(io.NewSectionReader(file, 0, 1<<63-1))
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// The gzip header has not been fully written yet
+		return &Fingerprint{FirstBytes: []byte{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading gzip header: %w", err)
+	}
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(gz, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	return &Fingerprint{FirstBytes: buf[:n]}, nil
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipBytes(t testing.TB, content string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func withGzip(cfg *Config) {
+	cfg.Compression = compressionGzip
+}
+
+func TestReadGzipFile(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, withGzip)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	path := filepath.Join(tempDir, "app.log.gz")
+	require.NoError(t, os.WriteFile(path, gzipBytes(t, "testlog1\ntestlog2\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+	// A fully read compressed file is not read again
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestReadUncompressedFileWithGzip(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, withGzip)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	path := filepath.Join(tempDir, "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+}
+
+func TestReadPartiallyWrittenGzipFile(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, withGzip)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	lines := make([][]byte, 0, 50)
+	var content bytes.Buffer
+	for i := 0; i < 50; i++ {
+		line := tokenWithLength(100)
+		lines = append(lines, line)
+		content.Write(line)
+		content.WriteByte('\n')
+	}
+	compressed := gzipBytes(t, content.String())
+
+	path := filepath.Join(tempDir, "app.log.gz")
+	file := openFile(t, path)
+	_, err := file.Write(compressed[:len(compressed)/2])
+	require.NoError(t, err)
+
+	operator.poll(context.Background())
+	require.NotEmpty(t, emitCalls)
+	require.Less(t, len(emitCalls), len(lines))
+
+	_, err = file.Write(compressed[len(compressed)/2:])
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// Lines emitted before the file was complete are not emitted again
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, lines)
+}
+
+func TestRotateAndCompress(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Moving files while open is unsupported on Windows")
+	}
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, withGzip)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	path := filepath.Join(tempDir, "app.log")
+	file := openFile(t, path)
+	writeString(t, file, "testlog1\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	operator.wg.Wait()
+
+	// Written just before the file is rotated and compressed
+	writeString(t, file, "testlog2\n")
+	require.NoError(t, file.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path+".1.gz", gzipBytes(t, string(content)), 0600))
+	require.NoError(t, os.Remove(path))
+	writeString(t, openFile(t, path), "testlog3\n")
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog2"), []byte("testlog3")})
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestGzipFileStartAtEnd(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		withGzip(cfg)
+		cfg.StartAt = "end"
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	path := filepath.Join(tempDir, "app.log.gz")
+	require.NoError(t, os.WriteFile(path, gzipBytes(t, "testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestGzipFileOffsetPersisted(t *testing.T) {
+	t.Parallel()
+	persister := testutil.NewMockPersister("test")
+	operator, emitCalls, tempDir := newTestScenario(t, withGzip)
+	operator.persister = persister
+
+	path := filepath.Join(tempDir, "app.log.gz")
+	require.NoError(t, os.WriteFile(path, gzipBytes(t, "testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.NoError(t, operator.Stop())
+
+	operator, emitCalls, _ = newTestScenario(t, withGzip)
+	operator.persister = persister
+	require.NoError(t, operator.loadLastPollFiles(context.Background()))
+	require.Len(t, operator.knownFiles, 1)
+	require.NotZero(t, operator.knownFiles[0].CompressedSize)
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	operator.finder.Include = []string{path}
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
@@ -15,6 +15,7 @@
 package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 
 import (
+	"bufio"
 	"fmt"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 const (
 	defaultMaxLogSize         = 1024 * 1024
 	defaultMaxConcurrentFiles = 1024
+	defaultDeleteQuietPeriod  = time.Minute
 )
 
 // NewConfig creates a new input config with default values
@@ -42,6 +44,7 @@ func NewConfig() *Config {
 		FingerprintSize:         DefaultFingerprintSize,
 		MaxLogSize:              defaultMaxLogSize,
 		MaxConcurrentFiles:      defaultMaxConcurrentFiles,
+		DeleteQuietPeriod:       helper.Duration{Duration: defaultDeleteQuietPeriod},
 	}
 }
 
@@ -57,11 +60,28 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"               json:"fingerprint_size,omitempty"              yaml:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"                   json:"max_log_size,omitempty"                  yaml:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"           json:"max_concurrent_files,omitempty"          yaml:"max_concurrent_files,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"                    json:"compression,omitempty"                   yaml:"compression,omitempty"`
+	ExcludeOlderThan        helper.Duration       `mapstructure:"exclude_older_than,omitempty"             json:"exclude_older_than,omitempty"            yaml:"exclude_older_than,omitempty"`
+	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"              json:"delete_after_read,omitempty"             yaml:"delete_after_read,omitempty"`
+	DeleteQuietPeriod       helper.Duration       `mapstructure:"delete_quiet_period,omitempty"            json:"delete_quiet_period,omitempty"           yaml:"delete_quiet_period,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"                        json:",inline,omitempty"                       yaml:",inline,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
 func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error) {
+	return c.build(logger, emit, nil)
+}
+
+// BuildWithSplitFunc will build a file input operator from the supplied configuration, splitting the
+// files into tokens with the given function instead of the multiline and encoding settings.
+func (c Config) BuildWithSplitFunc(logger *zap.SugaredLogger, emit EmitFunc, splitFunc bufio.SplitFunc) (*Input, error) {
+	if splitFunc == nil {
+		return nil, fmt.Errorf("must provide split function")
+	}
+	return c.build(logger, emit, splitFunc)
+}
+
+func (c Config) build(logger *zap.SugaredLogger, emit EmitFunc, splitFunc bufio.SplitFunc) (*Input, error) {
 	if emit == nil {
 		return nil, fmt.Errorf("must provide emit function")
 	}
@@ -100,12 +120,17 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error)
 		return nil, fmt.Errorf("`fingerprint_size` must be at least %d bytes", MinFingerprintSize)
 	}
 
-	// Ensure that splitter is buildable
-	_, err := c.Splitter.Build(false, int(c.MaxLogSize))
-	if err != nil {
+	if err := validateCompression(c.Compression); err != nil {
 		return nil, err
 	}
 
+	// Ensure that splitter is buildable
+	if splitFunc == nil {
+		if _, err := c.Splitter.Build(false, int(c.MaxLogSize)); err != nil {
+			return nil, err
+		}
+	}
+
 	var startAtBeginning bool
 	switch c.StartAt {
 	case "beginning":
@@ -116,6 +141,18 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error)
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	if c.ExcludeOlderThan.Raw() < 0 {
+		return nil, fmt.Errorf("`exclude_older_than` cannot be negative")
+	}
+
+	if c.DeleteAfterRead && !startAtBeginning {
+		return nil, fmt.Errorf("`delete_after_read` requires `start_at` to be 'beginning'")
+	}
+
+	if c.DeleteQuietPeriod.Raw() < 0 {
+		return nil, fmt.Errorf("`delete_quiet_period` cannot be negative")
+	}
+
 	return &Input{
 		SugaredLogger:      logger.With("component", "fileconsumer"),
 		finder:             c.Finder,
@@ -126,16 +163,21 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Input, error)
 		knownFiles:         make([]*Reader, 0, 10),
 		roller:             newRoller(),
 		MaxConcurrentFiles: c.MaxConcurrentFiles,
+		excludeOlderThan:   c.ExcludeOlderThan.Raw(),
+		deleteAfterRead:    c.DeleteAfterRead,
+		deleteQuietPeriod:  c.DeleteQuietPeriod.Raw(),
 		SeenPaths:          make(map[string]struct{}, 100),
 		readerFactory: readerFactory{
 			SugaredLogger: logger.With("component", "fileconsumer"),
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				compression:     c.Compression,
 				emit:            emit,
 			},
 			fromBeginning:  startAtBeginning,
 			splitterConfig: c.Splitter,
+			splitFunc:      splitFunc,
 		},
 	}, nil
 }
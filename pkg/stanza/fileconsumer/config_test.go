@@ -320,6 +320,35 @@ func TestUnmarshal(t *testing.T) {
 				return cfg
 			}(),
 		},
+		{
+			Name:      "compression_gzip",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.Compression = "gzip"
+				return cfg
+			}(),
+		},
+		{
+			Name:      "exclude_older_than",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.ExcludeOlderThan = helper.Duration{Duration: 24 * time.Hour}
+				return cfg
+			}(),
+		},
+		{
+			Name:      "delete_after_read",
+			ExpectErr: false,
+			Expect: func() *Config {
+				cfg := NewConfig()
+				cfg.StartAt = "beginning"
+				cfg.DeleteAfterRead = true
+				cfg.DeleteQuietPeriod = helper.Duration{Duration: 5 * time.Minute}
+				return cfg
+			}(),
+		},
 		{
 			Name:      "max_concurrent_large",
 			ExpectErr: false,
@@ -508,6 +537,72 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"Gzip",
+			func(f *Config) {
+				f.Compression = "gzip"
+			},
+			require.NoError,
+			func(t *testing.T, f *Input) {
+				require.Equal(t, "gzip", f.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "zip"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ExcludeOlderThan",
+			func(f *Config) {
+				f.ExcludeOlderThan = helper.Duration{Duration: time.Hour}
+			},
+			require.NoError,
+			func(t *testing.T, f *Input) {
+				require.Equal(t, time.Hour, f.excludeOlderThan)
+			},
+		},
+		{
+			"NegativeExcludeOlderThan",
+			func(f *Config) {
+				f.ExcludeOlderThan = helper.Duration{Duration: -time.Hour}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterRead",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+			},
+			require.NoError,
+			func(t *testing.T, f *Input) {
+				require.True(t, f.deleteAfterRead)
+			},
+		},
+		{
+			"NegativeDeleteQuietPeriod",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+				f.DeleteQuietPeriod = helper.Duration{Duration: -time.Minute}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterReadStartAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.DeleteAfterRead = true
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidLineEndRegex",
 			func(f *Config) {
@@ -571,6 +666,7 @@ func TestMapStructureDecodeConfigWithHook(t *testing.T) {
 			"line_end_pattern":   expect.Splitter.Multiline.LineEndPattern,
 		},
 		"force_flush_period":   0.5,
+		"delete_quiet_period":  60,
 		"include_file_name":    true,
 		"include_file_path":    false,
 		"start_at":             "end",
@@ -616,6 +712,9 @@ func TestMapStructureDecodeConfig(t *testing.T) {
 		"force_flush_period": map[string]interface{}{
 			"Duration": 500 * 1000 * 1000,
 		},
+		"delete_quiet_period": map[string]interface{}{
+			"Duration": 60 * 1000 * 1000 * 1000,
+		},
 	}
 
 	var actual Config
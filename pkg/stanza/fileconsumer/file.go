@@ -39,6 +39,10 @@ type Input struct {
 	MaxConcurrentFiles int
 	SeenPaths          map[string]struct{}
 
+	excludeOlderThan  time.Duration
+	deleteAfterRead   bool
+	deleteQuietPeriod time.Duration
+
 	persister operator.Persister
 
 	knownFiles    []*Reader
@@ -127,7 +131,9 @@ func (f *Input) poll(ctx context.Context) {
 				f.Warnw("no files match the configured include patterns",
 					"include", f.finder.Include,
 					"exclude", f.finder.Exclude)
-			} else if len(matches) > f.maxBatchFiles {
+			}
+			matches = f.excludeOlderFiles(matches)
+			if len(matches) > f.maxBatchFiles {
 				matches, f.queuedMatches = matches[:f.maxBatchFiles], matches[f.maxBatchFiles:]
 			}
 		}
@@ -156,7 +162,79 @@ func (f *Input) poll(ctx context.Context) {
 
 	f.roller.roll(ctx, readers)
 	f.saveCurrent(readers)
-	f.syncLastPollFiles(ctx)
+	if err := f.syncLastPollFiles(ctx); err != nil {
+		f.Errorw("Failed to sync to database", zap.Error(err))
+		return
+	}
+
+	// Files are only deleted once their offsets have been persisted
+	if f.deleteAfterRead {
+		f.deleteConsumedFiles(readers)
+	}
+}
+
+// excludeOlderFiles removes the paths of files which have not been modified within excludeOlderThan
+func (f *Input) excludeOlderFiles(paths []string) []string {
+	if f.excludeOlderThan == 0 {
+		return paths
+	}
+	cutoff := time.Now().Add(-f.excludeOlderThan)
+	filtered := paths[:0]
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			f.Debugw("Failed to stat file", "path", path, zap.Error(err))
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			continue
+		}
+		filtered = append(filtered, path)
+	}
+	return filtered
+}
+
+// deleteConsumedFiles deletes the files which have been read to their end and have not been modified
+// for deleteQuietPeriod, and forgets about them so that a new file with the same fingerprint is read from the beginning
+func (f *Input) deleteConsumedFiles(readers []*Reader) {
+	cutoff := time.Now().Add(-f.deleteQuietPeriod)
+	deleted := make(map[*Reader]struct{}, len(readers))
+	for _, reader := range readers {
+		if !reader.consumed {
+			continue
+		}
+		path := reader.file.Name()
+		info, err := reader.file.Stat()
+		if err != nil {
+			f.Errorw("Failed to stat file", "path", path, zap.Error(err))
+			continue
+		}
+		// The file may still be written to, in which case it is read again during the next poll
+		readSize := reader.Offset
+		if reader.compressed {
+			readSize = reader.CompressedSize
+		}
+		if info.Size() != readSize || info.ModTime().After(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			f.Errorw("Failed to delete file", "path", path, zap.Error(err))
+			continue
+		}
+		delete(f.SeenPaths, path)
+		deleted[reader] = struct{}{}
+	}
+	if len(deleted) == 0 {
+		return
+	}
+
+	knownFiles := f.knownFiles[:0]
+	for _, reader := range f.knownFiles {
+		if _, ok := deleted[reader]; !ok {
+			knownFiles = append(knownFiles, reader)
+		}
+	}
+	f.knownFiles = knownFiles
 }
 
 // makeReaders takes a list of paths, then creates readers from each of those paths,
@@ -278,14 +356,13 @@ func (f *Input) findFingerprintMatch(fp *Fingerprint) (*Reader, bool) {
 const knownFilesKey = "knownFiles"
 
 // syncLastPollFiles syncs the most recent set of files to the database
-func (f *Input) syncLastPollFiles(ctx context.Context) {
+func (f *Input) syncLastPollFiles(ctx context.Context) error {
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 
 	// Encode the number of known files
 	if err := enc.Encode(len(f.knownFiles)); err != nil {
-		f.Errorw("Failed to encode known files", zap.Error(err))
-		return
+		return fmt.Errorf("encode known files: %w", err)
 	}
 
 	// Encode each known file
@@ -295,9 +372,7 @@ func (f *Input) syncLastPollFiles(ctx context.Context) {
 		}
 	}
 
-	if err := f.persister.Set(ctx, knownFilesKey, buf.Bytes()); err != nil {
-		f.Errorw("Failed to sync to database", zap.Error(err))
-	}
+	return f.persister.Set(ctx, knownFilesKey, buf.Bytes())
 }
 
 // syncLastPollFiles loads the most recent set of files to the database
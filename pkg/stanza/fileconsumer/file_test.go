@@ -15,7 +15,9 @@
 package fileconsumer
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
@@ -214,6 +217,41 @@ func TestReadExistingLogs(t *testing.T) {
 }
 
 // TestReadUsingNopEncoding tests when nop encoding is set, that the splitfunction returns all bytes unchanged.
+func TestReadUsingSplitFunc(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := newDefaultConfig(tempDir)
+	emitCalls := make(chan *emitParams, 100)
+	emit := func(_ context.Context, attrs *FileAttributes, token []byte) {
+		emitCalls <- &emitParams{attrs, token}
+	}
+
+	_, err := cfg.BuildWithSplitFunc(testutil.Logger(t), emit, nil)
+	require.EqualError(t, err, "must provide split function")
+
+	// tokens end with a semicolon, regardless of the line breaks
+	splitFunc := func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, ';'); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, nil
+	}
+	operator, err := cfg.BuildWithSplitFunc(testutil.Logger(t), emit, splitFunc)
+	require.NoError(t, err)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ncontinued;testlog2;partial")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1\ncontinued"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+}
+
 func TestReadUsingNopEncoding(t *testing.T) {
 	tcs := []struct {
 		testName string
@@ -1121,3 +1159,172 @@ func TestEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestExcludeOlderThan(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.ExcludeOlderThan = helper.Duration{Duration: time.Hour}
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	oldFile := openTemp(t, tempDir)
+	writeString(t, oldFile, "old log\n")
+	require.NoError(t, oldFile.Close())
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(oldFile.Name(), twoHoursAgo, twoHoursAgo))
+
+	newFile := openTemp(t, tempDir)
+	writeString(t, newFile, "new log\n")
+	require.NoError(t, newFile.Close())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("new log"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestDeleteAfterRead(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.DeleteQuietPeriod = helper.Duration{}
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	// The same content in each file checks that deleted files are forgotten
+	paths := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		temp := openTemp(t, tempDir)
+		writeString(t, temp, "job log\n")
+		require.NoError(t, temp.Close())
+		paths = append(paths, temp.Name())
+	}
+
+	// Files with duplicate fingerprints are read one per poll
+	for i := 0; i < 3; i++ {
+		operator.poll(context.Background())
+		waitForToken(t, emitCalls, []byte("job log"))
+	}
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	for _, path := range paths {
+		require.NoFileExists(t, path)
+	}
+	require.Empty(t, operator.knownFiles)
+}
+
+func TestDeleteAfterReadIncompleteFile(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.DeleteQuietPeriod = helper.Duration{}
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, temp.Name())
+
+	writeString(t, temp, "\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	require.NoFileExists(t, temp.Name())
+}
+
+func TestDeleteAfterReadFlushedPartialToken(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.DeleteQuietPeriod = helper.Duration{}
+		cfg.Splitter.Flusher.Period = helper.Duration{Duration: 10 * time.Millisecond}
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2")
+	require.NoError(t, temp.Close())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, temp.Name())
+
+	time.Sleep(20 * time.Millisecond)
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	require.NoFileExists(t, temp.Name())
+}
+
+func TestDeleteAfterReadQuietPeriod(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.DeleteQuietPeriod = helper.Duration{Duration: time.Hour}
+	})
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+
+	// The file was just written to, so it may not be complete yet
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, temp.Name())
+
+	writeString(t, temp, "testlog2\n")
+	require.NoError(t, temp.Close())
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	require.FileExists(t, temp.Name())
+
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(temp.Name(), twoHoursAgo, twoHoursAgo))
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+	require.NoFileExists(t, temp.Name())
+}
+
+type failingPersister struct {
+	operator.Persister
+}
+
+func (failingPersister) Set(context.Context, string, []byte) error {
+	return errors.New("failed to set")
+}
+
+func TestDeleteAfterReadSyncFailure(t *testing.T) {
+	t.Parallel()
+	operator, emitCalls, tempDir := newTestScenario(t, func(cfg *Config) {
+		cfg.DeleteAfterRead = true
+		cfg.DeleteQuietPeriod = helper.Duration{}
+	})
+	operator.persister = failingPersister{testutil.NewMockPersister("test")}
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\n")
+	require.NoError(t, temp.Close())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, temp.Name())
+}
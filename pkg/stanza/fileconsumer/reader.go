@@ -16,7 +16,9 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -27,6 +29,7 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	compression     string
 	emit            EmitFunc
 }
 
@@ -36,10 +39,17 @@ type Reader struct {
 	*readerConfig
 	splitter *helper.Splitter
 
-	Fingerprint    *Fingerprint
-	Offset         int64
+	Fingerprint *Fingerprint
+	// Offset is the position in the decompressed content for compressed files
+	Offset int64
+	// CompressedSize is the size of a compressed file which has been read to its end
+	CompressedSize int64 `json:",omitempty"`
+
 	generation     int
 	file           *os.File
+	src            io.Reader
+	compressed     bool
+	consumed       bool
 	fileAttributes *FileAttributes
 }
 
@@ -49,16 +59,85 @@ func (r *Reader) offsetToEnd() error {
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
 	}
-	r.Offset = info.Size()
+	if !r.compressed {
+		r.Offset = info.Size()
+		return nil
+	}
+
+	// The decompressed size of a gzip file is only known after decompressing it
+	if _, err = r.file.Seek(0, 0); err != nil {
+		return fmt.Errorf("seek: %w", err)
+	}
+	gz, err := newGzipReader(r.file)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read gzip header: %w", err)
+	}
+	if r.Offset, err = io.Copy(io.Discard, gz); err != nil {
+		return fmt.Errorf("decompress: %w", err)
+	}
+	if gz.complete {
+		r.CompressedSize = info.Size()
+	}
 	return nil
 }
 
+// seekToOffset prepares the source from which the file is read, starting at Offset.
+// It returns false if there is nothing to read.
+func (r *Reader) seekToOffset() (bool, error) {
+	if !r.compressed {
+		if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			return false, err
+		}
+		r.src = r.file
+		return true, nil
+	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() == r.CompressedSize {
+		// Compressed files are not appended to once complete
+		r.consumed = true
+		return false, nil
+	}
+
+	// Compressed streams can't be seeked, so the content before Offset is decompressed and discarded
+	if _, err = r.file.Seek(0, 0); err != nil {
+		return false, err
+	}
+	gz, err := newGzipReader(r.file)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// The gzip header has not been fully written yet
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err = io.CopyN(io.Discard, gz, r.Offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	r.src = gz
+	return true, nil
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	r.consumed = false
+	ok, err := r.seekToOffset()
+	if err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
+	if !ok {
+		return
+	}
 
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitter.SplitFunc)
 
@@ -74,6 +153,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		if !ok {
 			if err := scanner.getError(); err != nil {
 				r.Errorw("Failed during scan", zap.Error(err))
+				return
 			}
 			break
 		}
@@ -87,6 +167,20 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 
 		r.Offset = scanner.Pos()
 	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		r.Errorw("Failed to stat", zap.Error(err))
+		return
+	}
+	if gz, ok := r.src.(*gzipReader); ok {
+		if gz.complete {
+			r.CompressedSize = info.Size()
+			r.consumed = r.Offset == gz.read
+		}
+		return
+	}
+	r.consumed = r.Offset == info.Size()
 }
 
 // Close will close the file
@@ -103,9 +197,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
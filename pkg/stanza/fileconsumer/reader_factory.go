@@ -15,9 +15,11 @@
 package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 
 import (
+	"bufio"
 	"os"
 
 	"go.uber.org/zap"
+	"golang.org/x/text/encoding"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
@@ -27,6 +29,8 @@ type readerFactory struct {
 	readerConfig   *readerConfig
 	fromBeginning  bool
 	splitterConfig helper.SplitterConfig
+	// splitFunc, when set, is used instead of the splitter config, and the tokens are emitted as read
+	splitFunc bufio.SplitFunc
 }
 
 func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
@@ -42,6 +46,7 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
+		withCompressedSize(old.CompressedSize).
 		withSplitter(old.splitter).
 		build()
 }
@@ -51,15 +56,19 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	if f.readerConfig.isCompressed(file.Name()) {
+		return newGzipFingerprint(file, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
 type readerBuilder struct {
 	*readerFactory
-	file     *os.File
-	fp       *Fingerprint
-	offset   int64
-	splitter *helper.Splitter
+	file           *os.File
+	fp             *Fingerprint
+	offset         int64
+	compressedSize int64
+	splitter       *helper.Splitter
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -86,14 +95,25 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withCompressedSize(size int64) *readerBuilder {
+	b.compressedSize = size
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:   b.readerConfig,
+		Offset:         b.offset,
+		CompressedSize: b.compressedSize,
 	}
 
 	if b.splitter != nil {
 		r.splitter = b.splitter
+	} else if b.splitFunc != nil {
+		r.splitter = &helper.Splitter{
+			Encoding:  helper.Encoding{Encoding: encoding.Nop},
+			SplitFunc: b.splitFunc,
+		}
 	} else {
 		r.splitter, err = b.splitterConfig.Build(false, b.readerConfig.maxLogSize)
 		if err != nil {
@@ -103,6 +123,7 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 
 	if b.file != nil {
 		r.file = b.file
+		r.compressed = b.readerConfig.isCompressed(b.file.Name())
 		r.SugaredLogger = b.SugaredLogger.With("path", b.file.Name())
 		r.fileAttributes, err = resolveFileAttributes(b.file.Name())
 		if err != nil {
@@ -780,6 +780,7 @@ func TestMapStructureDecodeConfigWithHook(t *testing.T) {
 			"line_end_pattern":   expect.Splitter.Multiline.LineEndPattern,
 		},
 		"force_flush_period":   0.5,
+		"delete_quiet_period":  60,
 		"include_file_name":    true,
 		"include_file_path":    false,
 		"start_at":             "end",
@@ -825,6 +826,9 @@ func TestMapStructureDecodeConfig(t *testing.T) {
 		"force_flush_period": map[string]interface{}{
 			"Duration": 500 * 1000 * 1000,
 		},
+		"delete_quiet_period": map[string]interface{}{
+			"Duration": 60 * 1000 * 1000 * 1000,
+		},
 	}
 
 	var actual Config
@@ -0,0 +1,154 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tqlcommon holds the path accessors and value helpers shared by the telemetry query language contexts.
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// PathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
+type PathGetSetter struct {
+	Getter tql.ExprFunc
+	Setter func(ctx tql.TransformContext, val interface{})
+}
+
+func (path PathGetSetter) Get(ctx tql.TransformContext) interface{} {
+	return path.Getter(ctx)
+}
+
+func (path PathGetSetter) Set(ctx tql.TransformContext, val interface{}) {
+	path.Setter(ctx, val)
+}
+
+// ParseEnum always returns an error, it is used by contexts that do not define any enums.
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	if val != nil {
+		return nil, fmt.Errorf("enum symbol, %s, not found", *val)
+	}
+	return nil, fmt.Errorf("enum symbol not provided")
+}
+
+// ResourcePathGetSetter resolves a path expression whose first field is "resource".
+func ResourcePathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	if len(path) == 1 {
+		return AccessResource(), nil
+	}
+	switch path[1].Name {
+	case "attributes":
+		keys := path[1].Keys
+		if len(keys) == 0 {
+			return AccessResourceAttributes(), nil
+		}
+		return AccessResourceAttributesKey(keys), nil
+	}
+	return nil, fmt.Errorf("invalid path expression %v", path)
+}
+
+// ScopePathGetSetter resolves a path expression whose first field is "instrumentation_scope".
+func ScopePathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	if len(path) == 1 {
+		return AccessInstrumentationScope(), nil
+	}
+	switch path[1].Name {
+	case "name":
+		return AccessInstrumentationScopeName(), nil
+	case "version":
+		return AccessInstrumentationScopeVersion(), nil
+	}
+	return nil, fmt.Errorf("invalid path expression %v", path)
+}
+
+func AccessResource() PathGetSetter {
+	return PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetResource()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newRes, ok := val.(pcommon.Resource); ok {
+				ctx.GetResource().Attributes().Clear()
+				newRes.CopyTo(ctx.GetResource())
+			}
+		},
+	}
+}
+
+func AccessResourceAttributes() PathGetSetter {
+	return PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetResource().Attributes()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				ctx.GetResource().Attributes().Clear()
+				attrs.CopyTo(ctx.GetResource().Attributes())
+			}
+		},
+	}
+}
+
+func AccessResourceAttributesKey(keys []tql.Key) PathGetSetter {
+	return PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return GetAttr(ctx.GetResource().Attributes(), keys)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			SetAttr(ctx.GetResource().Attributes(), keys, val)
+		},
+	}
+}
+
+func AccessInstrumentationScope() PathGetSetter {
+	return PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetInstrumentationScope()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
+				newIl.CopyTo(ctx.GetInstrumentationScope())
+			}
+		},
+	}
+}
+
+func AccessInstrumentationScopeName() PathGetSetter {
+	return PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetInstrumentationScope().Name()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetInstrumentationScope().SetName(str)
+			}
+		},
+	}
+}
+
+func AccessInstrumentationScopeVersion() PathGetSetter {
+	return PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetInstrumentationScope().Version()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetInstrumentationScope().SetVersion(str)
+			}
+		},
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func Test_ResourcePathGetSetter(t *testing.T) {
+	_, err := ResourcePathGetSetter([]tql.Field{{Name: "resource"}, {Name: "unknown"}})
+	assert.Error(t, err)
+
+	str := "str"
+	getSetter, err := ResourcePathGetSetter([]tql.Field{{Name: "resource"}, {Name: "attributes", Keys: []tql.Key{{String: &str}}}})
+	require.NoError(t, err)
+
+	ctx := testContext{resource: pcommon.NewResource(), scope: pcommon.NewInstrumentationScope()}
+	getSetter.Set(ctx, "val")
+	assert.Equal(t, "val", getSetter.Get(ctx))
+}
+
+func Test_ScopePathGetSetter(t *testing.T) {
+	_, err := ScopePathGetSetter([]tql.Field{{Name: "instrumentation_scope"}, {Name: "unknown"}})
+	assert.Error(t, err)
+
+	getSetter, err := ScopePathGetSetter([]tql.Field{{Name: "instrumentation_scope"}, {Name: "version"}})
+	require.NoError(t, err)
+
+	ctx := testContext{resource: pcommon.NewResource(), scope: pcommon.NewInstrumentationScope()}
+	getSetter.Set(ctx, "v1")
+	assert.Equal(t, "v1", ctx.scope.Version())
+}
+
+func Test_ParseEnum(t *testing.T) {
+	sym := tql.EnumSymbol("SOME_ENUM")
+	_, err := ParseEnum(&sym)
+	assert.EqualError(t, err, "enum symbol, SOME_ENUM, not found")
+	_, err = ParseEnum(nil)
+	assert.EqualError(t, err, "enum symbol not provided")
+}
+
+func Test_ParseIDs(t *testing.T) {
+	traceID, err := ParseTraceID("0102030405060708090a0b0c0d0e0f10")
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), traceID)
+	_, err = ParseTraceID("0102")
+	assert.Error(t, err)
+
+	spanID, err := ParseSpanID("0102030405060708")
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), spanID)
+	_, err = ParseSpanID("zz")
+	assert.Error(t, err)
+}
+
+type testContext struct {
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+}
+
+func (ctx testContext) GetItem() interface{} {
+	return nil
+}
+
+func (ctx testContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.scope
+}
+
+func (ctx testContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
@@ -0,0 +1,155 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// GetAttr returns the value found at keys in attrs, or nil if there is none.
+func GetAttr(attrs pcommon.Map, keys []tql.Key) interface{} {
+	val, ok := tql.GetMapValue(attrs, keys)
+	if !ok {
+		return nil
+	}
+	return GetValue(val)
+}
+
+// GetValue converts val into the type the telemetry query language works with.
+func GetValue(val pcommon.Value) interface{} {
+	switch val.Type() {
+	case pcommon.ValueTypeString:
+		return val.StringVal()
+	case pcommon.ValueTypeBool:
+		return val.BoolVal()
+	case pcommon.ValueTypeInt:
+		return val.IntVal()
+	case pcommon.ValueTypeDouble:
+		return val.DoubleVal()
+	case pcommon.ValueTypeMap:
+		return val.MapVal()
+	case pcommon.ValueTypeSlice:
+		return val.SliceVal()
+	case pcommon.ValueTypeBytes:
+		return val.MBytesVal()
+	}
+	return nil
+}
+
+// SetAttr upserts val at keys in attrs, creating the value with the matching type.
+func SetAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) {
+	parent, mapKey, ok := tql.GetParentMap(attrs, keys)
+	if !ok {
+		return
+	}
+	switch v := val.(type) {
+	case string:
+		parent.UpsertString(mapKey, v)
+	case bool:
+		parent.UpsertBool(mapKey, v)
+	case int64:
+		parent.UpsertInt(mapKey, v)
+	case float64:
+		parent.UpsertDouble(mapKey, v)
+	case []byte:
+		parent.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
+	case []string:
+		arr := pcommon.NewValueSlice()
+		for _, str := range v {
+			arr.SliceVal().AppendEmpty().SetStringVal(str)
+		}
+		parent.Upsert(mapKey, arr)
+	case []bool:
+		arr := pcommon.NewValueSlice()
+		for _, b := range v {
+			arr.SliceVal().AppendEmpty().SetBoolVal(b)
+		}
+		parent.Upsert(mapKey, arr)
+	case []int64:
+		arr := pcommon.NewValueSlice()
+		for _, i := range v {
+			arr.SliceVal().AppendEmpty().SetIntVal(i)
+		}
+		parent.Upsert(mapKey, arr)
+	case []float64:
+		arr := pcommon.NewValueSlice()
+		for _, f := range v {
+			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
+		}
+		parent.Upsert(mapKey, arr)
+	case [][]byte:
+		arr := pcommon.NewValueSlice()
+		for _, b := range v {
+			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
+		}
+		parent.Upsert(mapKey, arr)
+	case []interface{}:
+		arr := pcommon.NewValueSlice()
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				arr.SliceVal().AppendEmpty().SetStringVal(i)
+			case bool:
+				arr.SliceVal().AppendEmpty().SetBoolVal(i)
+			case int64:
+				arr.SliceVal().AppendEmpty().SetIntVal(i)
+			case float64:
+				arr.SliceVal().AppendEmpty().SetDoubleVal(i)
+			case []byte:
+				arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(i))
+			default:
+				arr.SliceVal().AppendEmpty()
+			}
+		}
+		parent.Upsert(mapKey, arr)
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		parent.Upsert(mapKey, m)
+	}
+}
+
+// ParseSpanID parses a hex encoded span id.
+func ParseSpanID(spanIDStr string) (pcommon.SpanID, error) {
+	id, err := hex.DecodeString(spanIDStr)
+	if err != nil {
+		return pcommon.SpanID{}, err
+	}
+	if len(id) != 8 {
+		return pcommon.SpanID{}, errors.New("span ids must be 8 bytes")
+	}
+	var idArr [8]byte
+	copy(idArr[:8], id)
+	return pcommon.NewSpanID(idArr), nil
+}
+
+// ParseTraceID parses a hex encoded trace id.
+func ParseTraceID(traceIDStr string) (pcommon.TraceID, error) {
+	id, err := hex.DecodeString(traceIDStr)
+	if err != nil {
+		return pcommon.TraceID{}, err
+	}
+	if len(id) != 16 {
+		return pcommon.TraceID{}, errors.New("traces ids must be 16 bytes")
+	}
+	var idArr [16]byte
+	copy(idArr[:16], id)
+	return pcommon.NewTraceID(idArr), nil
+}
@@ -16,8 +16,6 @@
 package tqllogs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/logs"
 
 import (
-	"encoding/hex"
-	"errors"
 	"fmt"
 	"time"
 
@@ -25,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	logsproto "go.opentelemetry.io/proto/otlp/logs/v1"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
@@ -46,20 +45,6 @@ func (ctx logTransformContext) GetResource() pcommon.Resource {
 	return ctx.resource
 }
 
-// pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
-type pathGetSetter struct {
-	getter tql.ExprFunc
-	setter func(ctx tql.TransformContext, val interface{})
-}
-
-func (path pathGetSetter) Get(ctx tql.TransformContext) interface{} {
-	return path.getter(ctx)
-}
-
-func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) {
-	path.setter(ctx, val)
-}
-
 var symbolTable = map[tql.EnumSymbol]tql.Enum{
 	"SEVERITY_NUMBER_UNSPECIFIED": tql.Enum(logsproto.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED),
 	"SEVERITY_NUMBER_TRACE":       tql.Enum(logsproto.SeverityNumber_SEVERITY_NUMBER_TRACE),
@@ -108,27 +93,9 @@ func ParsePath(val *tql.Path) (tql.GetSetter, error) {
 func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	switch path[0].Name {
 	case "resource":
-		if len(path) == 1 {
-			return accessResource(), nil
-		}
-		switch path[1].Name {
-		case "attributes":
-			mapKey := path[1].MapKey
-			if mapKey == nil {
-				return accessResourceAttributes(), nil
-			}
-			return accessResourceAttributesKey(mapKey), nil
-		}
+		return tqlcommon.ResourcePathGetSetter(path)
 	case "instrumentation_scope":
-		if len(path) == 1 {
-			return accessInstrumentationScope(), nil
-		}
-		switch path[1].Name {
-		case "name":
-			return accessInstrumentationScopeName(), nil
-		case "version":
-			return accessInstrumentationScopeVersion(), nil
-		}
+		return tqlcommon.ScopePathGetSetter(path)
 	case "time_unix_nano":
 		return accessTimeUnixNano(), nil
 	case "observed_time_unix_nano":
@@ -138,13 +105,17 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	case "severity_text":
 		return accessSeverityText(), nil
 	case "body":
-		return accessBody(), nil
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessBody(), nil
+		}
+		return accessBodyKey(keys), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "flags":
@@ -170,90 +141,12 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	return nil, fmt.Errorf("invalid path expression %v", path)
 }
 
-func accessResource() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if newRes, ok := val.(pcommon.Resource); ok {
-				ctx.GetResource().Attributes().Clear()
-				newRes.CopyTo(ctx.GetResource())
-			}
-		},
-	}
-}
-
-func accessResourceAttributes() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource().Attributes()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if attrs, ok := val.(pcommon.Map); ok {
-				ctx.GetResource().Attributes().Clear()
-				attrs.CopyTo(ctx.GetResource().Attributes())
-			}
-		},
-	}
-}
-
-func accessResourceAttributesKey(mapKey *string) pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
-		},
-	}
-}
-
-func accessInstrumentationScope() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
-				newIl.CopyTo(ctx.GetInstrumentationScope())
-			}
-		},
-	}
-}
-
-func accessInstrumentationScopeName() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Name()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if str, ok := val.(string); ok {
-				ctx.GetInstrumentationScope().SetName(str)
-			}
-		},
-	}
-}
-
-func accessInstrumentationScopeVersion() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Version()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if str, ok := val.(string); ok {
-				ctx.GetInstrumentationScope().SetVersion(str)
-			}
-		},
-	}
-}
-
-func accessTimeUnixNano() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessTimeUnixNano() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).Timestamp().AsTime().UnixNano()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
 			}
@@ -261,12 +154,12 @@ func accessTimeUnixNano() pathGetSetter {
 	}
 }
 
-func accessObservedTimeUnixNano() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessObservedTimeUnixNano() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).ObservedTimestamp().AsTime().UnixNano()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, i)))
 			}
@@ -274,12 +167,12 @@ func accessObservedTimeUnixNano() pathGetSetter {
 	}
 }
 
-func accessSeverityNumber() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessSeverityNumber() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return int64(ctx.GetItem().(plog.LogRecord).SeverityNumber())
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetSeverityNumber(plog.SeverityNumber(i))
 			}
@@ -287,12 +180,12 @@ func accessSeverityNumber() pathGetSetter {
 	}
 }
 
-func accessSeverityText() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessSeverityText() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).SeverityText()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if s, ok := val.(string); ok {
 				ctx.GetItem().(plog.LogRecord).SetSeverityText(s)
 			}
@@ -300,23 +193,45 @@ func accessSeverityText() pathGetSetter {
 	}
 }
 
-func accessBody() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getValue(ctx.GetItem().(plog.LogRecord).Body())
+func accessBody() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return tqlcommon.GetValue(ctx.GetItem().(plog.LogRecord).Body())
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			setValue(ctx.GetItem().(plog.LogRecord).Body(), val)
 		},
 	}
 }
 
-func accessAttributes() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessBodyKey(keys []tql.Key) tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			body := ctx.GetItem().(plog.LogRecord).Body()
+			if body.Type() != pcommon.ValueTypeMap {
+				return nil
+			}
+			return tqlcommon.GetAttr(body.MapVal(), keys)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			body := ctx.GetItem().(plog.LogRecord).Body()
+			if body.Type() == pcommon.ValueTypeEmpty {
+				pcommon.NewValueMap().CopyTo(body)
+			}
+			if body.Type() != pcommon.ValueTypeMap {
+				return
+			}
+			tqlcommon.SetAttr(body.MapVal(), keys, val)
+		},
+	}
+}
+
+func accessAttributes() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).Attributes()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if attrs, ok := val.(pcommon.Map); ok {
 				ctx.GetItem().(plog.LogRecord).Attributes().Clear()
 				attrs.CopyTo(ctx.GetItem().(plog.LogRecord).Attributes())
@@ -325,23 +240,23 @@ func accessAttributes() pathGetSetter {
 	}
 }
 
-func accessAttributesKey(mapKey *string) pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey)
+func accessAttributesKey(keys []tql.Key) tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return tqlcommon.GetAttr(ctx.GetItem().(plog.LogRecord).Attributes(), keys)
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey, val)
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			tqlcommon.SetAttr(ctx.GetItem().(plog.LogRecord).Attributes(), keys, val)
 		},
 	}
 }
 
-func accessDroppedAttributesCount() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessDroppedAttributesCount() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return int64(ctx.GetItem().(plog.LogRecord).DroppedAttributesCount())
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetDroppedAttributesCount(uint32(i))
 			}
@@ -349,12 +264,12 @@ func accessDroppedAttributesCount() pathGetSetter {
 	}
 }
 
-func accessFlags() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessFlags() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return int64(ctx.GetItem().(plog.LogRecord).Flags())
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if i, ok := val.(int64); ok {
 				ctx.GetItem().(plog.LogRecord).SetFlags(uint32(i))
 			}
@@ -362,12 +277,12 @@ func accessFlags() pathGetSetter {
 	}
 }
 
-func accessTraceID() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessTraceID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).TraceID()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newTraceID, ok := val.(pcommon.TraceID); ok {
 				ctx.GetItem().(plog.LogRecord).SetTraceID(newTraceID)
 			}
@@ -375,14 +290,14 @@ func accessTraceID() pathGetSetter {
 	}
 }
 
-func accessStringTraceID() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessStringTraceID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).TraceID().HexString()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if str, ok := val.(string); ok {
-				if traceID, err := parseTraceID(str); err == nil {
+				if traceID, err := tqlcommon.ParseTraceID(str); err == nil {
 					ctx.GetItem().(plog.LogRecord).SetTraceID(traceID)
 				}
 			}
@@ -390,12 +305,12 @@ func accessStringTraceID() pathGetSetter {
 	}
 }
 
-func accessSpanID() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessSpanID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).SpanID()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newSpanID, ok := val.(pcommon.SpanID); ok {
 				ctx.GetItem().(plog.LogRecord).SetSpanID(newSpanID)
 			}
@@ -403,14 +318,14 @@ func accessSpanID() pathGetSetter {
 	}
 }
 
-func accessStringSpanID() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessStringSpanID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.GetItem().(plog.LogRecord).SpanID().HexString()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if str, ok := val.(string); ok {
-				if spanID, err := parseSpanID(str); err == nil {
+				if spanID, err := tqlcommon.ParseSpanID(str); err == nil {
 					ctx.GetItem().(plog.LogRecord).SetSpanID(spanID)
 				}
 			}
@@ -418,81 +333,6 @@ func accessStringSpanID() pathGetSetter {
 	}
 }
 
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
-	if !ok {
-		return nil
-	}
-	return getValue(val)
-}
-
-func getValue(val pcommon.Value) interface{} {
-	switch val.Type() {
-	case pcommon.ValueTypeString:
-		return val.StringVal()
-	case pcommon.ValueTypeBool:
-		return val.BoolVal()
-	case pcommon.ValueTypeInt:
-		return val.IntVal()
-	case pcommon.ValueTypeDouble:
-		return val.DoubleVal()
-	case pcommon.ValueTypeMap:
-		return val.MapVal()
-	case pcommon.ValueTypeSlice:
-		return val.SliceVal()
-	case pcommon.ValueTypeBytes:
-		return val.MBytesVal()
-	}
-	return nil
-}
-
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
-	switch v := val.(type) {
-	case string:
-		attrs.UpsertString(mapKey, v)
-	case bool:
-		attrs.UpsertBool(mapKey, v)
-	case int64:
-		attrs.UpsertInt(mapKey, v)
-	case float64:
-		attrs.UpsertDouble(mapKey, v)
-	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
-	case []string:
-		arr := pcommon.NewValueSlice()
-		for _, str := range v {
-			arr.SliceVal().AppendEmpty().SetStringVal(str)
-		}
-		attrs.Upsert(mapKey, arr)
-	case []bool:
-		arr := pcommon.NewValueSlice()
-		for _, b := range v {
-			arr.SliceVal().AppendEmpty().SetBoolVal(b)
-		}
-		attrs.Upsert(mapKey, arr)
-	case []int64:
-		arr := pcommon.NewValueSlice()
-		for _, i := range v {
-			arr.SliceVal().AppendEmpty().SetIntVal(i)
-		}
-		attrs.Upsert(mapKey, arr)
-	case []float64:
-		arr := pcommon.NewValueSlice()
-		for _, f := range v {
-			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
-		}
-		attrs.Upsert(mapKey, arr)
-	case [][]byte:
-		arr := pcommon.NewValueSlice()
-		for _, b := range v {
-			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
-		}
-		attrs.Upsert(mapKey, arr)
-	default:
-		// TODO(anuraaga): Support set of map type.
-	}
-}
-
 func setValue(value pcommon.Value, val interface{}) {
 	switch v := val.(type) {
 	case string:
@@ -540,33 +380,9 @@ func setValue(value pcommon.Value, val interface{}) {
 		for _, b := range v {
 			value.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-	default:
-		// TODO(anuraaga): Support set of map type.
-	}
-}
-
-func parseSpanID(spanIDStr string) (pcommon.SpanID, error) {
-	id, err := hex.DecodeString(spanIDStr)
-	if err != nil {
-		return pcommon.SpanID{}, err
-	}
-	if len(id) != 8 {
-		return pcommon.SpanID{}, errors.New("span ids must be 8 bytes")
-	}
-	var idArr [8]byte
-	copy(idArr[:8], id)
-	return pcommon.NewSpanID(idArr), nil
-}
-
-func parseTraceID(traceIDStr string) (pcommon.TraceID, error) {
-	id, err := hex.DecodeString(traceIDStr)
-	if err != nil {
-		return pcommon.TraceID{}, err
-	}
-	if len(id) != 16 {
-		return pcommon.TraceID{}, errors.New("traces ids must be 16 bytes")
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		m.CopyTo(value)
 	}
-	var idArr [16]byte
-	copy(idArr[:16], id)
-	return pcommon.NewTraceID(idArr), nil
 }
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	metricsproto "go.opentelemetry.io/proto/otlp/metrics/v1"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
@@ -53,20 +54,6 @@ func (ctx metricTransformContext) GetMetrics() pmetric.MetricSlice {
 	return ctx.metrics
 }
 
-// pathGetSetter is a getSetter which has been resolved using a path expression provided by a user.
-type pathGetSetter struct {
-	getter tql.ExprFunc
-	setter func(ctx tql.TransformContext, val interface{})
-}
-
-func (path pathGetSetter) Get(ctx tql.TransformContext) interface{} {
-	return path.getter(ctx)
-}
-
-func (path pathGetSetter) Set(ctx tql.TransformContext, val interface{}) {
-	path.setter(ctx, val)
-}
-
 var symbolTable = map[tql.EnumSymbol]tql.Enum{
 	"AGGREGATION_TEMPORALITY_UNSPECIFIED":    tql.Enum(metricsproto.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED),
 	"AGGREGATION_TEMPORALITY_DELTA":          tql.Enum(metricsproto.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA),
@@ -101,27 +88,9 @@ func ParsePath(val *tql.Path) (tql.GetSetter, error) {
 func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	switch path[0].Name {
 	case "resource":
-		if len(path) == 1 {
-			return accessResource(), nil
-		}
-		switch path[1].Name {
-		case "attributes":
-			mapKey := path[1].MapKey
-			if mapKey == nil {
-				return accessResourceAttributes(), nil
-			}
-			return accessResourceAttributesKey(mapKey), nil
-		}
+		return tqlcommon.ResourcePathGetSetter(path)
 	case "instrumentation_scope":
-		if len(path) == 1 {
-			return accessInstrumentationScope(), nil
-		}
-		switch path[1].Name {
-		case "name":
-			return accessInstrumentationScopeName(), nil
-		case "version":
-			return accessInstrumentationScopeVersion(), nil
-		}
+		return tqlcommon.ScopePathGetSetter(path)
 	case "metric":
 		if len(path) == 1 {
 			return accessMetric(), nil
@@ -141,11 +110,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 			return accessMetricIsMonotonic(), nil
 		}
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "start_time_unix_nano":
 		return accessStartTimeUnixNano(), nil
 	case "time_unix_nano":
@@ -196,90 +165,12 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	return nil, fmt.Errorf("invalid path expression %v", path)
 }
 
-func accessResource() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if newRes, ok := val.(pcommon.Resource); ok {
-				ctx.GetResource().Attributes().Clear()
-				newRes.CopyTo(ctx.GetResource())
-			}
-		},
-	}
-}
-
-func accessResourceAttributes() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetResource().Attributes()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if attrs, ok := val.(pcommon.Map); ok {
-				ctx.GetResource().Attributes().Clear()
-				attrs.CopyTo(ctx.GetResource().Attributes())
-			}
-		},
-	}
-}
-
-func accessResourceAttributesKey(mapKey *string) pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
-		},
-	}
-}
-
-func accessInstrumentationScope() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if newIl, ok := val.(pcommon.InstrumentationScope); ok {
-				newIl.CopyTo(ctx.GetInstrumentationScope())
-			}
-		},
-	}
-}
-
-func accessInstrumentationScopeName() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Name()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if str, ok := val.(string); ok {
-				ctx.GetInstrumentationScope().SetName(str)
-			}
-		},
-	}
-}
-
-func accessInstrumentationScopeVersion() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
-			return ctx.GetInstrumentationScope().Version()
-		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
-			if str, ok := val.(string); ok {
-				ctx.GetInstrumentationScope().SetVersion(str)
-			}
-		},
-	}
-}
-
-func accessMetric() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetric() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.(metricTransformContext).GetMetric()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newMetric, ok := val.(pmetric.Metric); ok {
 				newMetric.CopyTo(ctx.(metricTransformContext).GetMetric())
 			}
@@ -287,12 +178,12 @@ func accessMetric() pathGetSetter {
 	}
 }
 
-func accessMetricName() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetricName() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.(metricTransformContext).GetMetric().Name()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if str, ok := val.(string); ok {
 				ctx.(metricTransformContext).GetMetric().SetName(str)
 			}
@@ -300,12 +191,12 @@ func accessMetricName() pathGetSetter {
 	}
 }
 
-func accessMetricDescription() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetricDescription() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.(metricTransformContext).GetMetric().Description()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if str, ok := val.(string); ok {
 				ctx.(metricTransformContext).GetMetric().SetDescription(str)
 			}
@@ -313,12 +204,12 @@ func accessMetricDescription() pathGetSetter {
 	}
 }
 
-func accessMetricUnit() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetricUnit() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return ctx.(metricTransformContext).GetMetric().Unit()
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if str, ok := val.(string); ok {
 				ctx.(metricTransformContext).GetMetric().SetUnit(str)
 			}
@@ -326,21 +217,21 @@ func accessMetricUnit() pathGetSetter {
 	}
 }
 
-func accessMetricType() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetricType() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			return int64(ctx.(metricTransformContext).GetMetric().DataType())
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			// TODO Implement methods so correctly convert data types.
 			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10130
 		},
 	}
 }
 
-func accessMetricAggTemporality() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetricAggTemporality() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			metric := ctx.(metricTransformContext).GetMetric()
 			switch metric.DataType() {
 			case pmetric.MetricDataTypeSum:
@@ -352,7 +243,7 @@ func accessMetricAggTemporality() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newAggTemporality, ok := val.(int64); ok {
 				metric := ctx.(metricTransformContext).GetMetric()
 				switch metric.DataType() {
@@ -368,9 +259,9 @@ func accessMetricAggTemporality() pathGetSetter {
 	}
 }
 
-func accessMetricIsMonotonic() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessMetricIsMonotonic() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			metric := ctx.(metricTransformContext).GetMetric()
 			switch metric.DataType() {
 			case pmetric.MetricDataTypeSum:
@@ -378,7 +269,7 @@ func accessMetricIsMonotonic() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newIsMonotonic, ok := val.(bool); ok {
 				metric := ctx.(metricTransformContext).GetMetric()
 				switch metric.DataType() {
@@ -390,9 +281,9 @@ func accessMetricIsMonotonic() pathGetSetter {
 	}
 }
 
-func accessAttributes() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessAttributes() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return ctx.GetItem().(pmetric.NumberDataPoint).Attributes()
@@ -405,7 +296,7 @@ func accessAttributes() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				if attrs, ok := val.(pcommon.Map); ok {
@@ -432,39 +323,39 @@ func accessAttributes() pathGetSetter {
 	}
 }
 
-func accessAttributesKey(mapKey *string) pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessAttributesKey(keys []tql.Key) tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), *mapKey)
+				return tqlcommon.GetAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), keys)
 			case pmetric.HistogramDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), *mapKey)
+				return tqlcommon.GetAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), keys)
 			case pmetric.ExponentialHistogramDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), *mapKey)
+				return tqlcommon.GetAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), keys)
 			case pmetric.SummaryDataPoint:
-				return getAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), *mapKey)
+				return tqlcommon.GetAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), keys)
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
-				setAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), *mapKey, val)
+				tqlcommon.SetAttr(ctx.GetItem().(pmetric.NumberDataPoint).Attributes(), keys, val)
 			case pmetric.HistogramDataPoint:
-				setAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), *mapKey, val)
+				tqlcommon.SetAttr(ctx.GetItem().(pmetric.HistogramDataPoint).Attributes(), keys, val)
 			case pmetric.ExponentialHistogramDataPoint:
-				setAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), *mapKey, val)
+				tqlcommon.SetAttr(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Attributes(), keys, val)
 			case pmetric.SummaryDataPoint:
-				setAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), *mapKey, val)
+				tqlcommon.SetAttr(ctx.GetItem().(pmetric.SummaryDataPoint).Attributes(), keys, val)
 			}
 		},
 	}
 }
 
-func accessStartTimeUnixNano() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessStartTimeUnixNano() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return ctx.GetItem().(pmetric.NumberDataPoint).StartTimestamp().AsTime().UnixNano()
@@ -477,7 +368,7 @@ func accessStartTimeUnixNano() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newTime, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -494,9 +385,9 @@ func accessStartTimeUnixNano() pathGetSetter {
 	}
 }
 
-func accessTimeUnixNano() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessTimeUnixNano() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return ctx.GetItem().(pmetric.NumberDataPoint).Timestamp().AsTime().UnixNano()
@@ -509,7 +400,7 @@ func accessTimeUnixNano() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newTime, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -526,16 +417,16 @@ func accessTimeUnixNano() pathGetSetter {
 	}
 }
 
-func accessDoubleValue() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessDoubleValue() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return ctx.GetItem().(pmetric.NumberDataPoint).DoubleVal()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newDouble, ok := val.(float64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -546,16 +437,16 @@ func accessDoubleValue() pathGetSetter {
 	}
 }
 
-func accessIntValue() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessIntValue() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return ctx.GetItem().(pmetric.NumberDataPoint).IntVal()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newInt, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -566,9 +457,9 @@ func accessIntValue() pathGetSetter {
 	}
 }
 
-func accessExemplars() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessExemplars() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return ctx.GetItem().(pmetric.NumberDataPoint).Exemplars()
@@ -579,7 +470,7 @@ func accessExemplars() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newExemplars, ok := val.(pmetric.ExemplarSlice); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -594,9 +485,9 @@ func accessExemplars() pathGetSetter {
 	}
 }
 
-func accessFlags() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessFlags() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.NumberDataPoint:
 				return int64(ctx.GetItem().(pmetric.NumberDataPoint).Flags())
@@ -609,7 +500,7 @@ func accessFlags() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newFlags, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.NumberDataPoint:
@@ -626,9 +517,9 @@ func accessFlags() pathGetSetter {
 	}
 }
 
-func accessCount() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessCount() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
 				return int64(ctx.GetItem().(pmetric.HistogramDataPoint).Count())
@@ -639,7 +530,7 @@ func accessCount() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newCount, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
@@ -654,9 +545,9 @@ func accessCount() pathGetSetter {
 	}
 }
 
-func accessSum() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessSum() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
 				return ctx.GetItem().(pmetric.HistogramDataPoint).Sum()
@@ -667,7 +558,7 @@ func accessSum() pathGetSetter {
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newSum, ok := val.(float64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
@@ -682,16 +573,16 @@ func accessSum() pathGetSetter {
 	}
 }
 
-func accessExplicitBounds() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessExplicitBounds() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
 				return ctx.GetItem().(pmetric.HistogramDataPoint).MExplicitBounds()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newExplicitBounds, ok := val.([]float64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
@@ -702,16 +593,16 @@ func accessExplicitBounds() pathGetSetter {
 	}
 }
 
-func accessBucketCounts() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessBucketCounts() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.HistogramDataPoint:
 				return ctx.GetItem().(pmetric.HistogramDataPoint).MBucketCounts()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newBucketCount, ok := val.([]uint64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.HistogramDataPoint:
@@ -722,16 +613,16 @@ func accessBucketCounts() pathGetSetter {
 	}
 }
 
-func accessScale() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessScale() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Scale())
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newScale, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -742,16 +633,16 @@ func accessScale() pathGetSetter {
 	}
 }
 
-func accessZeroCount() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessZeroCount() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).ZeroCount())
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newZeroCount, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -762,16 +653,16 @@ func accessZeroCount() pathGetSetter {
 	}
 }
 
-func accessPositive() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessPositive() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newPositive, ok := val.(pmetric.Buckets); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -782,16 +673,16 @@ func accessPositive() pathGetSetter {
 	}
 }
 
-func accessPositiveOffset() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessPositiveOffset() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().Offset())
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newPositiveOffset, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -802,16 +693,16 @@ func accessPositiveOffset() pathGetSetter {
 	}
 }
 
-func accessPositiveBucketCounts() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessPositiveBucketCounts() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Positive().MBucketCounts()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newPositiveBucketCounts, ok := val.([]uint64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -822,16 +713,16 @@ func accessPositiveBucketCounts() pathGetSetter {
 	}
 }
 
-func accessNegative() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessNegative() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newNegative, ok := val.(pmetric.Buckets); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -842,16 +733,16 @@ func accessNegative() pathGetSetter {
 	}
 }
 
-func accessNegativeOffset() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessNegativeOffset() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return int64(ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().Offset())
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newNegativeOffset, ok := val.(int64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -862,16 +753,16 @@ func accessNegativeOffset() pathGetSetter {
 	}
 }
 
-func accessNegativeBucketCounts() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessNegativeBucketCounts() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.ExponentialHistogramDataPoint:
 				return ctx.GetItem().(pmetric.ExponentialHistogramDataPoint).Negative().MBucketCounts()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newNegativeBucketCounts, ok := val.([]uint64); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.ExponentialHistogramDataPoint:
@@ -882,16 +773,16 @@ func accessNegativeBucketCounts() pathGetSetter {
 	}
 }
 
-func accessQuantileValues() pathGetSetter {
-	return pathGetSetter{
-		getter: func(ctx tql.TransformContext) interface{} {
+func accessQuantileValues() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
 			switch ctx.GetItem().(type) {
 			case pmetric.SummaryDataPoint:
 				return ctx.GetItem().(pmetric.SummaryDataPoint).QuantileValues()
 			}
 			return nil
 		},
-		setter: func(ctx tql.TransformContext, val interface{}) {
+		Setter: func(ctx tql.TransformContext, val interface{}) {
 			if newQuantileValues, ok := val.(pmetric.ValueAtQuantileSlice); ok {
 				switch ctx.GetItem().(type) {
 				case pmetric.SummaryDataPoint:
@@ -901,78 +792,3 @@ func accessQuantileValues() pathGetSetter {
 		},
 	}
 }
-
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
-	if !ok {
-		return nil
-	}
-	return getValue(val)
-}
-
-func getValue(val pcommon.Value) interface{} {
-	switch val.Type() {
-	case pcommon.ValueTypeString:
-		return val.StringVal()
-	case pcommon.ValueTypeBool:
-		return val.BoolVal()
-	case pcommon.ValueTypeInt:
-		return val.IntVal()
-	case pcommon.ValueTypeDouble:
-		return val.DoubleVal()
-	case pcommon.ValueTypeMap:
-		return val.MapVal()
-	case pcommon.ValueTypeSlice:
-		return val.SliceVal()
-	case pcommon.ValueTypeBytes:
-		return val.MBytesVal()
-	}
-	return nil
-}
-
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
-	switch v := val.(type) {
-	case string:
-		attrs.UpsertString(mapKey, v)
-	case bool:
-		attrs.UpsertBool(mapKey, v)
-	case int64:
-		attrs.UpsertInt(mapKey, v)
-	case float64:
-		attrs.UpsertDouble(mapKey, v)
-	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
-	case []string:
-		arr := pcommon.NewValueSlice()
-		for _, str := range v {
-			arr.SliceVal().AppendEmpty().SetStringVal(str)
-		}
-		attrs.Upsert(mapKey, arr)
-	case []bool:
-		arr := pcommon.NewValueSlice()
-		for _, b := range v {
-			arr.SliceVal().AppendEmpty().SetBoolVal(b)
-		}
-		attrs.Upsert(mapKey, arr)
-	case []int64:
-		arr := pcommon.NewValueSlice()
-		for _, i := range v {
-			arr.SliceVal().AppendEmpty().SetIntVal(i)
-		}
-		attrs.Upsert(mapKey, arr)
-	case []float64:
-		arr := pcommon.NewValueSlice()
-		for _, f := range v {
-			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
-		}
-		attrs.Upsert(mapKey, arr)
-	case [][]byte:
-		arr := pcommon.NewValueSlice()
-		for _, b := range v {
-			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
-		}
-		attrs.Upsert(mapKey, arr)
-	default:
-		// TODO(anuraaga): Support set of map type.
-	}
-}
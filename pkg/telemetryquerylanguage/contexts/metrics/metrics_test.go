@@ -137,8 +137,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -151,8 +155,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -165,8 +173,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -179,8 +191,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -193,8 +209,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -207,8 +227,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -224,8 +248,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -241,8 +269,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -258,8 +290,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -275,8 +311,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -469,8 +509,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -483,8 +527,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -497,8 +545,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -511,8 +563,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -525,8 +581,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -539,8 +599,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -556,8 +620,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -573,8 +641,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -590,8 +662,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -607,8 +683,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -897,8 +977,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -911,8 +995,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -925,8 +1013,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -939,8 +1031,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   1.2,
@@ -953,8 +1049,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -967,8 +1067,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -984,8 +1088,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1001,8 +1109,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1018,8 +1130,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1035,8 +1151,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1210,8 +1330,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -1224,8 +1348,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -1238,8 +1366,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -1252,8 +1384,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   1.2,
@@ -1266,8 +1402,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -1280,8 +1420,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1297,8 +1441,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1314,8 +1462,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1331,8 +1483,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1348,8 +1504,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
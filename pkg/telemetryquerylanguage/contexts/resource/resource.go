@@ -0,0 +1,106 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:gocritic
+package tqlresource // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/resource"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type resourceTransformContext struct {
+	resource pcommon.Resource
+}
+
+func (ctx resourceTransformContext) GetItem() interface{} {
+	return ctx.resource
+}
+
+func (ctx resourceTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return pcommon.NewInstrumentationScope()
+}
+
+func (ctx resourceTransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// ParseEnum always returns an error, the Resource Context does not define any enums.
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	return tqlcommon.ParseEnum(val)
+}
+
+func ParsePath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "attributes":
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessAttributes(), nil
+		}
+		return accessAttributesKey(keys), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount(), nil
+	}
+
+	return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+}
+
+func accessAttributes() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(pcommon.Resource).Attributes()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				ctx.GetItem().(pcommon.Resource).Attributes().Clear()
+				attrs.CopyTo(ctx.GetItem().(pcommon.Resource).Attributes())
+			}
+		},
+	}
+}
+
+func accessAttributesKey(keys []tql.Key) tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return tqlcommon.GetAttr(ctx.GetItem().(pcommon.Resource).Attributes(), keys)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			tqlcommon.SetAttr(ctx.GetItem().(pcommon.Resource).Attributes(), keys, val)
+		},
+	}
+}
+
+func accessDroppedAttributesCount() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return int64(ctx.GetItem().(pcommon.Resource).DroppedAttributesCount())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(pcommon.Resource).SetDroppedAttributesCount(uint32(i))
+			}
+		},
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refResource := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.UpsertString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(resource pcommon.Resource)
+	}{
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refResource.Attributes(),
+			newVal: newAttrs,
+			modified: func(resource pcommon.Resource) {
+				resource.Attributes().Clear()
+				newAttrs.CopyTo(resource.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("host.name"),
+						},
+					},
+				},
+			},
+			orig:   "localhost",
+			newVal: "remotehost",
+			modified: func(resource pcommon.Resource) {
+				resource.Attributes().UpsertString("host.name", "remotehost")
+			},
+		},
+		{
+			name: "attributes nested",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("k8s"),
+						},
+						{
+							String: tqltest.Strp("pod"),
+						},
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "name",
+			modified: func(resource pcommon.Resource) {
+				nested := pcommon.NewValueMap()
+				nested.MapVal().UpsertString("pod", "name")
+				resource.Attributes().Upsert("k8s", nested)
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(resource pcommon.Resource) {
+				resource.SetDroppedAttributesCount(20)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			resource := createTelemetry()
+
+			got := accessor.Get(resourceTransformContext{
+				resource: resource,
+			})
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(resourceTransformContext{
+				resource: resource,
+			}, tt.newVal)
+
+			exRes := createTelemetry()
+			tt.modified(exRes)
+
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	_, err := newPathGetSetter([]tql.Field{{Name: "name"}})
+	assert.Error(t, err)
+}
+
+func createTelemetry() pcommon.Resource {
+	resource := pcommon.NewResource()
+	resource.Attributes().UpsertString("host.name", "localhost")
+	resource.SetDroppedAttributesCount(10)
+	return resource
+}
@@ -0,0 +1,93 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:gocritic
+package tqlscope // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/scope"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type scopeTransformContext struct {
+	il       pcommon.InstrumentationScope
+	resource pcommon.Resource
+}
+
+func (ctx scopeTransformContext) GetItem() interface{} {
+	return ctx.il
+}
+
+func (ctx scopeTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.il
+}
+
+func (ctx scopeTransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// ParseEnum always returns an error, the Scope Context does not define any enums.
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	return tqlcommon.ParseEnum(val)
+}
+
+func ParsePath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource":
+		return tqlcommon.ResourcePathGetSetter(path)
+	case "name":
+		return accessName(), nil
+	case "version":
+		return accessVersion(), nil
+	}
+
+	return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+}
+
+func accessName() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(pcommon.InstrumentationScope).Name()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(pcommon.InstrumentationScope).SetName(str)
+			}
+		},
+	}
+}
+
+func accessVersion() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(pcommon.InstrumentationScope).Version()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(pcommon.InstrumentationScope).SetVersion(str)
+			}
+		},
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlscope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	_, refResource := createTelemetry()
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "newname",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("newname")
+			},
+		},
+		{
+			name: "version",
+			path: []tql.Field{
+				{
+					Name: "version",
+				},
+			},
+			orig:   "version",
+			newVal: "next",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetVersion("next")
+			},
+		},
+		{
+			name: "resource",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+			},
+			orig:   refResource,
+			newVal: pcommon.NewResource(),
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().Clear()
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("host.name"),
+						},
+					},
+				},
+			},
+			orig:   "localhost",
+			newVal: "remotehost",
+			modified: func(il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().UpsertString("host.name", "remotehost")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			il, resource := createTelemetry()
+
+			got := accessor.Get(scopeTransformContext{
+				il:       il,
+				resource: resource,
+			})
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(scopeTransformContext{
+				il:       il,
+				resource: resource,
+			}, tt.newVal)
+
+			exIl, exRes := createTelemetry()
+			tt.modified(exIl, exRes)
+
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	_, err := newPathGetSetter([]tql.Field{{Name: "attributes"}})
+	assert.Error(t, err)
+}
+
+func createTelemetry() (pcommon.InstrumentationScope, pcommon.Resource) {
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().UpsertString("host.name", "localhost")
+
+	return il, resource
+}
@@ -0,0 +1,265 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlspanevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refEvent, refIl, refResource := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.UpsertString("hello", "world")
+
+	newIl := pcommon.NewInstrumentationScope()
+	newIl.SetName("new")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "time_unix_nano",
+			path: []tql.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "name",
+			path: []tql.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "exception",
+			newVal: "new name",
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetName("new name")
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refEvent.Attributes(),
+			newVal: newAttrs,
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.Attributes().Clear()
+				newAttrs.CopyTo(event.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("exception.message"),
+						},
+					},
+				},
+			},
+			orig:   "secret",
+			newVal: "redacted",
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.Attributes().UpsertString("exception.message", "redacted")
+			},
+		},
+		{
+			name: "attributes int",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.Attributes().UpsertInt("int", 20)
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				event.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("host.name"),
+						},
+					},
+				},
+			},
+			orig:   "localhost",
+			newVal: "remotehost",
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().UpsertString("host.name", "remotehost")
+			},
+		},
+		{
+			name: "resource",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+			},
+			orig:   refResource,
+			newVal: pcommon.NewResource(),
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().Clear()
+			},
+		},
+		{
+			name: "instrumentation_scope",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+			},
+			orig:   refIl,
+			newVal: newIl,
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newIl.CopyTo(il)
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "newname",
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("newname")
+			},
+		},
+		{
+			name: "instrumentation_scope version",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "version",
+				},
+			},
+			orig:   "version",
+			newVal: "next",
+			modified: func(event ptrace.SpanEvent, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetVersion("next")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			event, il, resource := createTelemetry()
+
+			got := accessor.Get(spanEventTransformContext{
+				spanEvent: event,
+				il:        il,
+				resource:  resource,
+			})
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(spanEventTransformContext{
+				spanEvent: event,
+				il:        il,
+				resource:  resource,
+			}, tt.newVal)
+
+			exEvent, exIl, exRes := createTelemetry()
+			tt.modified(exEvent, exIl, exRes)
+
+			assert.Equal(t, exEvent, event)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	_, err := newPathGetSetter([]tql.Field{{Name: "links"}})
+	assert.Error(t, err)
+}
+
+func createTelemetry() (ptrace.SpanEvent, pcommon.InstrumentationScope, pcommon.Resource) {
+	event := ptrace.NewSpanEvent()
+	event.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	event.SetName("exception")
+	event.Attributes().UpsertString("exception.message", "secret")
+	event.Attributes().UpsertInt("int", 10)
+	event.SetDroppedAttributesCount(10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().UpsertString("host.name", "localhost")
+
+	return event, il, resource
+}
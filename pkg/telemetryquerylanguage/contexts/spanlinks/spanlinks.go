@@ -0,0 +1,230 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:gocritic
+package tqlspanlinks // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/spanlinks"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/internal/tqlcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+type spanLinkTransformContext struct {
+	spanLink ptrace.SpanLink
+	il       pcommon.InstrumentationScope
+	resource pcommon.Resource
+}
+
+func (ctx spanLinkTransformContext) GetItem() interface{} {
+	return ctx.spanLink
+}
+
+func (ctx spanLinkTransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.il
+}
+
+func (ctx spanLinkTransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// ParseEnum always returns an error, the Span Link Context does not define any enums.
+func ParseEnum(val *tql.EnumSymbol) (*tql.Enum, error) {
+	return tqlcommon.ParseEnum(val)
+}
+
+func ParsePath(val *tql.Path) (tql.GetSetter, error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
+	switch path[0].Name {
+	case "resource":
+		return tqlcommon.ResourcePathGetSetter(path)
+	case "instrumentation_scope":
+		return tqlcommon.ScopePathGetSetter(path)
+	case "trace_id":
+		if len(path) == 1 {
+			return accessTraceID(), nil
+		}
+		switch path[1].Name {
+		case "string":
+			return accessStringTraceID(), nil
+		}
+	case "span_id":
+		if len(path) == 1 {
+			return accessSpanID(), nil
+		}
+		switch path[1].Name {
+		case "string":
+			return accessStringSpanID(), nil
+		}
+	case "trace_state":
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessTraceState(), nil
+		}
+		if len(keys) > 1 || keys[0].String == nil {
+			return nil, fmt.Errorf("invalid path expression %v, trace_state only supports a single string key", path)
+		}
+		return accessTraceStateKey(keys[0].String), nil
+	case "attributes":
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessAttributes(), nil
+		}
+		return accessAttributesKey(keys), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount(), nil
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+
+	return nil, fmt.Errorf("invalid path expression %v", path)
+}
+
+func accessTraceID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).TraceID()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newTraceID, ok := val.(pcommon.TraceID); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetTraceID(newTraceID)
+			}
+		},
+	}
+}
+
+func accessStringTraceID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).TraceID().HexString()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				if traceID, err := tqlcommon.ParseTraceID(str); err == nil {
+					ctx.GetItem().(ptrace.SpanLink).SetTraceID(traceID)
+				}
+			}
+		},
+	}
+}
+
+func accessSpanID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).SpanID()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if newSpanID, ok := val.(pcommon.SpanID); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetSpanID(newSpanID)
+			}
+		},
+	}
+}
+
+func accessStringSpanID() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).SpanID().HexString()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				if spanID, err := tqlcommon.ParseSpanID(str); err == nil {
+					ctx.GetItem().(ptrace.SpanLink).SetSpanID(spanID)
+				}
+			}
+		},
+	}
+}
+
+func accessTraceState() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return (string)(ctx.GetItem().(ptrace.SpanLink).TraceState())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetTraceState(ptrace.TraceState(str))
+			}
+		},
+	}
+}
+
+func accessTraceStateKey(mapKey *string) tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			if ts, err := trace.ParseTraceState(string(ctx.GetItem().(ptrace.SpanLink).TraceState())); err == nil {
+				return ts.Get(*mapKey)
+			}
+			return nil
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if str, ok := val.(string); ok {
+				if ts, err := trace.ParseTraceState(string(ctx.GetItem().(ptrace.SpanLink).TraceState())); err == nil {
+					if updated, err := ts.Insert(*mapKey, str); err == nil {
+						ctx.GetItem().(ptrace.SpanLink).SetTraceState(ptrace.TraceState(updated.String()))
+					}
+				}
+			}
+		},
+	}
+}
+
+func accessAttributes() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem().(ptrace.SpanLink).Attributes()
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if attrs, ok := val.(pcommon.Map); ok {
+				ctx.GetItem().(ptrace.SpanLink).Attributes().Clear()
+				attrs.CopyTo(ctx.GetItem().(ptrace.SpanLink).Attributes())
+			}
+		},
+	}
+}
+
+func accessAttributesKey(keys []tql.Key) tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return tqlcommon.GetAttr(ctx.GetItem().(ptrace.SpanLink).Attributes(), keys)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			tqlcommon.SetAttr(ctx.GetItem().(ptrace.SpanLink).Attributes(), keys, val)
+		},
+	}
+}
+
+func accessDroppedAttributesCount() tqlcommon.PathGetSetter {
+	return tqlcommon.PathGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return int64(ctx.GetItem().(ptrace.SpanLink).DroppedAttributesCount())
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			if i, ok := val.(int64); ok {
+				ctx.GetItem().(ptrace.SpanLink).SetDroppedAttributesCount(uint32(i))
+			}
+		},
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlspanlinks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refLink, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.UpsertString("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []tql.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "trace_id",
+			path: []tql.Field{
+				{
+					Name: "trace_id",
+				},
+			},
+			orig:   pcommon.NewTraceID(traceID),
+			newVal: pcommon.NewTraceID(traceID2),
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceID(pcommon.NewTraceID(traceID2))
+			},
+		},
+		{
+			name: "trace_id string",
+			path: []tql.Field{
+				{
+					Name: "trace_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   pcommon.NewTraceID(traceID).HexString(),
+			newVal: pcommon.NewTraceID(traceID2).HexString(),
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceID(pcommon.NewTraceID(traceID2))
+			},
+		},
+		{
+			name: "span_id",
+			path: []tql.Field{
+				{
+					Name: "span_id",
+				},
+			},
+			orig:   pcommon.NewSpanID(spanID),
+			newVal: pcommon.NewSpanID(spanID2),
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetSpanID(pcommon.NewSpanID(spanID2))
+			},
+		},
+		{
+			name: "span_id string",
+			path: []tql.Field{
+				{
+					Name: "span_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   pcommon.NewSpanID(spanID).HexString(),
+			newVal: pcommon.NewSpanID(spanID2).HexString(),
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetSpanID(pcommon.NewSpanID(spanID2))
+			},
+		},
+		{
+			name: "trace_state",
+			path: []tql.Field{
+				{
+					Name: "trace_state",
+				},
+			},
+			orig:   "key1=val1,key2=val2",
+			newVal: "key=newVal",
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceState("key=newVal")
+			},
+		},
+		{
+			name: "trace_state key",
+			path: []tql.Field{
+				{
+					Name: "trace_state",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("key1"),
+						},
+					},
+				},
+			},
+			orig:   "val1",
+			newVal: "newVal",
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetTraceState("key1=newVal,key2=val2")
+			},
+		},
+		{
+			name: "attributes",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refLink.Attributes(),
+			newVal: newAttrs,
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.Attributes().Clear()
+				newAttrs.CopyTo(link.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.Attributes().UpsertString("str", "newVal")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []tql.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				link.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "resource attributes string",
+			path: []tql.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("host.name"),
+						},
+					},
+				},
+			},
+			orig:   "localhost",
+			newVal: "remotehost",
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().UpsertString("host.name", "remotehost")
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: []tql.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "newname",
+			modified: func(link ptrace.SpanLink, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("newname")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			link, il, resource := createTelemetry()
+
+			got := accessor.Get(spanLinkTransformContext{
+				spanLink: link,
+				il:       il,
+				resource: resource,
+			})
+			assert.Equal(t, tt.orig, got)
+
+			accessor.Set(spanLinkTransformContext{
+				spanLink: link,
+				il:       il,
+				resource: resource,
+			}, tt.newVal)
+
+			exLink, exIl, exRes := createTelemetry()
+			tt.modified(exLink, exIl, exRes)
+
+			assert.Equal(t, exLink, link)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []tql.Field
+	}{
+		{
+			name: "unknown field",
+			path: []tql.Field{{Name: "name"}},
+		},
+		{
+			name: "trace_state int key",
+			path: []tql.Field{{Name: "trace_state", Keys: []tql.Key{{Int: tqltest.Intp(0)}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func createTelemetry() (ptrace.SpanLink, pcommon.InstrumentationScope, pcommon.Resource) {
+	link := ptrace.NewSpanLink()
+	link.SetTraceID(pcommon.NewTraceID(traceID))
+	link.SetSpanID(pcommon.NewSpanID(spanID))
+	link.SetTraceState("key1=val1,key2=val2")
+	link.Attributes().UpsertString("str", "val")
+	link.SetDroppedAttributesCount(10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().UpsertString("host.name", "localhost")
+
+	return link, il, resource
+}
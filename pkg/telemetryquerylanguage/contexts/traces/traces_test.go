@@ -147,8 +147,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "trace_state key",
 			path: []tql.Field{
 				{
-					Name:   "trace_state",
-					MapKey: tqltest.Strp("key1"),
+					Name: "trace_state",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("key1"),
+						},
+					},
 				},
 			},
 			orig:   "val1",
@@ -240,8 +244,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -254,8 +262,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -268,8 +280,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -282,8 +298,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -296,8 +316,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -310,8 +334,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -327,8 +355,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -344,8 +376,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -361,8 +397,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -378,8 +418,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -391,6 +435,94 @@ func Test_newPathGetSetter(t *testing.T) {
 				span.Attributes().Upsert("arr_bytes", newArrBytes)
 			},
 		},
+		{
+			name: "attributes array index",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+						{
+							Int: tqltest.Intp(0),
+						},
+					},
+				},
+			},
+			orig:   "one",
+			newVal: "new",
+			modified: func(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				// setting a slice element is not supported
+			},
+		},
+		{
+			name: "attributes nested map",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("new"),
+						},
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "nested",
+			modified: func(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				nested := pcommon.NewValueMap()
+				nested.MapVal().UpsertString("str", "nested")
+				span.Attributes().Upsert("new", nested)
+			},
+		},
+		{
+			name: "attributes map",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("map"),
+						},
+					},
+				},
+			},
+			orig:   nil,
+			newVal: newAttrs,
+			modified: func(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				m := pcommon.NewValueMap()
+				newAttrs.CopyTo(m.MapVal())
+				span.Attributes().Upsert("map", m)
+			},
+		},
+		{
+			name: "attributes list",
+			path: []tql.Field{
+				{
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
+				},
+			},
+			orig: func() pcommon.Slice {
+				val, _ := refSpan.Attributes().Get("arr_str")
+				return val.SliceVal()
+			}(),
+			newVal: []interface{}{"new", int64(1)},
+			modified: func(span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				arr := pcommon.NewValueSlice()
+				arr.SliceVal().AppendEmpty().SetStringVal("new")
+				arr.SliceVal().AppendEmpty().SetIntVal(1)
+				span.Attributes().Upsert("arr_str", arr)
+			},
+		},
 		{
 			name: "dropped_attributes_count",
 			path: []tql.Field{
@@ -531,8 +663,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig:   "val",
@@ -548,8 +684,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig:   true,
@@ -565,8 +705,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig:   int64(10),
@@ -582,8 +726,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig:   float64(1.2),
@@ -599,8 +747,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -616,8 +768,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -636,8 +792,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -656,8 +816,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -676,8 +840,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -696,8 +864,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -0,0 +1,37 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+
+import (
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func Concat(vals []tql.Getter, delimiter string) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) interface{} {
+		builder := strings.Builder{}
+		for i, val := range vals {
+			if i > 0 {
+				builder.WriteString(delimiter)
+			}
+			// nil and values without a string representation are concatenated as empty strings
+			if str, ok := toString(val.Get(ctx)); ok {
+				builder.WriteString(str)
+			}
+		}
+		return builder.String()
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Concat(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []tql.Getter
+		delimiter string
+		expected  string
+	}{
+		{
+			name: "strings",
+			vals: []tql.Getter{
+				&tql.Literal{Value: "hello"},
+				&tql.Literal{Value: "world"},
+			},
+			delimiter: " ",
+			expected:  "hello world",
+		},
+		{
+			name: "mixed types",
+			vals: []tql.Getter{
+				&tql.Literal{Value: "a"},
+				&tql.Literal{Value: int64(1)},
+				&tql.Literal{Value: 2.5},
+				&tql.Literal{Value: true},
+			},
+			delimiter: "-",
+			expected:  "a-1-2.5-true",
+		},
+		{
+			name: "nil value",
+			vals: []tql.Getter{
+				&tql.Literal{Value: "a"},
+				&tql.Literal{Value: nil},
+				&tql.Literal{Value: "b"},
+			},
+			delimiter: ",",
+			expected:  "a,,b",
+		},
+		{
+			name:      "no values",
+			vals:      []tql.Getter{},
+			delimiter: ",",
+			expected:  "",
+		},
+		{
+			name: "empty delimiter",
+			vals: []tql.Getter{
+				&tql.Literal{Value: "a"},
+				&tql.Literal{Value: "b"},
+			},
+			delimiter: "",
+			expected:  "ab",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Concat(tt.vals, tt.delimiter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
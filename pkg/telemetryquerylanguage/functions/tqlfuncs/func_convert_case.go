@@ -0,0 +1,89 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func ConvertCase(target tql.Getter, toCase string) (tql.ExprFunc, error) {
+	var convert func(string) string
+	switch toCase {
+	case "lower":
+		convert = strings.ToLower
+	case "upper":
+		convert = strings.ToUpper
+	case "snake":
+		convert = toSnakeCase
+	case "camel":
+		convert = toCamelCase
+	default:
+		return nil, fmt.Errorf("invalid case for ConvertCase function, %q must be one of lower, upper, snake or camel", toCase)
+	}
+	return func(ctx tql.TransformContext) interface{} {
+		if str, ok := target.Get(ctx).(string); ok {
+			return convert(str)
+		}
+		return nil
+	}, nil
+}
+
+// splitWords splits a string into words on separators and on lower to upper case transitions.
+func splitWords(str string) []string {
+	var words []string
+	var current []rune
+	var prev rune
+	for _, r := range str {
+		switch {
+		case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) && len(current) > 0:
+			words = append(words, string(current))
+			current = []rune{r}
+		default:
+			current = append(current, r)
+		}
+		prev = r
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+func toSnakeCase(str string) string {
+	words := splitWords(str)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+func toCamelCase(str string) string {
+	builder := strings.Builder{}
+	for _, word := range splitWords(str) {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+	return builder.String()
+}
@@ -0,0 +1,94 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ConvertCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		toCase   string
+		expected interface{}
+	}{
+		{
+			name:     "lower",
+			value:    "Hello World",
+			toCase:   "lower",
+			expected: "hello world",
+		},
+		{
+			name:     "upper",
+			value:    "Hello World",
+			toCase:   "upper",
+			expected: "HELLO WORLD",
+		},
+		{
+			name:     "snake from camel",
+			value:    "httpStatusCode",
+			toCase:   "snake",
+			expected: "http_status_code",
+		},
+		{
+			name:     "snake from words",
+			value:    "Http Status-code",
+			toCase:   "snake",
+			expected: "http_status_code",
+		},
+		{
+			name:     "camel from snake",
+			value:    "http_status_code",
+			toCase:   "camel",
+			expected: "HttpStatusCode",
+		},
+		{
+			name:     "camel from dotted",
+			value:    "http.status.code",
+			toCase:   "camel",
+			expected: "HttpStatusCode",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			toCase:   "camel",
+			expected: "",
+		},
+		{
+			name:     "not a string",
+			value:    int64(1),
+			toCase:   "upper",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ConvertCase(&tql.Literal{Value: tt.value}, tt.toCase)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
+
+func Test_ConvertCase_validation(t *testing.T) {
+	_, err := ConvertCase(&tql.Literal{Value: "anything"}, "kebab")
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_Double(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "double",
+			value:    1.5,
+			expected: 1.5,
+		},
+		{
+			name:     "int",
+			value:    int64(5),
+			expected: float64(5),
+		},
+		{
+			name:     "string",
+			value:    "-4.2e1",
+			expected: float64(-42),
+		},
+		{
+			name:     "invalid string",
+			value:    "not a number",
+			expected: nil,
+		},
+		{
+			name:     "true",
+			value:    true,
+			expected: float64(1),
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Double(&tql.Literal{Value: tt.value})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+const (
+	insert = "insert"
+	update = "update"
+	upsert = "upsert"
+)
+
+// MergeMaps copies every entry of the source map into the target map. The strategy
+// decides how keys already present in the target are handled: insert keeps the target's
+// value, update only overwrites existing keys and upsert does both.
+func MergeMaps(target tql.Getter, source tql.Getter, strategy string) (tql.ExprFunc, error) {
+	if strategy != insert && strategy != update && strategy != upsert {
+		return nil, fmt.Errorf("invalid strategy for merge_maps function, %q must be one of %s, %s or %s", strategy, insert, update, upsert)
+	}
+	return func(ctx tql.TransformContext) interface{} {
+		targetMap, ok := target.Get(ctx).(pcommon.Map)
+		if !ok {
+			return nil
+		}
+		sourceMap, ok := source.Get(ctx).(pcommon.Map)
+		if !ok {
+			return nil
+		}
+		sourceMap.Range(func(k string, v pcommon.Value) bool {
+			switch strategy {
+			case insert:
+				targetMap.Insert(k, v)
+			case update:
+				targetMap.Update(k, v)
+			case upsert:
+				targetMap.Upsert(k, v)
+			}
+			return true
+		})
+		return nil
+	}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_MergeMaps(t *testing.T) {
+	source := pcommon.NewMap()
+	source.InsertString("existing", "new")
+	source.InsertString("added", "value")
+
+	target := &tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return ctx.GetItem()
+		},
+	}
+
+	tests := []struct {
+		name     string
+		source   tql.Getter
+		strategy string
+		want     func(pcommon.Map)
+	}{
+		{
+			name:     "insert",
+			source:   &tql.Literal{Value: source},
+			strategy: "insert",
+			want: func(expected pcommon.Map) {
+				expected.InsertString("added", "value")
+			},
+		},
+		{
+			name:     "update",
+			source:   &tql.Literal{Value: source},
+			strategy: "update",
+			want: func(expected pcommon.Map) {
+				expected.UpdateString("existing", "new")
+			},
+		},
+		{
+			name:     "upsert",
+			source:   &tql.Literal{Value: source},
+			strategy: "upsert",
+			want: func(expected pcommon.Map) {
+				expected.UpdateString("existing", "new")
+				expected.InsertString("added", "value")
+			},
+		},
+		{
+			name:     "source not a map",
+			source:   &tql.Literal{Value: "not a map"},
+			strategy: "upsert",
+			want:     func(expected pcommon.Map) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := pcommon.NewMap()
+			input.InsertString("existing", "old")
+
+			exprFunc, err := MergeMaps(target, tt.source, tt.strategy)
+			assert.NoError(t, err)
+			assert.Nil(t, exprFunc(tqltest.TestTransformContext{Item: input}))
+
+			expected := pcommon.NewMap()
+			expected.InsertString("existing", "old")
+			tt.want(expected)
+			assert.Equal(t, expected.Sort(), input.Sort())
+		})
+	}
+}
+
+func Test_MergeMaps_validation(t *testing.T) {
+	_, err := MergeMaps(&tql.Literal{}, &tql.Literal{}, "replace")
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+
+import (
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// ParseJSON returns a pcommon.Map built from a string holding a JSON object. JSON numbers
+// are always parsed as doubles.
+func ParseJSON(target tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) interface{} {
+		str, ok := target.Get(ctx).(string)
+		if !ok {
+			return nil
+		}
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(str), &parsed); err != nil {
+			return nil
+		}
+		return pcommon.NewMapFromRaw(parsed)
+	}, nil
+}
@@ -0,0 +1,94 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ParseJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  func(pcommon.Map)
+	}{
+		{
+			name:  "flat object",
+			value: `{"str":"value","num":1,"bool":true,"null":null}`,
+			want: func(expected pcommon.Map) {
+				expected.InsertString("str", "value")
+				expected.InsertDouble("num", 1)
+				expected.InsertBool("bool", true)
+				expected.Insert("null", pcommon.NewValueEmpty())
+			},
+		},
+		{
+			name:  "nested object and array",
+			value: `{"obj":{"a":"b"},"arr":["x",2]}`,
+			want: func(expected pcommon.Map) {
+				obj := pcommon.NewValueMap()
+				obj.MapVal().InsertString("a", "b")
+				expected.Insert("obj", obj)
+				arr := pcommon.NewValueSlice()
+				arr.SliceVal().AppendEmpty().SetStringVal("x")
+				arr.SliceVal().AppendEmpty().SetDoubleVal(2)
+				expected.Insert("arr", arr)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseJSON(&tql.Literal{Value: tt.value})
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+			assert.Equal(t, expected.Sort(), exprFunc(tqltest.TestTransformContext{}).(pcommon.Map).Sort())
+		})
+	}
+}
+
+func Test_ParseJSON_invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "invalid json",
+			value: `{"a":`,
+		},
+		{
+			name:  "not an object",
+			value: `["a"]`,
+		},
+		{
+			name:  "not a string",
+			value: int64(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseJSON(&tql.Literal{Value: tt.value})
+			assert.NoError(t, err)
+			assert.Nil(t, exprFunc(tqltest.TestTransformContext{}))
+		})
+	}
+}
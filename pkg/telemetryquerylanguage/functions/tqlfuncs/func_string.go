@@ -0,0 +1,66 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tqlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+func String(target tql.Getter) (tql.ExprFunc, error) {
+	return func(ctx tql.TransformContext) interface{} {
+		if str, ok := toString(target.Get(ctx)); ok {
+			return str
+		}
+		return nil
+	}, nil
+}
+
+// toString converts a value returned by a Getter into its string representation. Byte
+// slices are hex encoded and maps and slices are encoded as JSON.
+func toString(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case []byte:
+		return hex.EncodeToString(v), true
+	case pcommon.Map:
+		return toJSON(v.AsRaw())
+	case pcommon.Slice:
+		return toJSON(v.AsRaw())
+	case []interface{}:
+		return toJSON(v)
+	}
+	return "", false
+}
+
+func toJSON(val interface{}) (string, bool) {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
@@ -0,0 +1,33 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package tqlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+
+// ConditionFunctions returns the functions of this package that are useful in
+// a condition, keyed by the name they're documented under. Only converters,
+// which return a value, are included. A new map is returned on each call, so
+// components can add their own functions to it.
+func ConditionFunctions() map[string]interface{} {
+	return map[string]interface{}{
+		"Concat":      Concat,
+		"Split":       Split,
+		"Int":         Int,
+		"Double":      Double,
+		"String":      String,
+		"SHA256":      SHA256,
+		"ConvertCase": ConvertCase,
+		"Substring":   Substring,
+	}
+}
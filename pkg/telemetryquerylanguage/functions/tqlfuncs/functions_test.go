@@ -0,0 +1,38 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package tqlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_ConditionFunctions(t *testing.T) {
+	conditions, err := tql.ParseConditions(
+		[]string{`Int(Substring(String(Double("12.5")), 0, 2)) >= 12 and SHA256(Concat(Split("a,b", ","), "")) != "" and ConvertCase("A", "lower") == "a"`},
+		ConditionFunctions(), nil, nil)
+	require.NoError(t, err)
+	assert.True(t, conditions[0](tqltest.TestTransformContext{}))
+
+	functions := ConditionFunctions()
+	functions["custom"] = nil
+	assert.NotContains(t, ConditionFunctions(), "custom", "each call should return a new map")
+}
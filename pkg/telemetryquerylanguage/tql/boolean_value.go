@@ -82,11 +82,69 @@ func newComparisonEvaluator(comparison *Comparison, functions map[string]interfa
 			b := right.Get(ctx)
 			return a != b
 		}, nil
+	case "<", "<=", ">", ">=":
+		ordered := orderings[comparison.Op]
+		return func(ctx TransformContext) bool {
+			c, ok := compareNumbers(left.Get(ctx), right.Get(ctx))
+			return ok && ordered(c)
+		}, nil
 	}
 
 	return nil, fmt.Errorf("unrecognized boolean operation %v", comparison.Op)
 }
 
+var orderings = map[string]func(c int) bool{
+	"<":  func(c int) bool { return c < 0 },
+	"<=": func(c int) bool { return c <= 0 },
+	">":  func(c int) bool { return c > 0 },
+	">=": func(c int) bool { return c >= 0 },
+}
+
+// compareNumbers returns -1, 0 or 1 when a is respectively lower than, equal to or greater than b.
+// Only ints and floats, which includes enums, can be ordered. For any other value, or NaN, it returns false.
+func compareNumbers(a, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return compareInts(x, y), true
+		case float64:
+			return compareFloats(float64(x), y)
+		}
+	case float64:
+		switch y := b.(type) {
+		case int64:
+			return compareFloats(x, float64(y))
+		case float64:
+			return compareFloats(x, y)
+		}
+	}
+	return 0, false
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) (int, bool) {
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	case a == b:
+		return 0, true
+	}
+	// at least one of them is NaN
+	return 0, false
+}
+
 func newBooleanExpressionEvaluator(expr *BooleanExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (BoolExpressionEvaluator, error) {
 	if expr == nil {
 		return alwaysTrue, nil
@@ -131,6 +131,111 @@ func Test_newComparisonEvaluator(t *testing.T) {
 	}
 }
 
+func Test_newComparisonEvaluator_ordering(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  Value
+		op    string
+		right Value
+		item  interface{}
+		want  bool
+	}{
+		{
+			name:  "int lower than int",
+			left:  Value{Int: tqltest.Intp(1)},
+			op:    "<",
+			right: Value{Int: tqltest.Intp(2)},
+			want:  true,
+		},
+		{
+			name:  "int not lower than equal int",
+			left:  Value{Int: tqltest.Intp(2)},
+			op:    "<",
+			right: Value{Int: tqltest.Intp(2)},
+			want:  false,
+		},
+		{
+			name:  "int lower than or equal to int",
+			left:  Value{Int: tqltest.Intp(2)},
+			op:    "<=",
+			right: Value{Int: tqltest.Intp(2)},
+			want:  true,
+		},
+		{
+			name:  "float greater than float",
+			left:  Value{Float: tqltest.Floatp(2.5)},
+			op:    ">",
+			right: Value{Float: tqltest.Floatp(1.5)},
+			want:  true,
+		},
+		{
+			name:  "float not greater than or equal to float",
+			left:  Value{Float: tqltest.Floatp(1.5)},
+			op:    ">=",
+			right: Value{Float: tqltest.Floatp(2.5)},
+			want:  false,
+		},
+		{
+			name:  "int greater than float",
+			left:  Value{Int: tqltest.Intp(2)},
+			op:    ">",
+			right: Value{Float: tqltest.Floatp(1.5)},
+			want:  true,
+		},
+		{
+			name:  "float lower than int",
+			left:  Value{Float: tqltest.Floatp(1.5)},
+			op:    "<",
+			right: Value{Int: tqltest.Intp(2)},
+			want:  true,
+		},
+		{
+			name:  "path greater than or equal to Enum",
+			left:  Value{Path: &Path{Fields: []Field{{Name: "name"}}}},
+			op:    ">=",
+			right: Value{Enum: (*EnumSymbol)(tqltest.Strp("TEST_ENUM_ONE"))},
+			item:  int64(2),
+			want:  true,
+		},
+		{
+			name:  "path not greater than or equal to Enum",
+			left:  Value{Path: &Path{Fields: []Field{{Name: "name"}}}},
+			op:    ">=",
+			right: Value{Enum: (*EnumSymbol)(tqltest.Strp("TEST_ENUM_TWO"))},
+			item:  int64(1),
+			want:  false,
+		},
+		{
+			name:  "strings are not ordered",
+			left:  Value{String: tqltest.Strp("a")},
+			op:    "<",
+			right: Value{String: tqltest.Strp("b")},
+			want:  false,
+		},
+		{
+			name:  "nil is not ordered",
+			left:  Value{Path: &Path{Fields: []Field{{Name: "name"}}}},
+			op:    "<=",
+			right: Value{Int: tqltest.Intp(1)},
+			item:  nil,
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluate, err := newComparisonEvaluator(&Comparison{
+				Left:  tt.left,
+				Op:    tt.op,
+				Right: tt.right,
+			}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, evaluate(tqltest.TestTransformContext{
+				Item: tt.item,
+			}))
+		})
+	}
+}
+
 func Test_newConditionEvaluator_invalid(t *testing.T) {
 	tests := []struct {
 		name       string
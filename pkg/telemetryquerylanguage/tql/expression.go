@@ -105,6 +105,14 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 		return pathParser(val.Path)
 	}
 
+	if val.List != nil {
+		return newListGetter(val.List, functions, pathParser, enumParser)
+	}
+
+	if val.MathExpression != nil {
+		return newMathGetter(val.MathExpression, functions, pathParser, enumParser)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the transformprocessor")
@@ -117,3 +125,27 @@ func NewGetter(val Value, functions map[string]interface{}, pathParser PathExpre
 		expr: call,
 	}, nil
 }
+
+type listGetter struct {
+	items []Getter
+}
+
+func (l listGetter) Get(ctx TransformContext) interface{} {
+	vals := make([]interface{}, len(l.items))
+	for i, item := range l.items {
+		vals[i] = item.Get(ctx)
+	}
+	return vals
+}
+
+func newListGetter(list *List, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	items := make([]Getter, len(list.Values))
+	for i, val := range list.Values {
+		item, err := NewGetter(val, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return &listGetter{items: items}, nil
+}
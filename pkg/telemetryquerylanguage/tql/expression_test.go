@@ -105,6 +105,22 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: int64(1),
 		},
+		{
+			name: "list",
+			val: Value{
+				List: &List{
+					Values: []Value{
+						{
+							String: tqltest.Strp("str"),
+						},
+						{
+							Int: tqltest.Intp(1),
+						},
+					},
+				},
+			},
+			want: []interface{}{"str", int64(1)},
+		},
 	}
 
 	functions := map[string]interface{}{"hello": hello}
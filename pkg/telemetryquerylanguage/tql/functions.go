@@ -50,7 +50,7 @@ func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interfac
 		argType := fType.In(i)
 
 		if argType.Kind() == reflect.Slice {
-			err := buildSliceArg(inv, argType, i, &args)
+			err := buildSliceArg(inv, argType, i, &args, functions, pathParser, enumParser)
 			if err != nil {
 				return nil, err
 			}
@@ -69,46 +69,77 @@ func buildArgs(inv Invocation, fType reflect.Type, functions map[string]interfac
 	return args, nil
 }
 
-func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args *[]reflect.Value) error {
+func buildSliceArg(inv Invocation, argType reflect.Type, startingIndex int, args *[]reflect.Value,
+	functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) error {
+	if argType.Elem().Kind() == reflect.Uint8 {
+		if startingIndex >= len(inv.Arguments) || inv.Arguments[startingIndex].Bytes == nil {
+			return fmt.Errorf("invalid argument for slice parameter at position %v, must be a byte slice literal", startingIndex)
+		}
+		*args = append(*args, reflect.ValueOf(([]byte)(*inv.Arguments[startingIndex].Bytes)))
+		return nil
+	}
+
+	// The slice is filled either by a list literal at its position, which allows further
+	// parameters to follow, or by all remaining arguments.
+	values := inv.Arguments[min(startingIndex, len(inv.Arguments)):]
+	if len(values) > 0 && values[0].List != nil {
+		values = values[0].List.Values
+	}
+
 	switch argType.Elem().Kind() {
 	case reflect.String:
 		arg := make([]string, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			if inv.Arguments[j].String == nil {
-				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a string", j)
+		for j, val := range values {
+			if val.String == nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a string", startingIndex+j)
 			}
-			arg = append(arg, *inv.Arguments[j].String)
+			arg = append(arg, *val.String)
 		}
 		*args = append(*args, reflect.ValueOf(arg))
 	case reflect.Float64:
 		arg := make([]float64, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			if inv.Arguments[j].Float == nil {
-				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a float", j)
+		for j, val := range values {
+			if val.Float == nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v, must be a float", startingIndex+j)
 			}
-			arg = append(arg, *inv.Arguments[j].Float)
+			arg = append(arg, *val.Float)
 		}
 		*args = append(*args, reflect.ValueOf(arg))
 	case reflect.Int64:
 		arg := make([]int64, 0)
-		for j := startingIndex; j < len(inv.Arguments); j++ {
-			if inv.Arguments[j].Int == nil {
-				return fmt.Errorf("invalid argument for slice parameter at position %v, must be an int", j)
+		for j, val := range values {
+			if val.Int == nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v, must be an int", startingIndex+j)
 			}
-			arg = append(arg, *inv.Arguments[j].Int)
+			arg = append(arg, *val.Int)
 		}
 		*args = append(*args, reflect.ValueOf(arg))
-	case reflect.Uint8:
-		if inv.Arguments[startingIndex].Bytes == nil {
-			return fmt.Errorf("invalid argument for slice parameter at position %v, must be a byte slice literal", startingIndex)
+	case reflect.Interface:
+		if argType.Elem().Name() != "Getter" {
+			return fmt.Errorf("unsupported slice type for function %v", inv.Function)
 		}
-		*args = append(*args, reflect.ValueOf(([]byte)(*inv.Arguments[startingIndex].Bytes)))
+		arg := make([]Getter, 0)
+		for j, val := range values {
+			getter, err := NewGetter(val, functions, pathParser, enumParser)
+			if err != nil {
+				return fmt.Errorf("invalid argument for slice parameter at position %v %w", startingIndex+j, err)
+			}
+			arg = append(arg, getter)
+		}
+		*args = append(*args, reflect.ValueOf(arg))
 	default:
 		return fmt.Errorf("unsupported slice type for function %v", inv.Function)
 	}
 	return nil
 }
 
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 func buildArg(argDef Value, argType reflect.Type, index int, args *[]reflect.Value,
 	functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) error {
 	switch argType.Name() {
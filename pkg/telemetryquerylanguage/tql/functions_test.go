@@ -200,6 +200,72 @@ func Test_NewFunctionCall(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "string slice arg from list",
+			inv: Invocation{
+				Function: "testing_string_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									String: tqltest.Strp("test"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "getter slice arg",
+			inv: Invocation{
+				Function: "testing_getter_slice",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									String: tqltest.Strp("test"),
+								},
+								{
+									Path: &Path{
+										Fields: []Field{
+											{
+												Name: "name",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "list slice arg followed by other args",
+			inv: Invocation{
+				Function: "testing_getter_slice_and_string",
+				Arguments: []Value{
+					{
+						List: &List{
+							Values: []Value{
+								{
+									Int: tqltest.Intp(1),
+								},
+							},
+						},
+					},
+					{
+						String: tqltest.Strp("test"),
+					},
+				},
+			},
+		},
 		{
 			name: "setter arg",
 			inv: Invocation{
@@ -393,6 +459,18 @@ func functionWithByteSlice(_ []byte) (ExprFunc, error) {
 	}, nil
 }
 
+func functionWithGetterSlice(_ []Getter) (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return "anything"
+	}, nil
+}
+
+func functionWithGetterSliceAndString(_ []Getter, _ string) (ExprFunc, error) {
+	return func(ctx TransformContext) interface{} {
+		return "anything"
+	}, nil
+}
+
 func functionWithSetter(_ Setter) (ExprFunc, error) {
 	return func(ctx TransformContext) interface{} {
 		return "anything"
@@ -460,6 +538,8 @@ func DefaultFunctionsForTests() map[string]interface{} {
 	functions["testing_float_slice"] = functionWithFloatSlice
 	functions["testing_int_slice"] = functionWithIntSlice
 	functions["testing_byte_slice"] = functionWithByteSlice
+	functions["testing_getter_slice"] = functionWithGetterSlice
+	functions["testing_getter_slice_and_string"] = functionWithGetterSliceAndString
 	functions["testing_setter"] = functionWithSetter
 	functions["testing_getsetter"] = functionWithGetSetter
 	functions["testing_getter"] = functionWithGetter
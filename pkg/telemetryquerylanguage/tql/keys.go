@@ -0,0 +1,84 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// GetMapValue returns the value found by following keys from m. String keys index into maps
+// and int keys index into slices. The first key must be a string.
+func GetMapValue(m pcommon.Map, keys []Key) (pcommon.Value, bool) {
+	if len(keys) == 0 || keys[0].String == nil {
+		return pcommon.NewValueEmpty(), false
+	}
+	val, ok := m.Get(*keys[0].String)
+	if !ok {
+		return pcommon.NewValueEmpty(), false
+	}
+	for _, key := range keys[1:] {
+		if val, ok = getIndexedValue(val, key); !ok {
+			return pcommon.NewValueEmpty(), false
+		}
+	}
+	return val, true
+}
+
+// GetParentMap returns the map holding the value addressed by keys along with the key of
+// that value within the map. Missing intermediate maps are created. The last key must be
+// a string.
+func GetParentMap(m pcommon.Map, keys []Key) (pcommon.Map, string, bool) {
+	if len(keys) == 0 || keys[len(keys)-1].String == nil {
+		return pcommon.NewMap(), "", false
+	}
+	parent := m
+	path := keys[:len(keys)-1]
+	for len(path) > 0 {
+		if path[0].String == nil {
+			return pcommon.NewMap(), "", false
+		}
+		val, ok := parent.Get(*path[0].String)
+		if !ok {
+			parent.Insert(*path[0].String, pcommon.NewValueMap())
+			val, _ = parent.Get(*path[0].String)
+		}
+		path = path[1:]
+		for len(path) > 0 && path[0].Int != nil {
+			if val, ok = getIndexedValue(val, path[0]); !ok {
+				return pcommon.NewMap(), "", false
+			}
+			path = path[1:]
+		}
+		if val.Type() != pcommon.ValueTypeMap {
+			return pcommon.NewMap(), "", false
+		}
+		parent = val.MapVal()
+	}
+	return parent, *keys[len(keys)-1].String, true
+}
+
+func getIndexedValue(val pcommon.Value, key Key) (pcommon.Value, bool) {
+	switch {
+	case key.String != nil && val.Type() == pcommon.ValueTypeMap:
+		return val.MapVal().Get(*key.String)
+	case key.Int != nil && val.Type() == pcommon.ValueTypeSlice:
+		idx := *key.Int
+		if idx < 0 || idx >= int64(val.SliceVal().Len()) {
+			return pcommon.NewValueEmpty(), false
+		}
+		return val.SliceVal().At(int(idx)), true
+	}
+	return pcommon.NewValueEmpty(), false
+}
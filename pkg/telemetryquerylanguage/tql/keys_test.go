@@ -0,0 +1,139 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func testMap() pcommon.Map {
+	m := pcommon.NewMap()
+	m.InsertString("str", "value")
+	nested := pcommon.NewValueMap()
+	nested.MapVal().InsertString("str", "nested")
+	m.Insert("map", nested)
+	arr := pcommon.NewValueSlice()
+	arr.SliceVal().AppendEmpty().SetStringVal("first")
+	elem := pcommon.NewValueMap()
+	elem.MapVal().InsertString("str", "in slice")
+	elem.CopyTo(arr.SliceVal().AppendEmpty())
+	m.Insert("slice", arr)
+	return m
+}
+
+func Test_GetMapValue(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+		want interface{}
+	}{
+		{
+			name: "single key",
+			keys: []Key{{String: tqltest.Strp("str")}},
+			want: "value",
+		},
+		{
+			name: "nested map",
+			keys: []Key{{String: tqltest.Strp("map")}, {String: tqltest.Strp("str")}},
+			want: "nested",
+		},
+		{
+			name: "slice index",
+			keys: []Key{{String: tqltest.Strp("slice")}, {Int: tqltest.Intp(0)}},
+			want: "first",
+		},
+		{
+			name: "map in slice",
+			keys: []Key{{String: tqltest.Strp("slice")}, {Int: tqltest.Intp(1)}, {String: tqltest.Strp("str")}},
+			want: "in slice",
+		},
+		{
+			name: "missing key",
+			keys: []Key{{String: tqltest.Strp("map")}, {String: tqltest.Strp("missing")}},
+			want: nil,
+		},
+		{
+			name: "index out of range",
+			keys: []Key{{String: tqltest.Strp("slice")}, {Int: tqltest.Intp(2)}},
+			want: nil,
+		},
+		{
+			name: "string key into slice",
+			keys: []Key{{String: tqltest.Strp("slice")}, {String: tqltest.Strp("str")}},
+			want: nil,
+		},
+		{
+			name: "int key into map",
+			keys: []Key{{Int: tqltest.Intp(0)}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, ok := GetMapValue(testMap(), tt.keys)
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, val.StringVal())
+		})
+	}
+}
+
+func Test_GetParentMap(t *testing.T) {
+	t.Run("existing map", func(t *testing.T) {
+		m := testMap()
+		parent, key, ok := GetParentMap(m, []Key{{String: tqltest.Strp("map")}, {String: tqltest.Strp("new")}})
+		assert.True(t, ok)
+		assert.Equal(t, "new", key)
+		parent.InsertString(key, "set")
+		val, _ := GetMapValue(m, []Key{{String: tqltest.Strp("map")}, {String: tqltest.Strp("new")}})
+		assert.Equal(t, "set", val.StringVal())
+	})
+
+	t.Run("creates missing maps", func(t *testing.T) {
+		m := pcommon.NewMap()
+		parent, key, ok := GetParentMap(m, []Key{{String: tqltest.Strp("a")}, {String: tqltest.Strp("b")}, {String: tqltest.Strp("c")}})
+		assert.True(t, ok)
+		parent.InsertInt(key, 1)
+		val, _ := GetMapValue(m, []Key{{String: tqltest.Strp("a")}, {String: tqltest.Strp("b")}, {String: tqltest.Strp("c")}})
+		assert.Equal(t, int64(1), val.IntVal())
+	})
+
+	t.Run("map in slice", func(t *testing.T) {
+		m := testMap()
+		parent, key, ok := GetParentMap(m, []Key{{String: tqltest.Strp("slice")}, {Int: tqltest.Intp(1)}, {String: tqltest.Strp("str")}})
+		assert.True(t, ok)
+		assert.Equal(t, "str", key)
+		val, _ := parent.Get(key)
+		assert.Equal(t, "in slice", val.StringVal())
+	})
+
+	t.Run("last key is an index", func(t *testing.T) {
+		_, _, ok := GetParentMap(testMap(), []Key{{String: tqltest.Strp("slice")}, {Int: tqltest.Intp(0)}})
+		assert.False(t, ok)
+	})
+
+	t.Run("parent is not a map", func(t *testing.T) {
+		_, _, ok := GetParentMap(testMap(), []Key{{String: tqltest.Strp("str")}, {String: tqltest.Strp("key")}})
+		assert.False(t, ok)
+	})
+}
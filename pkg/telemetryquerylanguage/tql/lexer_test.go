@@ -57,6 +57,20 @@ func Test_lexer(t *testing.T) {
 			{"OpComparison", "!="},
 			{"Float", "4.9"},
 		}},
+		{"basic_ordering", "3<4 3<=4 3>4 3>=4", false, []result{
+			{"Int", "3"},
+			{"OpComparison", "<"},
+			{"Int", "4"},
+			{"Int", "3"},
+			{"OpComparison", "<="},
+			{"Int", "4"},
+			{"Int", "3"},
+			{"OpComparison", ">"},
+			{"Int", "4"},
+			{"Int", "3"},
+			{"OpComparison", ">="},
+			{"Int", "4"},
+		}},
 		{"unambiguous_names", "foo bar BAZZ", false, []result{
 			{"Lowercase", "foo"},
 			{"Lowercase", "bar"},
@@ -97,6 +111,21 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"math_operators", `1+2.5-x*3/y`, false, []result{
+			{"Int", "1"},
+			{"OpAddSub", "+"},
+			{"Float", "2.5"},
+			{"OpAddSub", "-"},
+			{"Lowercase", "x"},
+			{"OpMultDiv", "*"},
+			{"Int", "3"},
+			{"OpMultDiv", "/"},
+			{"Lowercase", "y"},
+		}},
+		{"negative_number", `-1`, false, []result{
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+		}},
 		{"Mixing case", `aBCd`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
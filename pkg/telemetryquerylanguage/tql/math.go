@@ -0,0 +1,134 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+
+import (
+	"fmt"
+)
+
+func newMathGetter(expr *MathExpression, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	left, err := newAddSubTermGetter(expr.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range expr.Right {
+		right, err := newAddSubTermGetter(rhs.Term, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		left = newArithmeticGetter(left, rhs.Operator, right)
+	}
+	return left, nil
+}
+
+func newAddSubTermGetter(term *AddSubTerm, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	left, err := newMathValueGetter(term.Left, functions, pathParser, enumParser)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range term.Right {
+		right, err := newMathValueGetter(rhs.Value, functions, pathParser, enumParser)
+		if err != nil {
+			return nil, err
+		}
+		left = newArithmeticGetter(left, rhs.Operator, right)
+	}
+	return left, nil
+}
+
+func newMathValueGetter(val *MathValue, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) (Getter, error) {
+	if val.SubExpression != nil {
+		return newMathGetter(val.SubExpression, functions, pathParser, enumParser)
+	}
+	if val.Literal == nil {
+		// In practice, can't happen since the DSL grammar guarantees one is set
+		return nil, fmt.Errorf("no math value field set. This is a bug in the transformprocessor")
+	}
+	return NewGetter(Value{
+		Invocation: val.Literal.Invocation,
+		Float:      val.Literal.Float,
+		Int:        val.Literal.Int,
+		Path:       val.Literal.Path,
+	}, functions, pathParser, enumParser)
+}
+
+func newArithmeticGetter(left Getter, operator string, right Getter) Getter {
+	return &exprGetter{
+		expr: func(ctx TransformContext) interface{} {
+			return evaluateArithmetic(left.Get(ctx), operator, right.Get(ctx))
+		},
+	}
+}
+
+// evaluateArithmetic applies operator to two numeric values. Two ints result in an int,
+// otherwise the result is a float. Non-numeric operands and integer division by zero
+// result in nil.
+func evaluateArithmetic(left interface{}, operator string, right interface{}) interface{} {
+	if l, ok := left.(int64); ok {
+		if r, ok := right.(int64); ok {
+			return evaluateInt(l, operator, r)
+		}
+	}
+	l, ok := toFloat64(left)
+	if !ok {
+		return nil
+	}
+	r, ok := toFloat64(right)
+	if !ok {
+		return nil
+	}
+	return evaluateFloat(l, operator, r)
+}
+
+func evaluateInt(left int64, operator string, right int64) interface{} {
+	switch operator {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "/":
+		if right == 0 {
+			return nil
+		}
+		return left / right
+	}
+	return nil
+}
+
+func evaluateFloat(left float64, operator string, right float64) interface{} {
+	switch operator {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "/":
+		return left / right
+	}
+	return nil
+}
+
+func toFloat64(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
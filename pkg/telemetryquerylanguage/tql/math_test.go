@@ -0,0 +1,114 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql/tqltest"
+)
+
+func Test_newMathGetter(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		input interface{}
+		want  interface{}
+	}{
+		{
+			name: "int addition",
+			expr: "1 + 2",
+			want: int64(3),
+		},
+		{
+			name: "operator precedence",
+			expr: "10 - 2 * 3",
+			want: int64(4),
+		},
+		{
+			name: "subexpression",
+			expr: "(10 - 2) * 3",
+			want: int64(24),
+		},
+		{
+			name: "left associativity",
+			expr: "10 - 2 - 3",
+			want: int64(5),
+		},
+		{
+			name: "int division",
+			expr: "7 / 2",
+			want: int64(3),
+		},
+		{
+			name: "int division by zero",
+			expr: "7 / 0",
+			want: nil,
+		},
+		{
+			name: "float division",
+			expr: "7.0 / 2",
+			want: 3.5,
+		},
+		{
+			name: "negative literal",
+			expr: "-1 * -2.5",
+			want: 2.5,
+		},
+		{
+			name:  "int path",
+			expr:  "name * 1000",
+			input: int64(5),
+			want:  int64(5000),
+		},
+		{
+			name:  "float path",
+			expr:  "name / 1000",
+			input: 1500.0,
+			want:  1.5,
+		},
+		{
+			name:  "non numeric path",
+			expr:  "name + 1",
+			input: "bear",
+			want:  nil,
+		},
+		{
+			name: "non numeric function",
+			expr: "hello() + 1",
+			want: nil,
+		},
+	}
+
+	functions := map[string]interface{}{"hello": hello}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery("set(name, " + tt.expr + ")")
+			require.NoError(t, err)
+			require.NotNil(t, parsed.Invocation.Arguments[1].MathExpression)
+
+			getter, err := NewGetter(parsed.Invocation.Arguments[1], functions, testParsePath, testParseEnum)
+			require.NoError(t, err)
+			val := getter.Get(tqltest.TestTransformContext{
+				Item: tt.input,
+			})
+			assert.Equal(t, tt.want, val)
+		})
+	}
+}
@@ -30,7 +30,7 @@ type ParsedQuery struct {
 }
 
 // BooleanValue represents something that evaluates to a boolean --
-// either an equality or inequality, explicit true or false, or
+// either a comparison, explicit true or false, or
 // a parenthesized subexpression.
 // nolint:govet
 type BooleanValue struct {
@@ -84,18 +84,21 @@ type Invocation struct {
 }
 
 // Value represents a part of a parsed query which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal, list or arithmetic expression. Function calls, numbers and paths which are
+// operands of an arithmetic operator are part of the MathExpression instead.
 // nolint:govet
 type Value struct {
-	Invocation *Invocation `( @@`
-	Bytes      *Bytes      `| @Bytes`
-	String     *string     `| @String`
-	Float      *float64    `| @Float`
-	Int        *int64      `| @Int`
-	Bool       *Boolean    `| @Boolean`
-	IsNil      *IsNil      `| @"nil"`
-	Enum       *EnumSymbol `| @Uppercase`
-	Path       *Path       `| @@ )`
+	Invocation     *Invocation     `( @@ (?! OpAddSub | OpMultDiv)`
+	Bytes          *Bytes          `| @Bytes`
+	String         *string         `| @String`
+	Float          *float64        `| @(OpAddSub? Float) (?! OpAddSub | OpMultDiv)`
+	Int            *int64          `| @(OpAddSub? Int) (?! OpAddSub | OpMultDiv)`
+	Bool           *Boolean        `| @Boolean`
+	IsNil          *IsNil          `| @"nil"`
+	List           *List           `| @@`
+	Path           *Path           `| @@ (?! OpAddSub | OpMultDiv | "(")`
+	MathExpression *MathExpression `| @@`
+	Enum           *EnumSymbol     `| @Uppercase )`
 }
 
 // Path represents a telemetry path expression.
@@ -107,8 +110,66 @@ type Path struct {
 // Field is an item within a Path.
 // nolint:govet
 type Field struct {
-	Name   string  `@Lowercase`
-	MapKey *string `( "[" @String "]" )?`
+	Name string `@Lowercase`
+	Keys []Key  `( "[" @@ "]" )*`
+}
+
+// Key represents an index into a Field, a string key of a map or an int index of a slice.
+// nolint:govet
+type Key struct {
+	String *string `( @String`
+	Int    *int64  `| @Int )`
+}
+
+// List represents a list of Values.
+// nolint:govet
+type List struct {
+	Values []Value `"[" ( @@ ( "," @@ )* )? "]"`
+}
+
+// MathExpression represents an arbitrary number of terms joined by addition or subtraction.
+// nolint:govet
+type MathExpression struct {
+	Left  *AddSubTerm     `@@`
+	Right []*OpAddSubTerm `@@*`
+}
+
+// OpAddSubTerm represents the right side of an addition or subtraction.
+// nolint:govet
+type OpAddSubTerm struct {
+	Operator string      `@OpAddSub`
+	Term     *AddSubTerm `@@`
+}
+
+// AddSubTerm represents an arbitrary number of math values joined by multiplication or division.
+// nolint:govet
+type AddSubTerm struct {
+	Left  *MathValue        `@@`
+	Right []*OpMultDivValue `@@*`
+}
+
+// OpMultDivValue represents the right side of a multiplication or division.
+// nolint:govet
+type OpMultDivValue struct {
+	Operator string     `@OpMultDiv`
+	Value    *MathValue `@@`
+}
+
+// MathValue represents an operand of an arithmetic operator, either a literal
+// or a parenthesized subexpression.
+// nolint:govet
+type MathValue struct {
+	Literal       *MathExprLiteral `( @@`
+	SubExpression *MathExpression  `| "(" @@ ")" )`
+}
+
+// MathExprLiteral represents the values which can be used in an arithmetic expression.
+// nolint:govet
+type MathExprLiteral struct {
+	Invocation *Invocation `( @@`
+	Float      *float64    `| @(OpAddSub? Float)`
+	Int        *int64      `| @(OpAddSub? Int)`
+	Path       *Path       `| @@ )`
 }
 
 // Query holds a top level Query for processing telemetry data. A Query is a combination of a function
@@ -181,7 +242,34 @@ func ParseQueries(statements []string, functions map[string]interface{}, pathPar
 	return queries, nil
 }
 
-var parser = newParser()
+// ParseConditions parses boolean expressions, written the same way as the where clause of a query,
+// into evaluators.
+func ParseConditions(conditions []string, functions map[string]interface{}, pathParser PathExpressionParser, enumParser EnumParser) ([]BoolExpressionEvaluator, error) {
+	evaluators := make([]BoolExpressionEvaluator, 0, len(conditions))
+	var errors error
+
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluator, err := newBooleanExpressionEvaluator(parsed, functions, pathParser, enumParser)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		evaluators = append(evaluators, evaluator)
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return evaluators, nil
+}
+
+var parser = newParser(&ParsedQuery{})
+var conditionParser = newParser(&BooleanExpression{})
 
 func parseQuery(raw string) (*ParsedQuery, error) {
 	parsed := &ParsedQuery{}
@@ -192,18 +280,29 @@ func parseQuery(raw string) (*ParsedQuery, error) {
 	return parsed, nil
 }
 
+func parseCondition(raw string) (*BooleanExpression, error) {
+	parsed := &BooleanExpression{}
+	err := conditionParser.ParseString("", raw, parsed)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
 // buildLexer constructs a SimpleLexer definition.
 // Note that the ordering of these rules matters.
 // It's in a separate function so it can be easily tested alone (see lexer_test.go).
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
-		{Name: `OpComparison`, Pattern: `==|!=`},
+		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
@@ -214,14 +313,20 @@ func buildLexer() *lexer.StatefulDefinition {
 	})
 }
 
-// newParser returns a parser that can be used to read a string into a ParsedQuery. An error will be returned if the string
-// is not formatted for the DSL.
-func newParser() *participle.Parser {
+// maxLookahead bounds how far the parser backtracks. Values are ambiguous until the token
+// following them tells whether they are the operand of an arithmetic operator, and a
+// function call used as an operand can span many tokens.
+const maxLookahead = 1024
+
+// newParser returns a parser that can be used to read a string into the given grammar, either a ParsedQuery or a
+// BooleanExpression. An error will be returned if the string is not formatted for the DSL.
+func newParser(grammar interface{}) *participle.Parser {
 	lex := buildLexer()
-	parser, err := participle.Build(&ParsedQuery{},
+	parser, err := participle.Build(grammar,
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		participle.UseLookahead(maxLookahead),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
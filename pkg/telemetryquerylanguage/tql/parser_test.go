@@ -128,8 +128,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -159,8 +163,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -210,8 +218,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -261,8 +273,12 @@ func Test_parse(t *testing.T) {
 										Name: "foo",
 									},
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bar"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
 									},
 									{
 										Name: "cat",
@@ -360,8 +376,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("bytes"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("bytes"),
+											},
+										},
 									},
 								},
 							},
@@ -385,8 +405,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
 									},
 								},
 							},
@@ -410,8 +434,12 @@ func Test_parse(t *testing.T) {
 							Path: &Path{
 								Fields: []Field{
 									{
-										Name:   "attributes",
-										MapKey: tqltest.Strp("test"),
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
 									},
 								},
 							},
@@ -424,6 +452,205 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:  "nested keys",
+			query: `set(attributes["foo"][0]["bar"], "dog")`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("foo"),
+											},
+											{
+												Int: tqltest.Intp(0),
+											},
+											{
+												String: tqltest.Strp("bar"),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							String: tqltest.Strp("dog"),
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with list",
+			query: `set(attributes["test"], ["a", -1, 2.5, name])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							List: &List{
+								Values: []Value{
+									{
+										String: tqltest.Strp("a"),
+									},
+									{
+										Int: tqltest.Intp(-1),
+									},
+									{
+										Float: tqltest.Floatp(2.5),
+									},
+									{
+										Path: &Path{
+											Fields: []Field{
+												{
+													Name: "name",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with empty list",
+			query: `set(attributes["test"], [])`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							List: &List{},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:  "Invocation with math expression",
+			query: `set(attributes["test"], Hello() + 2 * (duration - -1.5))`,
+			expected: &ParsedQuery{
+				Invocation: Invocation{
+					Function: "set",
+					Arguments: []Value{
+						{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "attributes",
+										Keys: []Key{
+											{
+												String: tqltest.Strp("test"),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							MathExpression: &MathExpression{
+								Left: &AddSubTerm{
+									Left: &MathValue{
+										Literal: &MathExprLiteral{
+											Invocation: &Invocation{
+												Function: "Hello",
+											},
+										},
+									},
+								},
+								Right: []*OpAddSubTerm{
+									{
+										Operator: "+",
+										Term: &AddSubTerm{
+											Left: &MathValue{
+												Literal: &MathExprLiteral{
+													Int: tqltest.Intp(2),
+												},
+											},
+											Right: []*OpMultDivValue{
+												{
+													Operator: "*",
+													Value: &MathValue{
+														SubExpression: &MathExpression{
+															Left: &AddSubTerm{
+																Left: &MathValue{
+																	Literal: &MathExprLiteral{
+																		Path: &Path{
+																			Fields: []Field{
+																				{
+																					Name: "duration",
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+															Right: []*OpAddSubTerm{
+																{
+																	Operator: "-",
+																	Term: &AddSubTerm{
+																		Left: &MathValue{
+																			Literal: &MathExprLiteral{
+																				Float: tqltest.Floatp(-1.5),
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -457,6 +684,11 @@ func Test_parse_failure(t *testing.T) {
 		`set("foo") where )`,
 		`set("foo") where (name == "fido"))`,
 		`set("foo") where ((name == "fido")`,
+		`set(name, 1 +)`,
+		`set(name, (1 + 2)`,
+		`set(name, [1, 2)`,
+		`set(attributes[])`,
+		`set(attributes[name])`,
 	}
 	for _, tt := range tests {
 		t.Run(tt, func(t *testing.T) {
@@ -783,6 +1015,56 @@ func Test_parseWhere(t *testing.T) {
 			parsed, err := parseQuery(query)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expected, parsed)
+
+			condition, err := parseCondition(tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.WhereClause, condition)
+		})
+	}
+}
+
+func Test_ParseConditions(t *testing.T) {
+	evaluators, err := ParseConditions([]string{
+		`name == "foo"`,
+		`name != "foo" and true`,
+	}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+	assert.NoError(t, err)
+	assert.Len(t, evaluators, 2)
+
+	ctx := tqltest.TestTransformContext{
+		Item: "foo",
+	}
+	assert.True(t, evaluators[0](ctx))
+	assert.False(t, evaluators[1](ctx))
+}
+
+func Test_ParseConditions_ordering(t *testing.T) {
+	evaluators, err := ParseConditions([]string{
+		`name >= TEST_ENUM_ONE`,
+		`name < 2`,
+		`name > 1.5 and name <= 2`,
+	}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+	assert.NoError(t, err)
+	assert.Len(t, evaluators, 3)
+
+	ctx := tqltest.TestTransformContext{
+		Item: int64(2),
+	}
+	assert.True(t, evaluators[0](ctx))
+	assert.False(t, evaluators[1](ctx))
+	assert.True(t, evaluators[2](ctx))
+}
+
+func Test_ParseConditions_failure(t *testing.T) {
+	tests := []string{
+		`set(name, "foo")`,
+		`name == "foo" where true`,
+		`unknown == "foo"`,
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			_, err := ParseConditions([]string{tt}, DefaultFunctionsForTests(), testParsePath, testParseEnum)
+			assert.Error(t, err)
 		})
 	}
 }
@@ -0,0 +1,431 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+)
+
+var errMissingMetricName = errors.New("time series without a metric name")
+
+// MetadataCache keeps the metadata of metric families across remote write requests. Prometheus sends the
+// metadata in separate and infrequent requests, so the metadata of a request alone is usually not enough to
+// convert its samples. It is safe for concurrent use.
+type MetadataCache struct {
+	mu       sync.RWMutex
+	metadata map[string]prompb.MetricMetadata
+}
+
+// NewMetadataCache returns an empty MetadataCache.
+func NewMetadataCache() *MetadataCache {
+	return &MetadataCache{metadata: make(map[string]prompb.MetricMetadata)}
+}
+
+// Update stores the given metadata, replacing the previous metadata of the same metric families.
+func (mc *MetadataCache) Update(metadata []prompb.MetricMetadata) {
+	if len(metadata) == 0 {
+		return
+	}
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	for _, m := range metadata {
+		mc.metadata[m.MetricFamilyName] = m
+	}
+}
+
+// Get returns the metadata of the given metric family, if known.
+func (mc *MetadataCache) Get(family string) (prompb.MetricMetadata, bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	md, ok := mc.metadata[family]
+	return md, ok
+}
+
+// ToMetrics converts a prometheus remote write request to pmetric.Metrics.
+// Time series are grouped into resources by their "job" and "instance" labels, which are converted back to the
+// service.namespace, service.name and service.instance.id attributes. When the request carries metadata for a
+// metric family, its type determines whether the samples become a sum, a histogram, a summary or a gauge.
+// Samples without metadata are converted to gauges. Time series that can't be converted are skipped, and the
+// returned error describes all of them.
+func ToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	return ToMetricsWithCache(req, NewMetadataCache())
+}
+
+// ToMetricsWithCache converts a prometheus remote write request to pmetric.Metrics like ToMetrics, using the
+// metadata cached from previous requests when the request doesn't carry the metadata of a metric family.
+// The metadata of the request is added to the cache.
+func ToMetricsWithCache(req *prompb.WriteRequest, cache *MetadataCache) (pmetric.Metrics, error) {
+	cache.Update(req.Metadata)
+	c := newPRWConverter(cache)
+	var errs error
+	for i := range req.Timeseries {
+		errs = multierr.Append(errs, c.addTimeSeries(&req.Timeseries[i]))
+	}
+	return c.metrics(), errs
+}
+
+// prwConverter accumulates the time series of a request into pmetric.Metrics.
+type prwConverter struct {
+	metadata  *MetadataCache
+	md        pmetric.Metrics
+	resources map[string]*resourceConverter
+}
+
+type resourceConverter struct {
+	rm      pmetric.ResourceMetrics
+	metrics map[string]pmetric.Metric
+
+	// data points for histograms and summaries are assembled from multiple time series
+	histograms map[string]*histogramPoint
+	summaries  map[string]pmetric.SummaryDataPoint
+}
+
+type histogramPoint struct {
+	dp      pmetric.HistogramDataPoint
+	buckets []bucket
+	count   float64
+	counted bool
+}
+
+type bucket struct {
+	bound float64
+	count float64
+}
+
+func newPRWConverter(metadata *MetadataCache) *prwConverter {
+	return &prwConverter{
+		metadata:  metadata,
+		md:        pmetric.NewMetrics(),
+		resources: make(map[string]*resourceConverter),
+	}
+}
+
+func (c *prwConverter) addTimeSeries(ts *prompb.TimeSeries) error {
+	var name, job, instance string
+	attrs := make([]prompb.Label, 0, len(ts.Labels))
+	for _, l := range ts.Labels {
+		switch l.Name {
+		case nameStr:
+			name = l.Value
+		case model.JobLabel:
+			job = l.Value
+		case model.InstanceLabel:
+			instance = l.Value
+		default:
+			attrs = append(attrs, l)
+		}
+	}
+	if name == "" {
+		return errMissingMetricName
+	}
+	sort.Sort(ByLabelName(attrs))
+
+	rc := c.resource(job, instance)
+	if name == targetMetricName || name == targetMetricName+"_info" {
+		// the target info metric carries the remaining resource attributes
+		for _, l := range attrs {
+			rc.rm.Resource().Attributes().UpsertString(l.Name, l.Value)
+		}
+		return nil
+	}
+
+	family, md := c.family(name)
+	switch md.Type {
+	case prompb.MetricMetadata_COUNTER:
+		metric := rc.metric(name, md, pmetric.MetricDataTypeSum)
+		addNumberDataPoints(metric.Sum().DataPoints(), attrs, ts.Samples)
+	case prompb.MetricMetadata_HISTOGRAM:
+		return rc.addHistogramSamples(family, name, md, attrs, ts.Samples)
+	case prompb.MetricMetadata_SUMMARY:
+		return rc.addSummarySamples(family, name, md, attrs, ts.Samples)
+	default:
+		metric := rc.metric(name, md, pmetric.MetricDataTypeGauge)
+		addNumberDataPoints(metric.Gauge().DataPoints(), attrs, ts.Samples)
+	}
+	return nil
+}
+
+// family returns the name of the metric family the given series belongs to, along with its metadata.
+// Histogram and summary series have a suffix on top of the family name.
+func (c *prwConverter) family(name string) (string, prompb.MetricMetadata) {
+	if md, ok := c.metadata.Get(name); ok {
+		return name, md
+	}
+	for _, suffix := range []string{bucketStr, sumStr, countStr} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		family := strings.TrimSuffix(name, suffix)
+		md, ok := c.metadata.Get(family)
+		if !ok {
+			continue
+		}
+		if md.Type == prompb.MetricMetadata_HISTOGRAM || (md.Type == prompb.MetricMetadata_SUMMARY && suffix != bucketStr) {
+			return family, md
+		}
+	}
+	// OpenMetrics counters are exposed with a "_total" suffix that isn't part of the family name
+	if family := strings.TrimSuffix(name, "_total"); family != name {
+		if md, ok := c.metadata.Get(family); ok && md.Type == prompb.MetricMetadata_COUNTER {
+			return name, md
+		}
+	}
+	return name, prompb.MetricMetadata{Type: prompb.MetricMetadata_UNKNOWN}
+}
+
+func (c *prwConverter) resource(job, instance string) *resourceConverter {
+	key := job + "\xff" + instance
+	if rc, ok := c.resources[key]; ok {
+		return rc
+	}
+
+	rc := &resourceConverter{
+		rm:         c.md.ResourceMetrics().AppendEmpty(),
+		metrics:    make(map[string]pmetric.Metric),
+		histograms: make(map[string]*histogramPoint),
+		summaries:  make(map[string]pmetric.SummaryDataPoint),
+	}
+	rc.rm.ScopeMetrics().AppendEmpty()
+
+	attrs := rc.rm.Resource().Attributes()
+	if job != "" {
+		// the job was built from the service namespace and name, separated by a slash
+		if i := strings.Index(job, "/"); i >= 0 {
+			attrs.UpsertString(conventions.AttributeServiceNamespace, job[:i])
+			attrs.UpsertString(conventions.AttributeServiceName, job[i+1:])
+		} else {
+			attrs.UpsertString(conventions.AttributeServiceName, job)
+		}
+	}
+	if instance != "" {
+		attrs.UpsertString(conventions.AttributeServiceInstanceID, instance)
+	}
+
+	c.resources[key] = rc
+	return rc
+}
+
+func (c *prwConverter) metrics() pmetric.Metrics {
+	for _, rc := range c.resources {
+		for _, hp := range rc.histograms {
+			hp.finish()
+		}
+	}
+	return c.md
+}
+
+func (rc *resourceConverter) metric(name string, md prompb.MetricMetadata, dataType pmetric.MetricDataType) pmetric.Metric {
+	if metric, ok := rc.metrics[name]; ok && metric.DataType() == dataType {
+		return metric
+	}
+
+	metric := rc.rm.ScopeMetrics().At(0).Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(md.Help)
+	metric.SetUnit(md.Unit)
+	metric.SetDataType(dataType)
+	switch dataType {
+	case pmetric.MetricDataTypeSum:
+		metric.Sum().SetIsMonotonic(true)
+		metric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	case pmetric.MetricDataTypeHistogram:
+		metric.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	}
+	rc.metrics[name] = metric
+	return metric
+}
+
+func (rc *resourceConverter) addHistogramSamples(family, name string, md prompb.MetricMetadata, attrs []prompb.Label, samples []prompb.Sample) error {
+	var bound float64
+	attrs, le, hasLe := extractLabel(attrs, leStr)
+	if name == family+bucketStr {
+		if !hasLe {
+			return fmt.Errorf("histogram bucket %q without the %q label", name, leStr)
+		}
+		var err error
+		if bound, err = strconv.ParseFloat(le, 64); err != nil {
+			return fmt.Errorf("invalid %q label for histogram bucket %q: %w", leStr, name, err)
+		}
+	}
+
+	metric := rc.metric(family, md, pmetric.MetricDataTypeHistogram)
+	for _, s := range samples {
+		key := pointKey(family, attrs, s.Timestamp)
+		hp, ok := rc.histograms[key]
+		if !ok {
+			hp = &histogramPoint{dp: metric.Histogram().DataPoints().AppendEmpty()}
+			setPointAttributes(hp.dp.Attributes(), attrs)
+			hp.dp.SetTimestamp(timestampFromMs(s.Timestamp))
+			rc.histograms[key] = hp
+		}
+		if value.IsStaleNaN(s.Value) {
+			hp.dp.SetFlags(pmetric.NewMetricDataPointFlags(pmetric.MetricDataPointFlagNoRecordedValue))
+			continue
+		}
+
+		switch name {
+		case family + bucketStr:
+			hp.buckets = append(hp.buckets, bucket{bound: bound, count: s.Value})
+		case family + sumStr:
+			hp.dp.SetSum(s.Value)
+		case family + countStr:
+			hp.count = s.Value
+			hp.counted = true
+		}
+	}
+	return nil
+}
+
+// finish converts the cumulative prometheus buckets into the bucket counts of the data point
+func (hp *histogramPoint) finish() {
+	sort.Slice(hp.buckets, func(i, j int) bool {
+		return hp.buckets[i].bound < hp.buckets[j].bound
+	})
+
+	bounds := make([]float64, 0, len(hp.buckets))
+	counts := make([]uint64, 0, len(hp.buckets)+1)
+	var previous float64
+	for _, b := range hp.buckets {
+		if !math.IsInf(b.bound, 1) {
+			bounds = append(bounds, b.bound)
+		}
+		counts = append(counts, uint64(math.Max(b.count-previous, 0)))
+		previous = b.count
+	}
+
+	count := previous
+	if hp.counted {
+		count = hp.count
+	}
+	// the +Inf bucket might not have been sent, in which case the total count fills it
+	if len(hp.buckets) > 0 && !math.IsInf(hp.buckets[len(hp.buckets)-1].bound, 1) {
+		counts = append(counts, uint64(math.Max(count-previous, 0)))
+	}
+
+	hp.dp.SetCount(uint64(count))
+	if len(hp.buckets) > 0 {
+		hp.dp.SetMExplicitBounds(bounds)
+		hp.dp.SetMBucketCounts(counts)
+	}
+}
+
+func (rc *resourceConverter) addSummarySamples(family, name string, md prompb.MetricMetadata, attrs []prompb.Label, samples []prompb.Sample) error {
+	var q float64
+	attrs, quantile, hasQuantile := extractLabel(attrs, quantileStr)
+	if name == family {
+		if !hasQuantile {
+			return fmt.Errorf("summary %q without the %q label", name, quantileStr)
+		}
+		var err error
+		if q, err = strconv.ParseFloat(quantile, 64); err != nil {
+			return fmt.Errorf("invalid %q label for summary %q: %w", quantileStr, name, err)
+		}
+	}
+
+	metric := rc.metric(family, md, pmetric.MetricDataTypeSummary)
+	for _, s := range samples {
+		key := pointKey(family, attrs, s.Timestamp)
+		dp, ok := rc.summaries[key]
+		if !ok {
+			dp = metric.Summary().DataPoints().AppendEmpty()
+			setPointAttributes(dp.Attributes(), attrs)
+			dp.SetTimestamp(timestampFromMs(s.Timestamp))
+			rc.summaries[key] = dp
+		}
+		if value.IsStaleNaN(s.Value) {
+			dp.SetFlags(pmetric.NewMetricDataPointFlags(pmetric.MetricDataPointFlagNoRecordedValue))
+			continue
+		}
+
+		switch name {
+		case family:
+			qv := dp.QuantileValues().AppendEmpty()
+			qv.SetQuantile(q)
+			qv.SetValue(s.Value)
+		case family + sumStr:
+			dp.SetSum(s.Value)
+		case family + countStr:
+			dp.SetCount(uint64(s.Value))
+		}
+	}
+	return nil
+}
+
+func addNumberDataPoints(dps pmetric.NumberDataPointSlice, attrs []prompb.Label, samples []prompb.Sample) {
+	for _, s := range samples {
+		dp := dps.AppendEmpty()
+		setPointAttributes(dp.Attributes(), attrs)
+		dp.SetTimestamp(timestampFromMs(s.Timestamp))
+		if value.IsStaleNaN(s.Value) {
+			dp.SetFlags(pmetric.NewMetricDataPointFlags(pmetric.MetricDataPointFlagNoRecordedValue))
+			continue
+		}
+		dp.SetDoubleVal(s.Value)
+	}
+}
+
+func setPointAttributes(dest pcommon.Map, attrs []prompb.Label) {
+	dest.EnsureCapacity(len(attrs))
+	for _, l := range attrs {
+		dest.UpsertString(l.Name, l.Value)
+	}
+}
+
+// extractLabel returns the labels without the one with the given name, along with its value
+func extractLabel(labels []prompb.Label, name string) ([]prompb.Label, string, bool) {
+	for i, l := range labels {
+		if l.Name == name {
+			rest := make([]prompb.Label, 0, len(labels)-1)
+			rest = append(rest, labels[:i]...)
+			return append(rest, labels[i+1:]...), l.Value, true
+		}
+	}
+	return labels, "", false
+}
+
+// pointKey identifies the data point the samples of histograms and summaries belong to.
+// The labels are expected to be sorted by name.
+func pointKey(family string, labels []prompb.Label, timestamp int64) string {
+	var b strings.Builder
+	b.WriteString(family)
+	for _, l := range labels {
+		b.WriteString("\xff")
+		b.WriteString(l.Name)
+		b.WriteString("\xff")
+		b.WriteString(l.Value)
+	}
+	b.WriteString("\xff")
+	b.WriteString(strconv.FormatInt(timestamp, 10))
+	return b.String()
+}
+
+// timestampFromMs converts a timestamp in ms to an OTLP timestamp in ns
+func timestampFromMs(timestamp int64) pcommon.Timestamp {
+	return pcommon.Timestamp(timestamp * int64(1e6))
+}
@@ -0,0 +1,310 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func series(name string, timestamp int64, v float64, labels ...string) prompb.TimeSeries {
+	ts := prompb.TimeSeries{
+		Labels:  []prompb.Label{{Name: nameStr, Value: name}},
+		Samples: []prompb.Sample{{Value: v, Timestamp: timestamp}},
+	}
+	for i := 0; i+1 < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return ts
+}
+
+func TestToMetricsResources(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", msTime1, 1, "job", "ns/api", "instance", "host:8080"),
+			series("up", msTime1, 0, "job", "worker", "instance", "host:9090"),
+			series("target_info", msTime1, 1, "job", "worker", "instance", "host:9090", "region", "eu"),
+			series("temperature", msTime1, 21.5, "job", "ns/api", "instance", "host:8080", "room", "kitchen"),
+		},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	api := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceNamespace:  "ns",
+		conventions.AttributeServiceName:       "api",
+		conventions.AttributeServiceInstanceID: "host:8080",
+	}, api.Resource().Attributes().AsRaw())
+	metrics := api.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "up", metrics.At(0).Name())
+	assert.Equal(t, "temperature", metrics.At(1).Name())
+	dp := metrics.At(1).Gauge().DataPoints().At(0)
+	assert.Equal(t, 21.5, dp.DoubleVal())
+	assert.Equal(t, pcommon.Timestamp(msTime1*1e6), dp.Timestamp())
+	assert.Equal(t, map[string]interface{}{"room": "kitchen"}, dp.Attributes().AsRaw())
+
+	worker := md.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "worker",
+		conventions.AttributeServiceInstanceID: "host:9090",
+		"region":                               "eu",
+	}, worker.Resource().Attributes().AsRaw())
+	assert.Equal(t, 1, worker.ScopeMetrics().At(0).Metrics().Len())
+}
+
+func TestToMetricsMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		metadata     []prompb.MetricMetadata
+		series       string
+		expectedType pmetric.MetricDataType
+	}{
+		{
+			name:         "without metadata",
+			series:       "requests_total",
+			expectedType: pmetric.MetricDataTypeGauge,
+		},
+		{
+			name:         "gauge",
+			metadata:     []prompb.MetricMetadata{{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "requests_total"}},
+			series:       "requests_total",
+			expectedType: pmetric.MetricDataTypeGauge,
+		},
+		{
+			name:         "counter",
+			metadata:     []prompb.MetricMetadata{{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total", Help: "Requests", Unit: "1"}},
+			series:       "requests_total",
+			expectedType: pmetric.MetricDataTypeSum,
+		},
+		{
+			name:         "openmetrics counter",
+			metadata:     []prompb.MetricMetadata{{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Requests", Unit: "1"}},
+			series:       "requests_total",
+			expectedType: pmetric.MetricDataTypeSum,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series(tt.series, msTime1, 10, "job", "api")},
+				Metadata:   tt.metadata,
+			}
+
+			md, err := ToMetrics(req)
+			require.NoError(t, err)
+
+			metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, tt.series, metric.Name())
+			require.Equal(t, tt.expectedType, metric.DataType())
+			if tt.expectedType == pmetric.MetricDataTypeSum {
+				assert.True(t, metric.Sum().IsMonotonic())
+				assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+				assert.Equal(t, 10.0, metric.Sum().DataPoints().At(0).DoubleVal())
+				assert.Equal(t, "Requests", metric.Description())
+				assert.Equal(t, "1", metric.Unit())
+			}
+		})
+	}
+}
+
+func TestToMetricsHistogram(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("latency_bucket", msTime1, 2, "job", "api", "le", "0.1", "path", "/"),
+			series("latency_bucket", msTime1, 5, "job", "api", "le", "1", "path", "/"),
+			series("latency_bucket", msTime1, 6, "job", "api", "le", "+Inf", "path", "/"),
+			series("latency_sum", msTime1, 3.5, "job", "api", "path", "/"),
+			series("latency_count", msTime1, 6, "job", "api", "path", "/"),
+			series("latency_bucket", msTime1, 1, "job", "api", "le", "0.1", "path", "/other"),
+			series("latency_count", msTime1, 4, "job", "api", "path", "/other"),
+		},
+		Metadata: []prompb.MetricMetadata{{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency"}},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	metric := metrics.At(0)
+	assert.Equal(t, "latency", metric.Name())
+	require.Equal(t, pmetric.MetricDataTypeHistogram, metric.DataType())
+	require.Equal(t, 2, metric.Histogram().DataPoints().Len())
+
+	dp := metric.Histogram().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"path": "/"}, dp.Attributes().AsRaw())
+	assert.Equal(t, []float64{0.1, 1}, dp.MExplicitBounds())
+	assert.Equal(t, []uint64{2, 3, 1}, dp.MBucketCounts())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 3.5, dp.Sum())
+
+	// the +Inf bucket is derived from the count when missing
+	dp = metric.Histogram().DataPoints().At(1)
+	assert.Equal(t, []float64{0.1}, dp.MExplicitBounds())
+	assert.Equal(t, []uint64{1, 3}, dp.MBucketCounts())
+	assert.Equal(t, uint64(4), dp.Count())
+}
+
+func TestToMetricsWithCache(t *testing.T) {
+	cache := NewMetadataCache()
+
+	// Prometheus sends the metadata in its own requests
+	md, err := ToMetricsWithCache(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total"},
+			{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency"},
+		},
+	}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	md, err = ToMetricsWithCache(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("requests_total", msTime1, 10, "job", "api"),
+			series("latency_bucket", msTime1, 2, "job", "api", "le", "+Inf"),
+			series("latency_count", msTime1, 2, "job", "api"),
+		},
+	}, cache)
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, pmetric.MetricDataTypeSum, metrics.At(0).DataType())
+	assert.Equal(t, pmetric.MetricDataTypeHistogram, metrics.At(1).DataType())
+
+	// new metadata replaces the cached one
+	md, err = ToMetricsWithCache(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{series("requests_total", msTime2, 11, "job", "api")},
+		Metadata:   []prompb.MetricMetadata{{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "requests_total"}},
+	}, cache)
+	require.NoError(t, err)
+	assert.Equal(t, pmetric.MetricDataTypeGauge, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).DataType())
+}
+
+func TestToMetricsSummary(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("rpc_duration", msTime1, 0.2, "job", "api", "quantile", "0.5"),
+			series("rpc_duration", msTime1, 0.9, "job", "api", "quantile", "0.99"),
+			series("rpc_duration_sum", msTime1, 12, "job", "api"),
+			series("rpc_duration_count", msTime1, 30, "job", "api"),
+		},
+		Metadata: []prompb.MetricMetadata{{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "rpc_duration"}},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "rpc_duration", metric.Name())
+	require.Equal(t, pmetric.MetricDataTypeSummary, metric.DataType())
+	require.Equal(t, 1, metric.Summary().DataPoints().Len())
+
+	dp := metric.Summary().DataPoints().At(0)
+	assert.Equal(t, 12.0, dp.Sum())
+	assert.Equal(t, uint64(30), dp.Count())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestToMetricsStaleMarker(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", msTime1, math.Float64frombits(value.StaleNaN), "job", "api"),
+		},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.True(t, dp.Flags().HasFlag(pmetric.MetricDataPointFlagNoRecordedValue))
+}
+
+func TestToMetricsInvalidSeries(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{Labels: []prompb.Label{{Name: "job", Value: "api"}}, Samples: []prompb.Sample{{Value: 1, Timestamp: msTime1}}},
+			series("latency_bucket", msTime1, 1, "job", "api"),
+			series("up", msTime1, 1, "job", "api"),
+		},
+		Metadata: []prompb.MetricMetadata{{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency"}},
+	}
+
+	md, err := ToMetrics(req)
+	assert.ErrorIs(t, err, errMissingMetricName)
+	assert.Contains(t, err.Error(), "without the \"le\" label")
+
+	// valid series are still converted
+	var names []string
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	assert.Contains(t, names, "up")
+}
+
+func TestRoundTrip(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().UpsertString(conventions.AttributeServiceName, "api")
+	rm.Resource().Attributes().UpsertString(conventions.AttributeServiceInstanceID, "host:8080")
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests_total")
+	metric.SetDataType(pmetric.MetricDataTypeSum)
+	metric.Sum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	dp.SetDoubleVal(42)
+	dp.SetTimestamp(pcommon.Timestamp(msTime1 * 1e6))
+	dp.Attributes().UpsertString("code", "200")
+
+	tsMap, err := FromMetrics(md, Settings{})
+	require.NoError(t, err)
+
+	req := &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total"}},
+	}
+	for _, ts := range tsMap {
+		req.Timeseries = append(req.Timeseries, *ts)
+	}
+
+	got, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.ResourceMetrics().Len())
+	assert.Equal(t, rm.Resource().Attributes().AsRaw(), got.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	gotMetric := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests_total", gotMetric.Name())
+	require.Equal(t, pmetric.MetricDataTypeSum, gotMetric.DataType())
+	assert.Equal(t, dp.Attributes().AsRaw(), gotMetric.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, dp.DoubleVal(), gotMetric.Sum().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, dp.Timestamp(), gotMetric.Sum().DataPoints().At(0).Timestamp())
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+// delta returns the difference between hp and the previous cumulative point prev.
+// It returns false when hp cannot be a continuation of prev, because the bucket
+// layout changed incompatibly or because any of the counts went down, in which
+// case hp starts a new series.
+func (hp *HistogramPoint) delta(prev *HistogramPoint) (*HistogramPoint, bool) {
+	if prev == nil {
+		return hp, true
+	}
+	if hp.Count < prev.Count || !hp.compatibleLayout(prev) {
+		return nil, false
+	}
+
+	// Lowering the scale of an exponential histogram merges adjacent buckets,
+	// so the previous buckets are merged the same way before subtracting.
+	prevPositive := prev.Positive.downscale(prev.Scale - hp.Scale)
+	prevNegative := prev.Negative.downscale(prev.Scale - hp.Scale)
+
+	bucketCounts, ok := subtractCounts(hp.BucketCounts, prev.BucketCounts)
+	if !ok {
+		return nil, false
+	}
+	positive, ok := hp.Positive.delta(prevPositive)
+	if !ok {
+		return nil, false
+	}
+	negative, ok := hp.Negative.delta(prevNegative)
+	if !ok || hp.ZeroCount < prev.ZeroCount {
+		return nil, false
+	}
+
+	out := &HistogramPoint{
+		Count:          hp.Count - prev.Count,
+		HasSum:         hp.HasSum && prev.HasSum,
+		ExplicitBounds: hp.ExplicitBounds,
+		BucketCounts:   bucketCounts,
+		Scale:          hp.Scale,
+		ZeroCount:      hp.ZeroCount - prev.ZeroCount,
+		Positive:       positive,
+		Negative:       negative,
+	}
+	if out.HasSum {
+		out.Sum = hp.Sum - prev.Sum
+	}
+
+	// The min and max of the interval are only known when a new extreme was
+	// observed during it; otherwise they are left unset.
+	if out.Count > 0 {
+		if hp.HasMin && (!prev.HasMin || hp.Min < prev.Min) {
+			out.Min, out.HasMin = hp.Min, true
+		}
+		if hp.HasMax && (!prev.HasMax || hp.Max > prev.Max) {
+			out.Max, out.HasMax = hp.Max, true
+		}
+	}
+	return out, true
+}
+
+// compatibleLayout returns whether the buckets of prev can be subtracted from those of hp.
+// The scale of an exponential histogram can only be lowered without a reset.
+func (hp *HistogramPoint) compatibleLayout(prev *HistogramPoint) bool {
+	if hp.Scale > prev.Scale ||
+		len(hp.BucketCounts) != len(prev.BucketCounts) ||
+		len(hp.ExplicitBounds) != len(prev.ExplicitBounds) {
+		return false
+	}
+	for i, bound := range hp.ExplicitBounds {
+		if bound != prev.ExplicitBounds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// delta subtracts prev from eb, aligning both by their offsets. The range of
+// a cumulative exponential histogram can only grow, so any previously
+// populated bucket falling outside of eb is treated as a reset.
+func (eb ExponentialBuckets) delta(prev ExponentialBuckets) (ExponentialBuckets, bool) {
+	for i, count := range prev.BucketCounts {
+		idx := int(prev.Offset) + i - int(eb.Offset)
+		if count > 0 && (idx < 0 || idx >= len(eb.BucketCounts)) {
+			return ExponentialBuckets{}, false
+		}
+	}
+
+	out := ExponentialBuckets{Offset: eb.Offset}
+	if len(eb.BucketCounts) == 0 {
+		return out, true
+	}
+	out.BucketCounts = make([]uint64, len(eb.BucketCounts))
+	for i, count := range eb.BucketCounts {
+		var prevCount uint64
+		if idx := int(eb.Offset) + i - int(prev.Offset); idx >= 0 && idx < len(prev.BucketCounts) {
+			prevCount = prev.BucketCounts[idx]
+		}
+		if count < prevCount {
+			return ExponentialBuckets{}, false
+		}
+		out.BucketCounts[i] = count - prevCount
+	}
+	return out, true
+}
+
+// downscale returns the buckets of eb merged into those of a scale lower by the given amount.
+// Each decrement of the scale merges pairs of adjacent buckets.
+func (eb ExponentialBuckets) downscale(by int32) ExponentialBuckets {
+	if by <= 0 || len(eb.BucketCounts) == 0 {
+		return eb
+	}
+	offset := eb.Offset >> by
+	last := (eb.Offset + int32(len(eb.BucketCounts)) - 1) >> by
+	out := ExponentialBuckets{Offset: offset, BucketCounts: make([]uint64, last-offset+1)}
+	for i, count := range eb.BucketCounts {
+		out.BucketCounts[((eb.Offset+int32(i))>>by)-offset] += count
+	}
+	return out
+}
+
+func subtractCounts(counts, prevCounts []uint64) ([]uint64, bool) {
+	if len(counts) == 0 {
+		return nil, true
+	}
+	out := make([]uint64, len(counts))
+	for i, count := range counts {
+		if count < prevCounts[i] {
+			return nil, false
+		}
+		out[i] = count - prevCounts[i]
+	}
+	return out, true
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestHistogramPoint_delta(t *testing.T) {
+	tests := []struct {
+		name string
+		prev *HistogramPoint
+		cur  *HistogramPoint
+		want *HistogramPoint
+	}{
+		{
+			name: "explicit buckets",
+			prev: &HistogramPoint{
+				Count: 10, Sum: 100, HasSum: true,
+				Min: 1, HasMin: true, Max: 20, HasMax: true,
+				ExplicitBounds: []float64{5, 10},
+				BucketCounts:   []uint64{5, 3, 2},
+			},
+			cur: &HistogramPoint{
+				Count: 15, Sum: 180, HasSum: true,
+				Min: 1, HasMin: true, Max: 30, HasMax: true,
+				ExplicitBounds: []float64{5, 10},
+				BucketCounts:   []uint64{6, 5, 4},
+			},
+			want: &HistogramPoint{
+				Count: 5, Sum: 80, HasSum: true,
+				Max: 30, HasMax: true,
+				ExplicitBounds: []float64{5, 10},
+				BucketCounts:   []uint64{1, 2, 2},
+			},
+		},
+		{
+			name: "no new observations",
+			prev: &HistogramPoint{
+				Count: 10, Sum: 100, HasSum: true,
+				Min: 1, HasMin: true, Max: 20, HasMax: true,
+				BucketCounts: []uint64{10},
+			},
+			cur: &HistogramPoint{
+				Count: 10, Sum: 100, HasSum: true,
+				Min: 1, HasMin: true, Max: 20, HasMax: true,
+				BucketCounts: []uint64{10},
+			},
+			want: &HistogramPoint{
+				HasSum:       true,
+				BucketCounts: []uint64{0},
+			},
+		},
+		{
+			name: "count reset",
+			prev: &HistogramPoint{
+				Count:        10,
+				BucketCounts: []uint64{5, 5},
+			},
+			cur: &HistogramPoint{
+				Count:        4,
+				BucketCounts: []uint64{2, 2},
+			},
+			want: nil,
+		},
+		{
+			name: "bucket reset",
+			prev: &HistogramPoint{
+				Count:        10,
+				BucketCounts: []uint64{5, 5},
+			},
+			cur: &HistogramPoint{
+				Count:        12,
+				BucketCounts: []uint64{12, 0},
+			},
+			want: nil,
+		},
+		{
+			name: "explicit bounds changed",
+			prev: &HistogramPoint{
+				Count:          10,
+				ExplicitBounds: []float64{5},
+				BucketCounts:   []uint64{5, 5},
+			},
+			cur: &HistogramPoint{
+				Count:          12,
+				ExplicitBounds: []float64{10},
+				BucketCounts:   []uint64{6, 6},
+			},
+			want: nil,
+		},
+		{
+			name: "exponential buckets with shifted offset",
+			prev: &HistogramPoint{
+				Count: 6, Scale: 1, ZeroCount: 1,
+				Positive: ExponentialBuckets{Offset: 2, BucketCounts: []uint64{2, 3}},
+			},
+			cur: &HistogramPoint{
+				Count: 10, Scale: 1, ZeroCount: 2,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 3, 3, 1}},
+				Negative: ExponentialBuckets{Offset: -1, BucketCounts: []uint64{0}},
+			},
+			want: &HistogramPoint{
+				Count: 4, Scale: 1, ZeroCount: 1,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 1, 0, 1}},
+				Negative: ExponentialBuckets{Offset: -1, BucketCounts: []uint64{0}},
+			},
+		},
+		{
+			name: "exponential buckets out of range",
+			prev: &HistogramPoint{
+				Count: 5, Scale: 1,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{5}},
+			},
+			cur: &HistogramPoint{
+				Count: 6, Scale: 1,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{6}},
+			},
+			want: nil,
+		},
+		{
+			name: "exponential scale decreased",
+			prev: &HistogramPoint{
+				Count: 6, Scale: 2,
+				Positive: ExponentialBuckets{Offset: -3, BucketCounts: []uint64{1, 1, 1, 1}},
+				Negative: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 1}},
+			},
+			cur: &HistogramPoint{
+				Count: 10, Scale: 1,
+				Positive: ExponentialBuckets{Offset: -2, BucketCounts: []uint64{2, 3, 2}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 2}},
+			},
+			want: &HistogramPoint{
+				Count: 4, Scale: 1,
+				Positive: ExponentialBuckets{Offset: -2, BucketCounts: []uint64{1, 1, 1}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{0, 1}},
+			},
+		},
+		{
+			name: "exponential scale increased",
+			prev: &HistogramPoint{
+				Count: 5, Scale: 1,
+				Positive: ExponentialBuckets{BucketCounts: []uint64{5}},
+			},
+			cur: &HistogramPoint{
+				Count: 6, Scale: 2,
+				Positive: ExponentialBuckets{BucketCounts: []uint64{6}},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.cur.delta(tt.prev)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMetricTracker_ConvertHistogram(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricDataType:         pmetric.MetricDataTypeHistogram,
+		MetricIsMonotonic:      true,
+		StartTimestamp:         5,
+		Attributes:             pcommon.NewMap(),
+	}
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	first := &HistogramPoint{Count: 2, BucketCounts: []uint64{1, 1}}
+	out, valid := m.Convert(MetricPoint{
+		Identity: mi,
+		Value:    ValuePoint{ObservedTimestamp: 10, HistogramValue: first},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(10), out.StartTimestamp)
+	assert.Equal(t, first, out.HistogramValue)
+
+	out, valid = m.Convert(MetricPoint{
+		Identity: mi,
+		Value:    ValuePoint{ObservedTimestamp: 20, HistogramValue: &HistogramPoint{Count: 5, BucketCounts: []uint64{3, 2}}},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(10), out.StartTimestamp)
+	assert.Equal(t, &HistogramPoint{Count: 3, BucketCounts: []uint64{2, 1}}, out.HistogramValue)
+
+	// A reset is emitted with its own cumulative values and becomes the reference for the following point
+	reset := &HistogramPoint{
+		Count: 1, Sum: 3, HasSum: true,
+		Min: 3, HasMin: true, Max: 3, HasMax: true,
+		BucketCounts: []uint64{1, 0},
+	}
+	out, valid = m.Convert(MetricPoint{
+		Identity: mi,
+		Value:    ValuePoint{ObservedTimestamp: 30, HistogramValue: reset},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(5), out.StartTimestamp)
+	assert.Equal(t, reset, out.HistogramValue)
+
+	out, valid = m.Convert(MetricPoint{
+		Identity: mi,
+		Value:    ValuePoint{ObservedTimestamp: 40, HistogramValue: &HistogramPoint{Count: 4, BucketCounts: []uint64{2, 2}}},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(30), out.StartTimestamp)
+	assert.Equal(t, &HistogramPoint{Count: 3, BucketCounts: []uint64{1, 2}}, out.HistogramValue)
+}
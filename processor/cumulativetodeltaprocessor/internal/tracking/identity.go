@@ -81,6 +81,16 @@ func (mi *MetricIdentity) IsFloatVal() bool {
 	return mi.MetricValueType == pmetric.NumberDataPointValueTypeDouble
 }
 
+func (mi *MetricIdentity) IsHistogram() bool {
+	return mi.MetricDataType == pmetric.MetricDataTypeHistogram ||
+		mi.MetricDataType == pmetric.MetricDataTypeExponentialHistogram
+}
+
 func (mi *MetricIdentity) IsSupportedMetricType() bool {
-	return mi.MetricDataType == pmetric.MetricDataTypeSum
+	switch mi.MetricDataType {
+	case pmetric.MetricDataTypeSum, pmetric.MetricDataTypeHistogram, pmetric.MetricDataTypeExponentialHistogram:
+		return true
+	default:
+		return false
+	}
 }
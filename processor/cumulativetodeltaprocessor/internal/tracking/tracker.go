@@ -44,6 +44,7 @@ type DeltaValue struct {
 	StartTimestamp pcommon.Timestamp
 	FloatValue     float64
 	IntValue       int64
+	HistogramValue *HistogramPoint
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -95,6 +96,7 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 				StartTimestamp: metricPoint.ObservedTimestamp,
 				FloatValue:     metricPoint.FloatValue,
 				IntValue:       metricPoint.IntValue,
+				HistogramValue: metricPoint.HistogramValue,
 			}
 			valid = true
 		}
@@ -108,7 +110,21 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 
 	out.StartTimestamp = state.PrevPoint.ObservedTimestamp
 
-	if metricID.IsFloatVal() {
+	switch {
+	case metricID.IsHistogram():
+		delta, ok := metricPoint.HistogramValue.delta(state.PrevPoint.HistogramValue)
+
+		// A histogram which was reset, or whose buckets can't be compared to the previous ones,
+		// starts afresh: its own cumulative values are the delta since its start time.
+		if !ok {
+			delta = metricPoint.HistogramValue
+			if metricID.StartTimestamp != 0 {
+				out.StartTimestamp = metricID.StartTimestamp
+			}
+		}
+
+		out.HistogramValue = delta
+	case metricID.IsFloatVal():
 		value := metricPoint.FloatValue
 		prevValue := state.PrevPoint.FloatValue
 		delta := value - prevValue
@@ -119,7 +135,7 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.FloatValue = delta
-	} else {
+	default:
 		value := metricPoint.IntValue
 		prevValue := state.PrevPoint.IntValue
 		delta := value - prevValue
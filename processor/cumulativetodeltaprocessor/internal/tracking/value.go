@@ -20,4 +20,32 @@ type ValuePoint struct {
 	ObservedTimestamp pcommon.Timestamp
 	FloatValue        float64
 	IntValue          int64
+	HistogramValue    *HistogramPoint
+}
+
+// HistogramPoint holds the counts of an explicit or exponential histogram
+// data point. Explicit histograms use ExplicitBounds and BucketCounts,
+// exponential histograms use Scale, ZeroCount, Positive and Negative.
+type HistogramPoint struct {
+	Count  uint64
+	Sum    float64
+	HasSum bool
+	Min    float64
+	HasMin bool
+	Max    float64
+	HasMax bool
+
+	ExplicitBounds []float64
+	BucketCounts   []uint64
+
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+// ExponentialBuckets holds the bucket counts of one range of an exponential histogram.
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
 }
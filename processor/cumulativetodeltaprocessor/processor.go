@@ -18,6 +18,7 @@ import (
 	"context"
 	"math"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
@@ -91,6 +92,41 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 					ctdp.convertDataPoints(ms.DataPoints(), baseIdentity)
 					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeHistogram:
+					ms := m.Histogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+						return false
+					}
+
+					// Histogram counts can only go up, so they are always treated as monotonic
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricDataType:         m.DataType(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+					}
+					ctdp.convertDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricDataTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricDataType:         m.DataType(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+					}
+					ctdp.convertDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
 				default:
 					return false
 				}
@@ -119,7 +155,8 @@ func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) b
 
 func (ctdp *cumulativeToDeltaProcessor) convertDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
 
-	if dps, ok := in.(pmetric.NumberDataPointSlice); ok {
+	switch dps := in.(type) {
+	case pmetric.NumberDataPointSlice:
 		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
 			id := baseIdentity
 			id.StartTimestamp = dp.StartTimestamp()
@@ -157,5 +194,133 @@ func (ctdp *cumulativeToDeltaProcessor) convertDataPoints(in interface{}, baseId
 			}
 			return false
 		})
+	case pmetric.HistogramDataPointSlice:
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			// Do not attempt to transform points marked as stale
+			if dp.Flags().HasFlag(pmetric.MetricDataPointFlagNoRecordedValue) {
+				return false
+			}
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				HistogramValue: &tracking.HistogramPoint{
+					Count:          dp.Count(),
+					Sum:            dp.Sum(),
+					HasSum:         dp.HasSum(),
+					Min:            dp.Min(),
+					HasMin:         dp.HasMin(),
+					Max:            dp.Max(),
+					HasMax:         dp.HasMax(),
+					ExplicitBounds: dp.ExplicitBounds().AsRaw(),
+					BucketCounts:   dp.BucketCounts().AsRaw(),
+				},
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			})
+			if !valid {
+				return true
+			}
+			setHistogramDelta(dp, delta)
+			return false
+		})
+	case pmetric.ExponentialHistogramDataPointSlice:
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			// Do not attempt to transform points marked as stale
+			if dp.Flags().HasFlag(pmetric.MetricDataPointFlagNoRecordedValue) {
+				return false
+			}
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				HistogramValue: &tracking.HistogramPoint{
+					Count:     dp.Count(),
+					Sum:       dp.Sum(),
+					HasSum:    dp.HasSum(),
+					Min:       dp.Min(),
+					HasMin:    dp.HasMin(),
+					Max:       dp.Max(),
+					HasMax:    dp.HasMax(),
+					Scale:     dp.Scale(),
+					ZeroCount: dp.ZeroCount(),
+					Positive: tracking.ExponentialBuckets{
+						Offset:       dp.Positive().Offset(),
+						BucketCounts: dp.Positive().BucketCounts().AsRaw(),
+					},
+					Negative: tracking.ExponentialBuckets{
+						Offset:       dp.Negative().Offset(),
+						BucketCounts: dp.Negative().BucketCounts().AsRaw(),
+					},
+				},
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			})
+			if !valid {
+				return true
+			}
+			setExponentialHistogramDelta(dp, delta)
+			return false
+		})
+	}
+}
+
+// setHistogramDelta replaces the values of dp with the given delta.
+// pdata does not allow unsetting sum, min or max, so a new point is
+// built and moved over dp.
+func setHistogramDelta(dp pmetric.HistogramDataPoint, delta tracking.DeltaValue) {
+	hv := delta.HistogramValue
+	out := pmetric.NewHistogramDataPoint()
+	dp.Attributes().CopyTo(out.Attributes())
+	dp.Exemplars().CopyTo(out.Exemplars())
+	out.SetFlags(dp.Flags())
+	out.SetStartTimestamp(delta.StartTimestamp)
+	out.SetTimestamp(dp.Timestamp())
+	out.SetCount(hv.Count)
+	if hv.HasSum {
+		out.SetSum(hv.Sum)
+	}
+	if hv.HasMin {
+		out.SetMin(hv.Min)
+	}
+	if hv.HasMax {
+		out.SetMax(hv.Max)
+	}
+	out.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(hv.ExplicitBounds))
+	out.SetBucketCounts(pcommon.NewImmutableUInt64Slice(hv.BucketCounts))
+	out.MoveTo(dp)
+}
+
+// setExponentialHistogramDelta replaces the values of dp with the given delta.
+func setExponentialHistogramDelta(dp pmetric.ExponentialHistogramDataPoint, delta tracking.DeltaValue) {
+	hv := delta.HistogramValue
+	out := pmetric.NewExponentialHistogramDataPoint()
+	dp.Attributes().CopyTo(out.Attributes())
+	dp.Exemplars().CopyTo(out.Exemplars())
+	out.SetFlags(dp.Flags())
+	out.SetStartTimestamp(delta.StartTimestamp)
+	out.SetTimestamp(dp.Timestamp())
+	out.SetCount(hv.Count)
+	if hv.HasSum {
+		out.SetSum(hv.Sum)
+	}
+	if hv.HasMin {
+		out.SetMin(hv.Min)
+	}
+	if hv.HasMax {
+		out.SetMax(hv.Max)
 	}
+	out.SetScale(hv.Scale)
+	out.SetZeroCount(hv.ZeroCount)
+	out.Positive().SetOffset(hv.Positive.Offset)
+	out.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(hv.Positive.BucketCounts))
+	out.Negative().SetOffset(hv.Negative.Offset)
+	out.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice(hv.Negative.BucketCounts))
+	out.MoveTo(dp)
 }
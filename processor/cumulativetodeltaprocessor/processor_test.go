@@ -39,6 +39,16 @@ type testMetric struct {
 	isCumulative []bool
 }
 
+type testHistogramMetric struct {
+	metricNames   []string
+	metricCounts  [][]uint64
+	metricSums    [][]float64
+	metricMins    [][]float64
+	metricMaxes   [][]float64
+	metricBuckets [][][]uint64
+	isCumulative  []bool
+}
+
 type cumulativeToDeltaTest struct {
 	name       string
 	metrics    []string
@@ -167,6 +177,51 @@ var (
 				isCumulative: []bool{true, true},
 			}),
 		},
+		{
+			name: "cumulative_to_delta_histogram",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricCounts:  [][]uint64{{100, 200, 500}, {4}},
+				metricSums:    [][]float64{{100, 200, 500}, {4}},
+				metricMins:    [][]float64{{1, 1, 0.5}, {1}},
+				metricMaxes:   [][]float64{{10, 10, 20}, {4}},
+				metricBuckets: [][][]uint64{{{50, 25, 25}, {100, 50, 50}, {250, 125, 125}}, {{4, 4, 4}}},
+				isCumulative:  []bool{true, true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricCounts:  [][]uint64{{100, 100, 300}, {4}},
+				metricSums:    [][]float64{{100, 100, 300}, {4}},
+				metricMins:    [][]float64{{1, math.NaN(), 0.5}, {1}},
+				metricMaxes:   [][]float64{{10, math.NaN(), 20}, {4}},
+				metricBuckets: [][][]uint64{{{50, 25, 25}, {50, 25, 25}, {150, 75, 75}}, {{4, 4, 4}}},
+				isCumulative:  []bool{false, true},
+			}),
+		},
+		{
+			name: "cumulative_to_delta_histogram_reset",
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{100, 200, 50}},
+				metricSums:    [][]float64{{100, 200, 50}},
+				metricBuckets: [][][]uint64{{{50, 25, 25}, {100, 50, 50}, {25, 15, 10}}},
+				isCumulative:  []bool{true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{100, 100, 50}},
+				metricSums:    [][]float64{{100, 100, 50}},
+				metricBuckets: [][][]uint64{{{50, 25, 25}, {50, 25, 25}, {25, 15, 10}}},
+				isCumulative:  []bool{false},
+			}),
+		},
 	}
 )
 
@@ -240,6 +295,24 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 					}
 				}
 
+				if eM.DataType() == pmetric.MetricDataTypeHistogram {
+					eDataPoints := eM.Histogram().DataPoints()
+					aDataPoints := aM.Histogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.Histogram().AggregationTemporality(), aM.Histogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).HasSum(), aDataPoints.At(j).HasSum())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).HasMin(), aDataPoints.At(j).HasMin())
+						require.Equal(t, eDataPoints.At(j).Min(), aDataPoints.At(j).Min())
+						require.Equal(t, eDataPoints.At(j).HasMax(), aDataPoints.At(j).HasMax())
+						require.Equal(t, eDataPoints.At(j).Max(), aDataPoints.At(j).Max())
+						require.Equal(t, eDataPoints.At(j).BucketCounts(), aDataPoints.At(j).BucketCounts())
+					}
+				}
 			}
 
 			require.NoError(t, mgp.Shutdown(ctx))
@@ -277,6 +350,45 @@ func generateTestMetrics(tm testMetric) pmetric.Metrics {
 	return md
 }
 
+func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := time.Now()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pmetric.MetricDataTypeHistogram)
+
+		hist := m.Histogram()
+
+		if tm.isCumulative[i] {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		} else {
+			hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(10 * time.Second)))
+			dp.SetCount(count)
+			dp.SetSum(tm.metricSums[i][index])
+			// NaN marks a min or max that is expected to be unset
+			if len(tm.metricMins) > 0 && !math.IsNaN(tm.metricMins[i][index]) {
+				dp.SetMin(tm.metricMins[i][index])
+			}
+			if len(tm.metricMaxes) > 0 && !math.IsNaN(tm.metricMaxes[i][index]) {
+				dp.SetMax(tm.metricMaxes[i][index])
+			}
+			dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1, 10}))
+			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(tm.metricBuckets[i][index]))
+		}
+	}
+
+	return md
+}
+
 func BenchmarkConsumeMetrics(b *testing.B) {
 	c := consumertest.NewNop()
 	params := component.ProcessorCreateSettings{
@@ -15,6 +15,7 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -36,15 +37,29 @@ type Config struct {
 	// Default: 1s.
 	WaitDuration time.Duration `mapstructure:"wait_duration"`
 
-	// DiscardOrphans instructs the processor to discard traces without the root span.
-	// This typically indicates that the trace is incomplete.
+	// DiscardOrphans instructs the processor to keep track of the traces that have been released, so that
+	// spans arriving late for those traces are discarded instead of starting a new, incomplete, trace.
+	// Up to NumTraces released trace IDs are remembered.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
+	// LateSpansExporter is the ID of a traces exporter that should receive the late spans discarded
+	// because of DiscardOrphans. When not set, late spans are dropped.
+	LateSpansExporter string `mapstructure:"late_spans_exporter"`
+
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
+	// Requires a storage extension, such as the file_storage extension, to be configured in the service.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
 }
+
+var errLateSpansExporterWithoutDiscardOrphans = errors.New("the 'late_spans_exporter' option requires 'discard_orphans' to be enabled")
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.LateSpansExporter != "" && !cfg.DiscardOrphans {
+		return errLateSpansExporterWithoutDiscardOrphans
+	}
+	return nil
+}
@@ -41,6 +41,12 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// traceID recovered from the storage, to be released at its original deadline
+	traceRecovered
+
+	// traceID of a trace released before a restart, recovered from the storage
+	releasedTraceRecovered
 )
 
 var (
@@ -81,10 +87,13 @@ type eventMachine struct {
 
 	logger *zap.Logger
 
-	onTraceReceived func(td tracesWithID, worker *eventMachineWorker) error
-	onTraceExpired  func(traceID pcommon.TraceID, worker *eventMachineWorker) error
-	onTraceReleased func(rss []ptrace.ResourceSpans) error
-	onTraceRemoved  func(traceID pcommon.TraceID) error
+	onTraceReceived  func(td tracesWithID, worker *eventMachineWorker) error
+	onTraceExpired   func(traceID pcommon.TraceID, worker *eventMachineWorker) error
+	onTraceReleased  func(rss []ptrace.ResourceSpans) error
+	onTraceRemoved   func(traceID pcommon.TraceID) error
+	onTraceRecovered func(trace traceIDWithDeadline, worker *eventMachineWorker) error
+
+	onReleasedTraceRecovered func(traceID pcommon.TraceID, worker *eventMachineWorker) error
 
 	onError func(event)
 
@@ -214,6 +223,38 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceRecovered:
+		if em.onTraceRecovered == nil {
+			em.logger.Debug("onTraceRecovered not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(traceIDWithDeadline)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceRecovered", func() error {
+			return em.onTraceRecovered(payload, w)
+		})
+	case releasedTraceRecovered:
+		if em.onReleasedTraceRecovered == nil {
+			em.logger.Debug("onReleasedTraceRecovered not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(pcommon.TraceID)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onReleasedTraceRecovered", func() error {
+			return em.onReleasedTraceRecovered(payload, w)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -242,6 +283,32 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 	return nil
 }
 
+// recover routes a trace ID found in the storage to the worker responsible for it.
+func (em *eventMachine) recover(trace traceIDWithDeadline) {
+	var bucket uint64
+	if len(em.workers) != 1 {
+		bucket = workerIndexForTraceID(trace.id, len(em.workers))
+	}
+
+	em.workers[bucket].fire(event{
+		typ:     traceRecovered,
+		payload: trace,
+	})
+}
+
+// recoverReleased routes the ID of a trace released before a restart to the worker responsible for it.
+func (em *eventMachine) recoverReleased(traceID pcommon.TraceID) {
+	var bucket uint64
+	if len(em.workers) != 1 {
+		bucket = workerIndexForTraceID(traceID, len(em.workers))
+	}
+
+	em.workers[bucket].fire(event{
+		typ:     releasedTraceRecovered,
+		payload: traceID,
+	})
+}
+
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
 	hash := hashPool.Get().(*maphash.Hash)
 	defer func() {
@@ -318,6 +385,9 @@ type eventMachineWorker struct {
 	// the ring buffer holds the IDs for all the in-flight traces
 	buffer *ringBuffer
 
+	// the ring buffer holds the IDs for the traces released recently, when orphans are discarded
+	released *ringBuffer
+
 	events chan event
 }
 
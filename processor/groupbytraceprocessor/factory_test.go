@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
 
@@ -47,33 +48,46 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
-	// prepare
-	f := NewFactory()
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.StoreOnDisk = true
+
+	next := &mockProcessor{}
+
+	// test
+	p, err := createTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), c, next)
+
+	// verify
+	assert.NoError(t, err)
+	require.NotNil(t, p)
+	assert.IsType(t, &persistentStorage{}, p.(*groupByTraceProcessor).st)
+}
+
+func TestCreateTestProcessorWithDiscardOrphans(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.DiscardOrphans = true
+	c.NumWorkers = 2
+
 	next := &mockProcessor{}
 
 	// test
-	for _, tt := range []struct {
-		config      *Config
-		expectedErr error
-	}{
-		{
-			&Config{
-				DiscardOrphans: true,
-			},
-			errDiscardOrphansNotSupported,
-		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
-	} {
-		p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.config, next)
-
-		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
+	p, err := createTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), c, next)
+
+	// verify
+	assert.NoError(t, err)
+	require.NotNil(t, p)
+	for _, worker := range p.(*groupByTraceProcessor).eventMachine.workers {
+		assert.NotNil(t, worker.released)
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	assert.NoError(t, c.Validate())
+
+	c.LateSpansExporter = "otlp/late"
+	assert.ErrorIs(t, c.Validate(), errLateSpansExporterWithoutDiscardOrphans)
+
+	c.DiscardOrphans = true
+	assert.NoError(t, c.Validate())
+}
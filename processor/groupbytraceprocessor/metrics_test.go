@@ -29,6 +29,7 @@ func TestProcessorMetrics(t *testing.T) {
 		"processor/groupbytrace/processor_groupbytrace_spans_released",
 		"processor/groupbytrace/processor_groupbytrace_traces_released",
 		"processor/groupbytrace/processor_groupbytrace_incomplete_releases",
+		"processor/groupbytrace/processor_groupbytrace_spans_late",
 		"processor/groupbytrace/processor_groupbytrace_event_latency",
 	}
 
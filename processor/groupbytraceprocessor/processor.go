@@ -21,6 +21,7 @@ import (
 
 	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -52,12 +53,25 @@ type groupByTraceProcessor struct {
 
 	// the trace storage
 	st storage
+
+	// the exporter receiving spans for traces that have already been released, if any
+	lateSpansExporter component.TracesExporter
+
+	// the late spans are queued for a single goroutine sending them to the late spans exporter,
+	// so that the event workers are never blocked by it
+	lateSpans     chan ptrace.Traces
+	lateSpansStop chan struct{}
+	lateSpansDone chan struct{}
 }
 
 var _ component.TracesProcessor = (*groupByTraceProcessor)(nil)
 
 const bufferSize = 10_000
 
+// lateSpansQueueSize is the number of batches of late spans that can wait for the late spans exporter,
+// batches arriving once the queue is full are discarded
+const lateSpansQueueSize = 1_000
+
 // newGroupByTraceProcessor returns a new processor.
 func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consumer.Traces, config Config) *groupByTraceProcessor {
 	// the event machine will buffer up to N concurrent events before blocking
@@ -71,11 +85,19 @@ func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consu
 		st:           st,
 	}
 
+	if config.DiscardOrphans {
+		for _, worker := range eventMachine.workers {
+			worker.released = newRingBuffer(config.NumTraces / config.NumWorkers)
+		}
+	}
+
 	// register the callbacks
 	eventMachine.onTraceReceived = sp.onTraceReceived
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRecovered = sp.onTraceRecovered
+	eventMachine.onReleasedTraceRecovered = sp.onReleasedTraceRecovered
 
 	return sp
 }
@@ -93,24 +115,62 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
+	stats.Record(context.Background(), mLateSpans.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if sp.config.LateSpansExporter != "" {
+		exporter, err := findTracesExporter(host, sp.config.LateSpansExporter)
+		if err != nil {
+			return err
+		}
+		sp.lateSpansExporter = exporter
+		sp.lateSpans = make(chan ptrace.Traces, lateSpansQueueSize)
+		sp.lateSpansStop = make(chan struct{})
+		sp.lateSpansDone = make(chan struct{})
+		go sp.exportLateSpans()
+	}
+
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	// traces kept by a persistent storage are scheduled to be released at their original deadlines
+	if rst, ok := sp.st.(recoverableStorage); ok {
+		for _, trace := range rst.recovered() {
+			sp.eventMachine.recover(trace)
+		}
+		// as well as the traces released before the restart, so that their late spans are still discarded
+		for _, traceID := range rst.released() {
+			sp.eventMachine.recoverReleased(traceID)
+		}
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
 func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
 	sp.eventMachine.shutdown()
+	if sp.lateSpansStop != nil {
+		close(sp.lateSpansStop)
+		<-sp.lateSpansDone
+		sp.lateSpansStop = nil
+	}
 	return sp.st.shutdown()
 }
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
 	traceID := trace.id
+	if worker.released != nil && worker.released.contains(traceID) {
+		// the trace has been released already, so these spans arrived too late
+		sp.onLateSpans(traceID, trace.td)
+		return nil
+	}
+
 	if worker.buffer.contains(traceID) {
 		sp.logger.Debug("trace is already in memory storage")
 
@@ -158,6 +218,48 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	return nil
 }
 
+func (sp *groupByTraceProcessor) onTraceRecovered(trace traceIDWithDeadline, worker *eventMachineWorker) error {
+	traceID := trace.id
+	if worker.buffer.contains(traceID) {
+		// spans for this trace arrived before the recovery took place, and its release is already scheduled
+		return nil
+	}
+
+	evicted := worker.buffer.put(traceID)
+	if !evicted.IsEmpty() {
+		// delete from the storage
+		worker.fire(event{
+			typ:     traceRemoved,
+			payload: evicted,
+		})
+
+		stats.Record(context.Background(), mTracesEvicted.M(1))
+
+		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
+			zap.String("traceID", evicted.HexString()))
+	}
+
+	// traces whose deadline passed while the processor was down are released right away
+	wait := time.Until(trace.deadline)
+	sp.logger.Debug("scheduled to release recovered trace", zap.Duration("duration", wait))
+
+	time.AfterFunc(wait, func() {
+		// if the event machine has stopped, it will just discard the event
+		worker.fire(event{
+			typ:     traceExpired,
+			payload: traceID,
+		})
+	})
+	return nil
+}
+
+func (sp *groupByTraceProcessor) onReleasedTraceRecovered(traceID pcommon.TraceID, worker *eventMachineWorker) error {
+	if worker.released != nil && !worker.released.contains(traceID) {
+		worker.released.put(traceID)
+	}
+	return nil
+}
+
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
 	sp.logger.Debug("processing expired", zap.String("traceID",
 		traceID.HexString()))
@@ -175,6 +277,11 @@ func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker
 	// delete from the map and erase its memory entry
 	worker.buffer.delete(traceID)
 
+	// remember the trace, so that spans arriving after the release can be told apart
+	if worker.released != nil {
+		worker.released.put(traceID)
+	}
+
 	// this might block, but we don't need to wait
 	sp.logger.Debug("marking the trace as released",
 		zap.String("traceID", traceID.HexString()))
@@ -196,6 +303,13 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 		return fmt.Errorf("the trace %q couldn't be found at the storage", traceID)
 	}
 
+	// keep the released trace ID along with the traces, so that late spans are still discarded after a restart
+	if rst, ok := sp.st.(recoverableStorage); ok && sp.config.DiscardOrphans {
+		if err := rst.markReleased(traceID); err != nil {
+			sp.logger.Warn("couldn't record the trace as released", zap.Error(err))
+		}
+	}
+
 	// signal that the trace is ready to be released
 	sp.logger.Debug("trace marked as released", zap.String("traceID", traceID.HexString()))
 
@@ -231,6 +345,50 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) err
 	return nil
 }
 
+func (sp *groupByTraceProcessor) onLateSpans(traceID pcommon.TraceID, td ptrace.Traces) {
+	stats.Record(context.Background(), mLateSpans.M(int64(td.SpanCount())))
+
+	if sp.lateSpansExporter == nil {
+		sp.logger.Debug("discarding spans for a trace that has been released already",
+			zap.String("traceID", traceID.HexString()))
+		return
+	}
+
+	select {
+	case sp.lateSpans <- td:
+	default:
+		sp.logger.Warn("the late spans queue is full, discarding spans for a trace that has been released already",
+			zap.String("traceID", traceID.HexString()))
+	}
+}
+
+// exportLateSpans sends the queued late spans to the late spans exporter until the processor is shut down,
+// flushing the spans still in the queue at that point
+func (sp *groupByTraceProcessor) exportLateSpans() {
+	defer close(sp.lateSpansDone)
+	for {
+		select {
+		case td := <-sp.lateSpans:
+			sp.consumeLateSpans(td)
+		case <-sp.lateSpansStop:
+			for {
+				select {
+				case td := <-sp.lateSpans:
+					sp.consumeLateSpans(td)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (sp *groupByTraceProcessor) consumeLateSpans(td ptrace.Traces) {
+	if err := sp.lateSpansExporter.ConsumeTraces(context.Background(), td); err != nil {
+		sp.logger.Error("consume of late spans failed", zap.Error(err))
+	}
+}
+
 func (sp *groupByTraceProcessor) onTraceRemoved(traceID pcommon.TraceID) error {
 	trace, err := sp.st.delete(traceID)
 	if err != nil {
@@ -248,3 +406,18 @@ func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.
 	sp.logger.Debug("creating trace at the storage", zap.String("traceID", traceID.HexString()))
 	return sp.st.createOrAppend(traceID, trace)
 }
+
+// findTracesExporter returns the traces exporter with the given ID from the host
+func findTracesExporter(host component.Host, id string) (component.TracesExporter, error) {
+	for compID, exp := range host.GetExporters()[config.TracesDataType] {
+		if compID.String() != id {
+			continue
+		}
+		tExp, ok := exp.(component.TracesExporter)
+		if !ok {
+			return nil, fmt.Errorf("the exporter %q isn't a traces exporter", id)
+		}
+		return tExp, nil
+	}
+	return nil, fmt.Errorf("the late spans exporter %q couldn't be found", id)
+}
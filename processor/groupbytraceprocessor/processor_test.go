@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -144,6 +146,156 @@ func TestInternalCacheLimit(t *testing.T) {
 	assert.NotContains(t, receivedTraceIDs, traceIDs[0])
 }
 
+func TestLateSpansAreDiscarded(t *testing.T) {
+	// prepare
+	cfg := Config{
+		WaitDuration:   time.Millisecond,
+		NumTraces:      10,
+		NumWorkers:     1,
+		DiscardOrphans: true,
+	}
+
+	released := make(chan ptrace.Traces, 2)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			released <- td
+			return nil
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), next, cfg)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(traceID)))
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the trace wasn't released")
+	}
+
+	// test
+	require.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(traceID)))
+
+	// verify
+	select {
+	case <-released:
+		t.Fatal("late spans shouldn't start a new trace")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.True(t, p.eventMachine.workers[0].released.contains(traceID))
+	assert.False(t, p.eventMachine.workers[0].buffer.contains(traceID))
+}
+
+func TestLateSpansAreSentToExporter(t *testing.T) {
+	// prepare
+	cfg := Config{
+		WaitDuration:      time.Millisecond,
+		NumTraces:         10,
+		NumWorkers:        1,
+		DiscardOrphans:    true,
+		LateSpansExporter: "otlp/late",
+	}
+
+	released := make(chan ptrace.Traces, 2)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			released <- td
+			return nil
+		},
+	}
+	late := make(chan ptrace.Traces, 1)
+	lateExporter := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			late <- td
+			return nil
+		},
+	}
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {
+				config.NewComponentIDWithName("otlp", "late"): lateExporter,
+			},
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), next, cfg)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(traceID)))
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the trace wasn't released")
+	}
+
+	// test
+	lateSpans := simpleTracesWithID(traceID)
+	lateSpans.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("late-span")
+	require.NoError(t, p.ConsumeTraces(ctx, lateSpans))
+
+	// verify
+	select {
+	case td := <-late:
+		assert.Equal(t, "late-span", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the late spans weren't sent to the late spans exporter")
+	}
+	assert.Len(t, released, 0)
+}
+
+func TestLateSpansAreDiscardedWhenQueueIsFull(t *testing.T) {
+	// prepare
+	cfg := Config{
+		WaitDuration:      time.Millisecond,
+		NumTraces:         10,
+		NumWorkers:        1,
+		DiscardOrphans:    true,
+		LateSpansExporter: "otlp/late",
+	}
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), &mockProcessor{}, cfg)
+	p.lateSpansExporter = &mockProcessor{}
+	p.lateSpans = make(chan ptrace.Traces, 1)
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+
+	// test
+	p.onLateSpans(traceID, first)
+	p.onLateSpans(traceID, simpleTracesWithID(traceID))
+
+	// verify
+	require.Len(t, p.lateSpans, 1)
+	assert.Equal(t, first, <-p.lateSpans)
+}
+
+func TestLateSpansExporterNotFound(t *testing.T) {
+	cfg := Config{
+		WaitDuration:      time.Millisecond,
+		NumTraces:         10,
+		NumWorkers:        1,
+		DiscardOrphans:    true,
+		LateSpansExporter: "otlp/late",
+	}
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), &mockProcessor{}, cfg)
+
+	// test
+	err := p.Start(context.Background(), &mockHost{Host: componenttest.NewNopHost()})
+
+	// verify
+	assert.Error(t, err)
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := Config{
@@ -612,6 +764,15 @@ func (m *mockProcessor) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
+type mockHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *mockHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
 type mockStorage struct {
 	onCreateOrAppend func(pcommon.TraceID, ptrace.Traces) error
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
@@ -640,7 +801,7 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
@@ -15,6 +15,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -36,8 +40,29 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// recoverableStorage is implemented by storages able to keep traces across restarts
+type recoverableStorage interface {
+	storage
+
+	// recovered returns the traces found in the storage during start, along with their
+	// original deadlines, so that they can be scheduled for release again
+	recovered() []traceIDWithDeadline
+
+	// markReleased records that the trace with the given ID has been released, so that the
+	// spans arriving late for it can be recognized after a restart
+	markReleased(pcommon.TraceID) error
+
+	// released returns the IDs of the released traces found in the storage during start, oldest first
+	released() []pcommon.TraceID
+}
+
+type traceIDWithDeadline struct {
+	id       pcommon.TraceID
+	deadline time.Time
+}
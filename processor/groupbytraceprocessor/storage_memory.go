@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -85,7 +86,7 @@ func (st *memoryStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans
 	return st.content[traceID], nil
 }
 
-func (st *memoryStorage) start() error {
+func (st *memoryStorage) start(context.Context, component.Host) error {
 	go st.periodicMetrics()
 	return nil
 }
@@ -0,0 +1,546 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// metadataKey holds the range of sequence numbers that might still have a trace in the storage
+	metadataKey = "metadata"
+
+	// releasedKey holds the number of trace IDs recorded as released so far
+	releasedKey = "released"
+
+	// the first record of a trace starts with the trace ID and its deadline, followed by the OTLP-encoded
+	// spans, while the batches appended later only hold their OTLP-encoded spans
+	recordHeaderSize = 16 + 8
+)
+
+var (
+	errNoStorageExtension        = errors.New("the 'store_on_disk' option requires a storage extension, but none was found")
+	errMultipleStorageExtensions = errors.New("multiple storage extensions found")
+	errInvalidRecord             = errors.New("invalid trace record found in the storage")
+	errInvalidReleasedRecord     = errors.New("invalid released trace record found in the storage")
+)
+
+// persistentStorage keeps the spans in a storage extension, holding only the trace IDs and their
+// deadlines in memory. Each trace gets a sequence number once it's created, which is used as its key
+// in the storage. Spans appended to an existing trace are stored as a new batch, under the key of the
+// trace followed by the number of the batch, and are merged with the other batches once the trace
+// is released. The metadata record keeps track of the range of sequence numbers in use, so that the
+// traces can be found again after a restart.
+//
+// When orphans are discarded, the IDs of the released traces are kept as well, in a ring of numReleased
+// records, so that spans arriving late for those traces are still recognized after a restart.
+//
+// The storage lock only guards the index and the metadata: reading and writing the batches of a trace
+// is guarded by the lock of its entry, so that workers handling different traces don't wait on each other.
+type persistentStorage struct {
+	sync.Mutex
+	id           config.ComponentID
+	logger       *zap.Logger
+	waitDuration time.Duration
+	client       extstorage.Client
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	// index holds the sequence number, deadline and number of batches for every trace in the storage
+	index map[pcommon.TraceID]*persistentEntry
+	// inUse holds the sequence numbers currently assigned to traces
+	inUse map[uint64]struct{}
+	// first is the lowest sequence number that might be in use, next is the one to be assigned next
+	first, next uint64
+
+	// traces found in the storage during start
+	pending []traceIDWithDeadline
+
+	// numReleased is the number of released trace IDs to keep, releasedNext the number of IDs recorded so far
+	numReleased  int
+	releasedNext uint64
+	// released trace IDs found in the storage during start, oldest first
+	releasedPending []pcommon.TraceID
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+type persistentEntry struct {
+	sync.Mutex
+	seq      uint64
+	deadline time.Time
+	// batches is the number of batches stored for the trace, including the first record
+	batches uint64
+	// deleted is set once the trace has been removed from the index and its records deleted
+	deleted bool
+}
+
+var _ recoverableStorage = (*persistentStorage)(nil)
+
+// newPersistentStorage returns a storage backed by a storage extension. When numReleased is greater than zero,
+// up to that many released trace IDs are kept in the storage as well.
+func newPersistentStorage(id config.ComponentID, logger *zap.Logger, waitDuration time.Duration, numReleased int) *persistentStorage {
+	return &persistentStorage{
+		id:                        id,
+		logger:                    logger,
+		waitDuration:              waitDuration,
+		numReleased:               numReleased,
+		marshaler:                 ptrace.NewProtoMarshaler(),
+		unmarshaler:               ptrace.NewProtoUnmarshaler(),
+		index:                     make(map[pcommon.TraceID]*persistentEntry),
+		inUse:                     make(map[uint64]struct{}),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *persistentStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	for {
+		st.Lock()
+		entry, ok := st.index[traceID]
+		if !ok {
+			err := st.create(traceID, td)
+			st.Unlock()
+			return err
+		}
+		st.Unlock()
+
+		appended, err := st.append(entry, traceID, td)
+		if appended || err != nil {
+			return err
+		}
+		// the trace was deleted while we were waiting for its lock, so it has to be created again
+	}
+}
+
+// create stores a new trace, along with the updated metadata. It must be called with the storage lock held.
+func (st *persistentStorage) create(traceID pcommon.TraceID, td ptrace.Traces) error {
+	entry := &persistentEntry{seq: st.next, deadline: time.Now().Add(st.waitDuration), batches: 1}
+	value, err := st.encode(traceID, entry.deadline, td)
+	if err != nil {
+		return err
+	}
+
+	// the metadata is stored along with the trace, so that a restart will know about it
+	err = st.client.Batch(context.Background(),
+		extstorage.SetOperation(traceKey(entry.seq), value),
+		extstorage.SetOperation(metadataKey, encodeMetadata(st.first, st.next+1)),
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't store trace %q: %w", traceID.HexString(), err)
+	}
+
+	st.next++
+	st.index[traceID] = entry
+	st.inUse[entry.seq] = struct{}{}
+	return nil
+}
+
+// append stores the spans as a new batch of the given trace. It returns false if the trace was deleted
+// in the meantime.
+func (st *persistentStorage) append(entry *persistentEntry, traceID pcommon.TraceID, td ptrace.Traces) (bool, error) {
+	entry.Lock()
+	defer entry.Unlock()
+
+	if entry.deleted {
+		return false, nil
+	}
+
+	value, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return false, fmt.Errorf("couldn't encode trace %q: %w", traceID.HexString(), err)
+	}
+	if err := st.client.Set(context.Background(), batchKey(entry.seq, entry.batches), value); err != nil {
+		return false, fmt.Errorf("couldn't append to trace %q: %w", traceID.HexString(), err)
+	}
+	entry.batches++
+	return true, nil
+}
+
+func (st *persistentStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	entry, ok := st.index[traceID]
+	st.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	entry.Lock()
+	defer entry.Unlock()
+	if entry.deleted {
+		return nil, nil
+	}
+
+	td, found, err := st.read(context.Background(), entry)
+	if err != nil || !found {
+		return nil, err
+	}
+	return resourceSpans(td), nil
+}
+
+func (st *persistentStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	entry, ok := st.index[traceID]
+	st.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	entry.Lock()
+	defer entry.Unlock()
+	if entry.deleted {
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	td, found, err := st.read(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]extstorage.Operation, 0, entry.batches+1)
+	ops = append(ops, extstorage.DeleteOperation(traceKey(entry.seq)))
+	for n := uint64(1); n < entry.batches; n++ {
+		ops = append(ops, extstorage.DeleteOperation(batchKey(entry.seq, n)))
+	}
+
+	// the metadata is written with the storage lock held, so that it's never overwritten by an older version
+	st.Lock()
+	defer st.Unlock()
+
+	delete(st.inUse, entry.seq)
+	first := st.first
+	for first < st.next {
+		if _, ok := st.inUse[first]; ok {
+			break
+		}
+		first++
+	}
+
+	ops = append(ops, extstorage.SetOperation(metadataKey, encodeMetadata(first, st.next)))
+	if err = st.client.Batch(ctx, ops...); err != nil {
+		st.inUse[entry.seq] = struct{}{}
+		return nil, fmt.Errorf("couldn't delete trace %q: %w", traceID.HexString(), err)
+	}
+
+	st.first = first
+	delete(st.index, traceID)
+	entry.deleted = true
+
+	if !found {
+		return nil, nil
+	}
+	return resourceSpans(td), nil
+}
+
+func (st *persistentStorage) start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, st.id, host)
+	if err != nil {
+		return err
+	}
+	st.client = client
+
+	if err := st.load(ctx); err != nil {
+		return err
+	}
+
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *persistentStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	if st.client == nil {
+		return nil
+	}
+	return st.client.Close(context.Background())
+}
+
+func (st *persistentStorage) recovered() []traceIDWithDeadline {
+	return st.pending
+}
+
+func (st *persistentStorage) markReleased(traceID pcommon.TraceID) error {
+	if st.numReleased <= 0 {
+		return nil
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	id := traceID.Bytes()
+	err := st.client.Batch(context.Background(),
+		extstorage.SetOperation(releasedTraceKey(st.releasedNext%uint64(st.numReleased)), id[:]),
+		extstorage.SetOperation(releasedKey, encodeReleased(st.releasedNext+1)),
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't record trace %q as released: %w", traceID.HexString(), err)
+	}
+	st.releasedNext++
+	return nil
+}
+
+func (st *persistentStorage) released() []pcommon.TraceID {
+	return st.releasedPending
+}
+
+// load rebuilds the in-memory index based on the records available in the storage
+func (st *persistentStorage) load(ctx context.Context) error {
+	st.Lock()
+	defer st.Unlock()
+
+	if err := st.loadReleased(ctx); err != nil {
+		return err
+	}
+
+	metadata, err := st.client.Get(ctx, metadataKey)
+	if err != nil {
+		return fmt.Errorf("couldn't read the storage metadata: %w", err)
+	}
+	if metadata == nil {
+		return nil
+	}
+	if len(metadata) != 16 {
+		return fmt.Errorf("invalid storage metadata with %d bytes", len(metadata))
+	}
+	st.first = binary.BigEndian.Uint64(metadata[:8])
+	st.next = binary.BigEndian.Uint64(metadata[8:])
+
+	for seq := st.first; seq < st.next; seq++ {
+		value, err := st.client.Get(ctx, traceKey(seq))
+		if err != nil {
+			return fmt.Errorf("couldn't read trace from the storage: %w", err)
+		}
+		if value == nil {
+			continue
+		}
+		if len(value) < recordHeaderSize {
+			st.logger.Warn("skipping invalid trace record", zap.Uint64("sequence", seq))
+			continue
+		}
+
+		batches := uint64(1)
+		for {
+			batch, err := st.client.Get(ctx, batchKey(seq, batches))
+			if err != nil {
+				return fmt.Errorf("couldn't read trace from the storage: %w", err)
+			}
+			if batch == nil {
+				break
+			}
+			batches++
+		}
+
+		traceID, deadline := decodeHeader(value)
+		st.index[traceID] = &persistentEntry{seq: seq, deadline: deadline, batches: batches}
+		st.inUse[seq] = struct{}{}
+		st.pending = append(st.pending, traceIDWithDeadline{id: traceID, deadline: deadline})
+	}
+
+	st.logger.Info("recovered traces from the storage", zap.Int("traces", len(st.pending)))
+	return nil
+}
+
+// loadReleased reads the released trace IDs kept in the storage. It must be called with the storage lock held.
+func (st *persistentStorage) loadReleased(ctx context.Context) error {
+	if st.numReleased <= 0 {
+		return nil
+	}
+
+	value, err := st.client.Get(ctx, releasedKey)
+	if err != nil {
+		return fmt.Errorf("couldn't read the released traces from the storage: %w", err)
+	}
+	if value == nil {
+		return nil
+	}
+	if len(value) != 8 {
+		return errInvalidReleasedRecord
+	}
+	st.releasedNext = binary.BigEndian.Uint64(value)
+
+	// the number of IDs to keep might have changed since they were recorded
+	first := uint64(0)
+	if st.releasedNext > uint64(st.numReleased) {
+		first = st.releasedNext - uint64(st.numReleased)
+	}
+	for n := first; n < st.releasedNext; n++ {
+		value, err := st.client.Get(ctx, releasedTraceKey(n%uint64(st.numReleased)))
+		if err != nil {
+			return fmt.Errorf("couldn't read the released traces from the storage: %w", err)
+		}
+		if len(value) != 16 {
+			continue
+		}
+		var id [16]byte
+		copy(id[:], value)
+		st.releasedPending = append(st.releasedPending, pcommon.NewTraceID(id))
+	}
+
+	st.logger.Info("recovered released trace IDs from the storage", zap.Int("traces", len(st.releasedPending)))
+	return nil
+}
+
+// read returns the trace of the given entry, merging all of its batches, and whether it was found
+func (st *persistentStorage) read(ctx context.Context, entry *persistentEntry) (ptrace.Traces, bool, error) {
+	value, err := st.client.Get(ctx, traceKey(entry.seq))
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("couldn't read trace from the storage: %w", err)
+	}
+	if value == nil {
+		return ptrace.Traces{}, false, nil
+	}
+	if len(value) < recordHeaderSize {
+		return ptrace.Traces{}, false, errInvalidRecord
+	}
+
+	td, err := st.unmarshaler.UnmarshalTraces(value[recordHeaderSize:])
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("couldn't decode trace from the storage: %w", err)
+	}
+
+	for n := uint64(1); n < entry.batches; n++ {
+		value, err := st.client.Get(ctx, batchKey(entry.seq, n))
+		if err != nil {
+			return ptrace.Traces{}, false, fmt.Errorf("couldn't read trace from the storage: %w", err)
+		}
+		if value == nil {
+			continue
+		}
+
+		batch, err := st.unmarshaler.UnmarshalTraces(value)
+		if err != nil {
+			return ptrace.Traces{}, false, fmt.Errorf("couldn't decode trace from the storage: %w", err)
+		}
+		batch.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	return td, true, nil
+}
+
+func (st *persistentStorage) encode(traceID pcommon.TraceID, deadline time.Time, td ptrace.Traces) ([]byte, error) {
+	spans, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode trace %q: %w", traceID.HexString(), err)
+	}
+
+	value := make([]byte, recordHeaderSize, recordHeaderSize+len(spans))
+	id := traceID.Bytes()
+	copy(value, id[:])
+	binary.BigEndian.PutUint64(value[16:], uint64(deadline.UnixNano()))
+	return append(value, spans...), nil
+}
+
+func (st *persistentStorage) periodicMetrics() {
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(st.count())))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *persistentStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	return len(st.index)
+}
+
+// getStorageClient returns a client from the single storage extension available in the host
+func getStorageClient(ctx context.Context, id config.ComponentID, host component.Host) (extstorage.Client, error) {
+	var storageExtension extstorage.Extension
+	if host != nil {
+		for _, ext := range host.GetExtensions() {
+			if se, ok := ext.(extstorage.Extension); ok {
+				if storageExtension != nil {
+					return nil, errMultipleStorageExtensions
+				}
+				storageExtension = se
+			}
+		}
+	}
+
+	if storageExtension == nil {
+		return nil, errNoStorageExtension
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, id, "")
+}
+
+func traceKey(seq uint64) string {
+	return fmt.Sprintf("trace_%d", seq)
+}
+
+// batchKey returns the key of a batch appended to the trace with the given sequence number, counting
+// from 1, as the first batch is stored under the trace key
+func batchKey(seq, n uint64) string {
+	return fmt.Sprintf("%s/%d", traceKey(seq), n)
+}
+
+func releasedTraceKey(n uint64) string {
+	return fmt.Sprintf("%s_%d", releasedKey, n)
+}
+
+func encodeReleased(next uint64) []byte {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, next)
+	return value
+}
+
+func encodeMetadata(first, next uint64) []byte {
+	metadata := make([]byte, 16)
+	binary.BigEndian.PutUint64(metadata[:8], first)
+	binary.BigEndian.PutUint64(metadata[8:], next)
+	return metadata
+}
+
+func decodeHeader(value []byte) (pcommon.TraceID, time.Time) {
+	var id [16]byte
+	copy(id[:], value[:16])
+	deadline := int64(binary.BigEndian.Uint64(value[16:recordHeaderSize]))
+	return pcommon.NewTraceID(id), time.Unix(0, deadline)
+}
+
+func resourceSpans(td ptrace.Traces) []ptrace.ResourceSpans {
+	rss := td.ResourceSpans()
+	result := make([]ptrace.ResourceSpans, 0, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		result = append(result, rss.At(i))
+	}
+	return result
+}
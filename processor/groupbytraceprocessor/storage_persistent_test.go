@@ -0,0 +1,382 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestPersistentStorage(t *testing.T, host component.Host) *persistentStorage {
+	st := newPersistentStorage(config.NewComponentID(typeStr), zap.NewNop(), time.Minute, 0)
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func TestPersistentCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.NewTraceID([16]byte{1, 2, 3, 4}),
+		pcommon.NewTraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 1)
+		assert.Equal(t, traceID, retrieved[0].ScopeSpans().At(0).Spans().At(0).TraceID())
+	}
+}
+
+func TestPersistentAppendToTrace(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+	assert.Equal(t, 1, st.count())
+}
+
+func TestPersistentDeleteTrace(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+	assert.Equal(t, 0, st.count())
+
+	// deleting an unknown trace isn't an error
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestPersistentRecoverTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.NewTraceID([16]byte{1, 2, 3, 4}),
+		pcommon.NewTraceID([16]byte{2, 3, 4, 5}),
+		pcommon.NewTraceID([16]byte{3, 4, 5, 6}),
+	}
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	_, err := st.delete(traceIDs[0])
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	// verify
+	recovered := st.recovered()
+	require.Len(t, recovered, 2)
+	assert.Equal(t, traceIDs[1], recovered[0].id)
+	assert.Equal(t, traceIDs[2], recovered[1].id)
+	for _, trace := range recovered {
+		assert.WithinDuration(t, time.Now().Add(time.Minute), trace.deadline, 10*time.Second)
+
+		retrieved, err := st.get(trace.id)
+		require.NoError(t, err)
+		assert.Len(t, retrieved, 1)
+	}
+
+	// new traces don't overwrite the recovered ones
+	traceID := pcommon.NewTraceID([16]byte{4, 5, 6, 7})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	assert.Equal(t, 3, st.count())
+}
+
+func TestPersistentRecoverReleasedTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	st := newPersistentStorage(config.NewComponentID(typeStr), zap.NewNop(), time.Minute, 2)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.NewTraceID([16]byte{1, 2, 3, 4}),
+		pcommon.NewTraceID([16]byte{2, 3, 4, 5}),
+		pcommon.NewTraceID([16]byte{3, 4, 5, 6}),
+	}
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.markReleased(traceID))
+	}
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newPersistentStorage(config.NewComponentID(typeStr), zap.NewNop(), time.Minute, 2)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	// verify
+	assert.Equal(t, traceIDs[1:], st.released())
+	assert.Empty(t, st.recovered())
+
+	// new released traces overwrite the oldest ones
+	traceID := pcommon.NewTraceID([16]byte{4, 5, 6, 7})
+	require.NoError(t, st.markReleased(traceID))
+	assert.Equal(t, uint64(4), st.releasedNext)
+}
+
+func TestPersistentReleasedTracesNotKept(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+	require.NoError(t, st.markReleased(pcommon.NewTraceID([16]byte{1, 2, 3, 4})))
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	// verify
+	assert.Empty(t, st.released())
+}
+
+func TestPersistentRecoverAppendedBatches(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	deleted, err := st.delete(traceID)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// verify
+	assert.Len(t, deleted, 4)
+
+	st = newTestPersistentStorage(t, storagetest.NewStorageHost(t, dir, "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+	assert.Empty(t, st.recovered())
+
+	// a new trace with the same ID doesn't get the batches of the deleted one
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestPersistentConcurrentAppends(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost(t, t.TempDir(), "test"))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.NewTraceID([16]byte{1, 2, 3, 4}),
+		pcommon.NewTraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		for _, traceID := range traceIDs {
+			wg.Add(1)
+			go func(traceID pcommon.TraceID) {
+				defer wg.Done()
+				assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+			}(traceID)
+		}
+	}
+	wg.Wait()
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		deleted, err := st.delete(traceID)
+		require.NoError(t, err)
+		assert.Len(t, deleted, 10)
+	}
+}
+
+func TestPersistentStartWithoutStorageExtension(t *testing.T) {
+	st := newPersistentStorage(config.NewComponentID(typeStr), zap.NewNop(), time.Minute, 0)
+
+	err := st.start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, errNoStorageExtension)
+	assert.NoError(t, st.shutdown())
+}
+
+func TestPersistentStartWithMultipleStorageExtensions(t *testing.T) {
+	st := newPersistentStorage(config.NewComponentID(typeStr), zap.NewNop(), time.Minute, 0)
+
+	err := st.start(context.Background(), storagetest.NewStorageHost(t, t.TempDir(), "first", "second"))
+	assert.ErrorIs(t, err, errMultipleStorageExtensions)
+}
+
+func TestProcessorReleasesRecoveredTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		WaitDuration:      time.Hour,
+		NumTraces:         10,
+		NumWorkers:        1,
+		StoreOnDisk:       true,
+	}
+
+	st := newPersistentStorage(cfg.ID(), zap.NewNop(), cfg.WaitDuration, 0)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, cfg)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	require.Eventually(t, func() bool {
+		return st.count() == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// test
+	released := make(chan ptrace.Traces, 1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			released <- td
+			return nil
+		},
+	}
+	cfg.WaitDuration = 10 * time.Millisecond
+	st = newPersistentStorage(cfg.ID(), zap.NewNop(), cfg.WaitDuration, 0)
+	st.metricsCollectionInterval = 10 * time.Millisecond
+	p = newGroupByTraceProcessor(zap.NewNop(), st, next, cfg)
+
+	// the recovered trace keeps its original deadline, so force it to expire
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
+	require.Len(t, st.recovered(), 1)
+	p.eventMachine.workers[0].fire(event{
+		typ:     traceExpired,
+		payload: traceID,
+	})
+
+	// verify
+	select {
+	case td := <-released:
+		assert.Equal(t, traceID, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the recovered trace wasn't released")
+	}
+	assert.Eventually(t, func() bool {
+		return st.count() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProcessorDiscardsLateSpansAfterRestart(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4})
+
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		WaitDuration:      time.Millisecond,
+		NumTraces:         10,
+		NumWorkers:        1,
+		DiscardOrphans:    true,
+		StoreOnDisk:       true,
+	}
+
+	released := make(chan ptrace.Traces, 2)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			released <- td
+			return nil
+		},
+	}
+
+	st := newPersistentStorage(cfg.ID(), zap.NewNop(), cfg.WaitDuration, cfg.NumTraces)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, next, cfg)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the trace wasn't released")
+	}
+	require.Eventually(t, func() bool {
+		return st.count() == 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// test
+	st = newPersistentStorage(cfg.ID(), zap.NewNop(), cfg.WaitDuration, cfg.NumTraces)
+	p = newGroupByTraceProcessor(zap.NewNop(), st, next, cfg)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+	// verify
+	select {
+	case <-released:
+		t.Fatal("late spans shouldn't start a new trace after a restart")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, []pcommon.TraceID{traceID}, st.released())
+	assert.Equal(t, 0, st.count())
+}
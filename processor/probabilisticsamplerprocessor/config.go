@@ -30,6 +30,16 @@ type Config struct {
 	// have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
+
+	// FromAttribute (logs only) is the name of a log record attribute, e.g. a request ID, whose value is hashed to
+	// make the sampling decision for log records that do not carry a trace ID. Log records without a trace ID and
+	// without this attribute are sampled randomly at the configured sampling percentage.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// SamplingPriority (logs only) is the name of a log record attribute holding a per-record sampling priority.
+	// It follows the "sampling.priority" semantics used for spans: a value of zero drops the log record and a value
+	// greater than zero always samples it. Defaults to empty, i.e.: the priority is not taken into account.
+	SamplingPriority string `mapstructure:"sampling_priority"`
 }
 
 var _ config.Processor = (*Config)(nil)
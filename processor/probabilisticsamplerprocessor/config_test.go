@@ -43,6 +43,14 @@ func TestLoadConfig(t *testing.T) {
 			HashSeed:           22,
 		})
 
+	p1 := cfg.Processors[config.NewComponentIDWithName(typeStr, "logs")]
+	assert.Equal(t, p1,
+		&Config{
+			ProcessorSettings:  config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "logs")),
+			SamplingPercentage: 15.3,
+			FromAttribute:      "request.id",
+			SamplingPriority:   "priority",
+		})
 }
 
 func TestLoadConfigEmpty(t *testing.T) {
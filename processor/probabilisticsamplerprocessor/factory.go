@@ -27,6 +27,8 @@ const (
 	typeStr = "probabilistic_sampler"
 	// The stability level of the processor.
 	stability = component.StabilityLevelBeta
+	// The stability level of the processor for logs.
+	logsStability = component.StabilityLevelAlpha
 )
 
 // NewFactory returns a new factory for the Probabilistic sampler processor.
@@ -34,7 +36,8 @@ func NewFactory() component.ProcessorFactory {
 	return component.NewProcessorFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithTracesProcessorAndStabilityLevel(createTracesProcessor, stability))
+		component.WithTracesProcessorAndStabilityLevel(createTracesProcessor, stability),
+		component.WithLogsProcessorAndStabilityLevel(createLogsProcessor, logsStability))
 }
 
 func createDefaultConfig() config.Processor {
@@ -52,3 +55,13 @@ func createTracesProcessor(
 ) (component.TracesProcessor, error) {
 	return newTracesProcessor(nextConsumer, cfg.(*Config))
 }
+
+// createLogsProcessor creates a log processor based on this config.
+func createLogsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	return newLogsProcessor(nextConsumer, cfg.(*Config))
+}
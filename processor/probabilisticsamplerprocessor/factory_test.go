@@ -36,4 +36,7 @@ func TestCreateProcessor(t *testing.T) {
 	tp, err := createTracesProcessor(context.Background(), set, cfg, consumertest.NewNop())
 	assert.NotNil(t, tp)
 	assert.NoError(t, err, "cannot create trace processor")
+	lp, err := createLogsProcessor(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NotNil(t, lp)
+	assert.NoError(t, err, "cannot create logs processor")
 }
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"math/rand"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type logsamplerprocessor struct {
+	scaledSamplingRate uint32
+	hashSeed           uint32
+	fromAttribute      string
+	samplingPriority   string
+}
+
+// newLogsProcessor returns a processor.LogsProcessor that will perform head sampling according to the given
+// configuration.
+func newLogsProcessor(nextConsumer consumer.Logs, cfg *Config) (component.LogsProcessor, error) {
+	lsp := &logsamplerprocessor{
+		// Adjust sampling percentage on private so recalculations are avoided.
+		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
+		hashSeed:           cfg.HashSeed,
+		fromAttribute:      cfg.FromAttribute,
+		samplingPriority:   cfg.SamplingPriority,
+	}
+
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		lsp.processLogs,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+func (lsp *logsamplerprocessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(ill plog.ScopeLogs) bool {
+			ill.LogRecords().RemoveIf(func(l plog.LogRecord) bool {
+				if lsp.samplingPriority != "" {
+					switch parseSamplingPriority(l.Attributes(), lsp.samplingPriority) {
+					case doNotSampleSpan:
+						return true
+					case mustSampleSpan:
+						return false
+					}
+				}
+				return lsp.bucket(l) >= lsp.scaledSamplingRate
+			})
+			// Filter out empty ScopeLogs
+			return ill.LogRecords().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// bucket returns the hash bucket of the log record. Log records that belong to a trace are hashed on their trace ID,
+// so that they are sampled consistently with the spans of that trace when both use the same hash seed. Otherwise the
+// value of the configured attribute is hashed, and if that is missing too the bucket is picked randomly.
+func (lsp *logsamplerprocessor) bucket(l plog.LogRecord) uint32 {
+	if tid := l.TraceID(); !tid.IsEmpty() {
+		tidBytes := tid.Bytes()
+		return hash(tidBytes[:], lsp.hashSeed) & bitMaskHashBuckets
+	}
+	if lsp.fromAttribute != "" {
+		if v, ok := l.Attributes().Get(lsp.fromAttribute); ok {
+			return hash([]byte(v.AsString()), lsp.hashSeed) & bitMaskHashBuckets
+		}
+	}
+	return rand.Uint32() & bitMaskHashBuckets // nolint:gosec
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewLogsProcessor(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SamplingPercentage: 15.5,
+		FromAttribute:      "request.id",
+		SamplingPriority:   "priority",
+	}
+
+	_, err := newLogsProcessor(nil, cfg)
+	assert.Error(t, err)
+
+	got, err := newLogsProcessor(consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestLogsSampling(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		received int
+	}{
+		{
+			name: "full_sampling",
+			cfg: &Config{
+				SamplingPercentage: 100,
+			},
+			received: 100,
+		},
+		{
+			name: "nothing",
+			cfg: &Config{
+				SamplingPercentage: 0,
+			},
+			received: 0,
+		},
+		{
+			name: "half",
+			cfg: &Config{
+				SamplingPercentage: 49,
+			},
+			received: 49,
+		},
+		{
+			name: "from_attribute",
+			cfg: &Config{
+				SamplingPercentage: 50,
+				FromAttribute:      "request.id",
+			},
+			received: 51,
+		},
+		{
+			name: "sampling_priority",
+			cfg: &Config{
+				SamplingPercentage: 0,
+				SamplingPriority:   "priority",
+			},
+			received: 25,
+		},
+		{
+			name: "sampling_priority_drops",
+			cfg: &Config{
+				SamplingPercentage: 100,
+				SamplingPriority:   "priority",
+			},
+			received: 75,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			tt.cfg.ProcessorSettings = config.NewProcessorSettings(config.NewComponentID(typeStr))
+			processor, err := newLogsProcessor(sink, tt.cfg)
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+			for i := 0; i < 100; i++ {
+				record := lr.AppendEmpty()
+				record.SetTimestamp(pcommon.Timestamp(i))
+				if tt.cfg.FromAttribute != "" {
+					record.Attributes().InsertString(tt.cfg.FromAttribute, "request-"+string(rune('a'+i%26))+string(rune('a'+i/26)))
+				} else {
+					record.SetTraceID(pcommon.NewTraceID([16]byte{byte(i), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}))
+				}
+				if tt.cfg.SamplingPriority != "" {
+					switch i % 4 {
+					case 0:
+						record.Attributes().InsertInt(tt.cfg.SamplingPriority, 1)
+					case 1:
+						record.Attributes().InsertDouble(tt.cfg.SamplingPriority, 0)
+					}
+				}
+			}
+
+			require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+			sunk := sink.AllLogs()
+			numReceived := 0
+			if len(sunk) > 0 {
+				numReceived = sunk[0].LogRecordCount()
+			}
+			assert.Equal(t, tt.received, numReceived)
+		})
+	}
+}
+
+func TestLogsSamplingWithoutKey(t *testing.T) {
+	for _, pct := range []float32{0, 100} {
+		sink := new(consumertest.LogsSink)
+		processor, err := newLogsProcessor(sink, &Config{
+			ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+			SamplingPercentage: pct,
+			FromAttribute:      "request.id",
+		})
+		require.NoError(t, err)
+
+		logs := plog.NewLogs()
+		lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for i := 0; i < 10; i++ {
+			lr.AppendEmpty().Body().SetStringVal("no trace id nor request id")
+		}
+
+		require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+		assert.Equal(t, int(pct/10), sink.LogRecordCount())
+	}
+}
@@ -107,12 +107,17 @@ func (tsp *tracesamplerprocessor) processTraces(_ context.Context, td ptrace.Tra
 // OpenTracing semantic tags:
 // https://github.com/opentracing/specification/blob/main/semantic_conventions.md#span-tags-table
 func parseSpanSamplingPriority(span ptrace.Span) samplingPriority {
-	attribMap := span.Attributes()
+	return parseSamplingPriority(span.Attributes(), "sampling.priority")
+}
+
+// parseSamplingPriority reads the sampling priority from the attribute with the
+// given key, if present, applying the same semantics as "sampling.priority".
+func parseSamplingPriority(attribMap pcommon.Map, key string) samplingPriority {
 	if attribMap.Len() <= 0 {
 		return deferDecision
 	}
 
-	samplingPriorityAttrib, ok := attribMap.Get("sampling.priority")
+	samplingPriorityAttrib, ok := attribMap.Get(key)
 	if !ok {
 		return deferDecision
 	}
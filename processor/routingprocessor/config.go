@@ -42,7 +42,7 @@ type Config struct {
 	// this could be the HTTP/gRPC header from the original request/RPC. Typically, aggregation processors (batch, groupbytrace)
 	// will create a new context, so, those should be avoided when using this processor.Although the HTTP spec allows headers to be repeated,
 	// this processor will only use the first value.
-	// Required.
+	// Required when the routing table contains items with a Value.
 	FromAttribute string `mapstructure:"from_attribute"`
 
 	// DropRoutingResourceAttribute controls whether to remove the resource attribute used for routing.
@@ -57,16 +57,23 @@ type Config struct {
 
 // Validate checks if the processor configuration is valid.
 func (c *Config) Validate() error {
-	// validate that every route has a value for the routing attribute and has
-	// at least one exporter
+	// validate that every route has either a value for the routing attribute
+	// or an expression, and has at least one exporter
+	usesValue := false
 	for _, item := range c.Table {
-		if len(item.Value) == 0 {
+		if len(item.Value) == 0 && len(item.Expression) == 0 {
 			return fmt.Errorf("invalid (empty) route : %w", errEmptyRoute)
 		}
 
+		if len(item.Value) > 0 && len(item.Expression) > 0 {
+			return fmt.Errorf("invalid route %s: %w", item.Value, errValueAndExpression)
+		}
+
 		if len(item.Exporters) == 0 {
-			return fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
+			return fmt.Errorf("invalid route %s: %w", item.route(), errNoExporters)
 		}
+
+		usesValue = usesValue || len(item.Value) > 0
 	}
 
 	// validate that there's at least one item in the table
@@ -74,8 +81,8 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid routing table: %w", errNoTableItems)
 	}
 
-	// we also need a "FromAttribute" value
-	if len(c.FromAttribute) == 0 {
+	// we also need a "FromAttribute" value when routing on values
+	if usesValue && len(c.FromAttribute) == 0 {
 		return fmt.Errorf(
 			"invalid attribute to read the route's value from: %w",
 			errNoMissingFromAttribute,
@@ -100,12 +107,28 @@ const (
 
 // RoutingTableItem specifies how data should be routed to the different exporters
 type RoutingTableItem struct {
-	// Value represents a possible value for the field specified under FromAttribute. Required.
+	// Value represents a possible value for the field specified under FromAttribute.
+	// Either Value or Expression is required.
 	Value string `mapstructure:"value"`
 
+	// Expression is a condition written in the telemetry query language, e.g.:
+	// `resource.attributes["env"] == "prod"`. It is evaluated against each log record and span,
+	// and against each resource for metrics; the first matching expression route is used.
+	// Records not matched by any expression are routed using Value, then the default exporters.
+	// Either Value or Expression is required.
+	Expression string `mapstructure:"expression"`
+
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
 	// The routing processor will fail upon the first failure from these exporters.
 	// Optional.
 	Exporters []string `mapstructure:"exporters"`
 }
+
+// route returns the value or the expression identifying this table item.
+func (i RoutingTableItem) route() string {
+	if len(i.Expression) > 0 {
+		return i.Expression
+	}
+	return i.Value
+}
@@ -109,6 +109,30 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: "config_logs_expressions.yaml",
+			factoriesFunc: func(factories component.Factories) component.Factories {
+				// we don't need to use it in this test, but the config has them
+				factories.Exporters["logging"] = loggingexporter.NewFactory()
+				return factories
+			},
+			expectedConfig: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				DefaultExporters:  []string{"logging/default"},
+				AttributeSource:   "resource",
+				FromAttribute:     "X-Tenant",
+				Table: []RoutingTableItem{
+					{
+						Expression: `resource.attributes["env"] == "prod" and severity_number >= SEVERITY_NUMBER_WARN`,
+						Exporters:  []string{"logging/errors"},
+					},
+					{
+						Value:     "acme",
+						Exporters: []string{"logging/acme"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
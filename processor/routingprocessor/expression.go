@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	tqllogs "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/logs"
+	tqlresource "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/resource"
+	tqltraces "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/traces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+// exprRoute is a routing table item whose route is a condition written in the
+// telemetry query language, along with the exporters registered for it.
+type exprRoute struct {
+	expression string
+	condition  tql.BoolExpressionEvaluator
+	exporters  routeExporters
+}
+
+// compileExpressions parses the expressions of the routing table using the
+// paths and enums of the given pipeline type: log records for logs, spans for
+// traces and resources for metrics.
+func (r *router) compileExpressions(dataType config.DataType) error {
+	var pathParser tql.PathExpressionParser
+	var enumParser tql.EnumParser
+	switch dataType {
+	case config.LogsDataType:
+		pathParser, enumParser = tqllogs.ParsePath, tqllogs.ParseEnum
+	case config.TracesDataType:
+		pathParser, enumParser = tqltraces.ParsePath, tqltraces.ParseEnum
+	case config.MetricsDataType:
+		pathParser, enumParser = parseResourcePath, tqlresource.ParseEnum
+	default:
+		return fmt.Errorf("unsupported data type %q", dataType)
+	}
+
+	for i := range r.exprRoutes {
+		conditions, err := tql.ParseConditions([]string{r.exprRoutes[i].expression}, tqlfuncs.ConditionFunctions(), pathParser, enumParser)
+		if err != nil {
+			return fmt.Errorf("invalid route %s for %s: %w", r.exprRoutes[i].expression, dataType, err)
+		}
+		r.exprRoutes[i].condition = conditions[0]
+	}
+	return nil
+}
+
+// parseResourcePath parses paths of the form `resource.<field>`, so that
+// expressions written for metrics read like the ones for logs and traces.
+func parseResourcePath(val *tql.Path) (tql.GetSetter, error) {
+	if val == nil || len(val.Fields) < 2 || val.Fields[0].Name != "resource" {
+		return nil, fmt.Errorf("invalid path expression %v, only resource fields are available for metrics", val)
+	}
+	return tqlresource.ParsePath(&tql.Path{Fields: val.Fields[1:]})
+}
+
+type logContext struct {
+	logRecord plog.LogRecord
+	scope     pcommon.InstrumentationScope
+	resource  pcommon.Resource
+}
+
+func (ctx logContext) GetItem() interface{} {
+	return ctx.logRecord
+}
+
+func (ctx logContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.scope
+}
+
+func (ctx logContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+type spanContext struct {
+	span     ptrace.Span
+	scope    pcommon.InstrumentationScope
+	resource pcommon.Resource
+}
+
+func (ctx spanContext) GetItem() interface{} {
+	return ctx.span
+}
+
+func (ctx spanContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.scope
+}
+
+func (ctx spanContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+type resourceContext struct {
+	resource pcommon.Resource
+}
+
+func (ctx resourceContext) GetItem() interface{} {
+	return ctx.resource
+}
+
+func (ctx resourceContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return pcommon.NewInstrumentationScope()
+}
+
+func (ctx resourceContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// matchExpression returns the index of the first expression route whose
+// condition is true in the given context, or -1 if there is none.
+func (r *router) matchExpression(ctx tql.TransformContext) int {
+	for i, route := range r.exprRoutes {
+		if route.condition != nil && route.condition(ctx) {
+			return i
+		}
+	}
+	return -1
+}
@@ -50,17 +50,29 @@ func createDefaultConfig() config.Processor {
 
 func createTracesProcessor(_ context.Context, params component.ProcessorCreateSettings, cfg config.Processor, nextConsumer consumer.Traces) (component.TracesProcessor, error) {
 	warnIfNotLastInPipeline(nextConsumer, params.Logger)
-	return newProcessor(params.Logger, cfg), nil
+	p := newProcessor(params.Logger, cfg)
+	if err := p.router.compileExpressions(config.TracesDataType); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func createMetricsProcessor(_ context.Context, params component.ProcessorCreateSettings, cfg config.Processor, nextConsumer consumer.Metrics) (component.MetricsProcessor, error) {
 	warnIfNotLastInPipeline(nextConsumer, params.Logger)
-	return newProcessor(params.Logger, cfg), nil
+	p := newProcessor(params.Logger, cfg)
+	if err := p.router.compileExpressions(config.MetricsDataType); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func createLogsProcessor(_ context.Context, params component.ProcessorCreateSettings, cfg config.Processor, nextConsumer consumer.Logs) (component.LogsProcessor, error) {
 	warnIfNotLastInPipeline(nextConsumer, params.Logger)
-	return newProcessor(params.Logger, cfg), nil
+	p := newProcessor(params.Logger, cfg)
+	if err := p.router.compileExpressions(config.LogsDataType); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func warnIfNotLastInPipeline(nextConsumer interface{}, logger *zap.Logger) {
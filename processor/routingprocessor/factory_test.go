@@ -115,6 +115,60 @@ func TestProcessorFailsWithNoFromAttribute(t *testing.T) {
 	assert.ErrorIs(t, cfg.Validate(), errNoMissingFromAttribute)
 }
 
+func TestProcessorFailsWithValueAndExpression(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		FromAttribute:     "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:      "acme",
+				Expression: `attributes["tenant"] == "acme"`,
+				Exporters:  []string{"otlp"},
+			},
+		},
+	}
+	assert.ErrorIs(t, cfg.Validate(), errValueAndExpression)
+}
+
+func TestExpressionsDoNotRequireFromAttribute(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression: `attributes["tenant"] == "acme"`,
+				Exporters:  []string{"otlp"},
+			},
+		},
+	}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestProcessorFailsToBeCreatedWithInvalidExpression(t *testing.T) {
+	factory := NewFactory()
+	creationParams := componenttest.NewNopProcessorCreateSettings()
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DefaultExporters:  []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression: `severity_number >= SEVERITY_NUMBER_WARN`,
+				Exporters:  []string{"otlp"},
+			},
+		},
+	}
+
+	_, err := factory.CreateLogsProcessor(context.Background(), creationParams, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	// log record fields aren't available for metrics, nor for spans
+	_, err = factory.CreateMetricsProcessor(context.Background(), creationParams, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	_, err = factory.CreateTracesProcessor(context.Background(), creationParams, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+}
+
 func TestShouldNotFailWhenNextIsProcessor(t *testing.T) {
 	// prepare
 	factory := NewFactory()
@@ -29,6 +29,7 @@ import (
 
 var (
 	errEmptyRoute                   = errors.New("empty routing attribute provided")
+	errValueAndExpression           = errors.New("both a value and an expression are set for the route")
 	errNoExporters                  = errors.New("no exporters defined for the route")
 	errNoTableItems                 = errors.New("the routing table is empty")
 	errNoMissingFromAttribute       = errors.New("the FromAttribute property is empty")
@@ -875,6 +875,183 @@ func TestLogs_AreCorrectlySplitPerResourceAttributeRouting(t *testing.T) {
 	)
 }
 
+func TestLogs_RoutingWorks_Expression(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	errExp := &mockLogsExporter{}
+	acmeExp := &mockLogsExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.LogsDataType: {
+					config.NewComponentID("otlp"):       defaultExp,
+					config.NewComponentID("otlp/error"): errExp,
+					config.NewComponentID("otlp/acme"):  acmeExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		FromAttribute:    "X-Tenant",
+		AttributeSource:  resourceAttributeSource,
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression: `resource.attributes["env"] == "prod" and severity_number >= SEVERITY_NUMBER_WARN`,
+				Exporters:  []string{"otlp/error"},
+			},
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp/acme"},
+			},
+		},
+	})
+	require.NoError(t, exp.router.compileExpressions(config.LogsDataType))
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("env", "prod")
+	rl.Resource().Attributes().InsertString("X-Tenant", "acme")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberERROR)
+	lr.Body().SetStringVal("error")
+	lr = sl.LogRecords().AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberWARN)
+	lr.Body().SetStringVal("warn")
+	lr = sl.LogRecords().AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberINFO)
+	lr.Body().SetStringVal("info")
+
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("env", "dev")
+	lr = rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetSeverityNumber(plog.SeverityNumberERROR)
+	lr.Body().SetStringVal("dev error")
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	require.Len(t, errExp.AllLogs(), 1)
+	errLogs := errExp.AllLogs()[0]
+	require.Equal(t, 2, errLogs.LogRecordCount())
+	assert.Equal(t, "scope", errLogs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, "error", errLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+	assert.Equal(t, "warn", errLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Body().StringVal())
+
+	require.Len(t, acmeExp.AllLogs(), 1, "records not matched by an expression should be routed by value")
+	acmeLogs := acmeExp.AllLogs()[0]
+	require.Equal(t, 1, acmeLogs.LogRecordCount())
+	assert.Equal(t, "info", acmeLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+	env, ok := acmeLogs.ResourceLogs().At(0).Resource().Attributes().Get("env")
+	require.True(t, ok)
+	assert.Equal(t, "prod", env.StringVal())
+
+	require.Len(t, defaultExp.AllLogs(), 1)
+	assert.Equal(t, 1, defaultExp.AllLogs()[0].LogRecordCount())
+
+	assert.Equal(t, 4, l.LogRecordCount(), "incoming data should not be modified")
+}
+
+func TestTraces_RoutingWorks_Expression(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+	tExp := &mockTracesExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.TracesDataType: {
+					config.NewComponentID("otlp"):   defaultExp,
+					config.NewComponentID("otlp/2"): tExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression: `status.code == STATUS_CODE_ERROR`,
+				Exporters:  []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, exp.router.compileExpressions(config.TracesDataType))
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	tr := ptrace.NewTraces()
+	spans := tr.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	span := spans.AppendEmpty()
+	span.SetName("failed")
+	span.Status().SetCode(ptrace.StatusCodeError)
+	spans.AppendEmpty().SetName("ok")
+	spans.AppendEmpty().SetName("also ok")
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), tr))
+
+	require.Len(t, tExp.AllTraces(), 1)
+	require.Equal(t, 1, tExp.AllTraces()[0].SpanCount())
+	assert.Equal(t, "failed", tExp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	require.Len(t, defaultExp.AllTraces(), 1)
+	assert.Equal(t, 2, defaultExp.AllTraces()[0].SpanCount(),
+		"spans of the same resource and scope should be grouped together",
+	)
+	assert.Equal(t, 1, defaultExp.AllTraces()[0].ResourceSpans().Len())
+}
+
+func TestMetrics_RoutingWorks_Expression(t *testing.T) {
+	defaultExp := &mockMetricsExporter{}
+	mExp := &mockMetricsExporter{}
+
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.MetricsDataType: {
+					config.NewComponentID("otlp"):   defaultExp,
+					config.NewComponentID("otlp/2"): mExp,
+				},
+			}
+		},
+	}
+
+	exp := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Expression: `resource.attributes["env"] == "prod"`,
+				Exporters:  []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, exp.router.compileExpressions(config.MetricsDataType))
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	m := pmetric.NewMetrics()
+	rm := m.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("env", "prod")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("prod")
+	rm = m.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("env", "dev")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("dev")
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), m))
+
+	require.Len(t, mExp.AllMetrics(), 1)
+	require.Equal(t, 1, mExp.AllMetrics()[0].MetricCount())
+	assert.Equal(t, "prod", mExp.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	require.Len(t, defaultExp.AllMetrics(), 1)
+	require.Equal(t, 1, defaultExp.AllMetrics()[0].MetricCount())
+	assert.Equal(t, "dev", defaultExp.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
 func Benchmark_MetricsRouting_ResourceAttribute(b *testing.B) {
 	cfg := &Config{
 		FromAttribute:    "X-Tenant",
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
 )
 
 // router routes logs, metrics and traces using the configured attributes and
@@ -39,6 +41,8 @@ type router struct {
 	logger    *zap.Logger
 	extractor extractor
 
+	exprRoutes []exprRoute
+
 	defaultLogsExporters    []component.LogsExporter
 	logsExporters           map[string][]component.LogsExporter
 	defaultMetricsExporters []component.MetricsExporter
@@ -48,7 +52,7 @@ type router struct {
 }
 
 func newRouter(config Config, logger *zap.Logger) *router {
-	return &router{
+	r := &router{
 		config:           config,
 		logger:           logger,
 		extractor:        newExtractor(config.FromAttribute, logger),
@@ -56,6 +60,29 @@ func newRouter(config Config, logger *zap.Logger) *router {
 		metricsExporters: make(map[string][]component.MetricsExporter),
 		tracesExporters:  make(map[string][]component.TracesExporter),
 	}
+	for _, item := range config.Table {
+		if len(item.Expression) > 0 {
+			r.exprRoutes = append(r.exprRoutes, exprRoute{expression: item.Expression})
+		}
+	}
+	return r
+}
+
+// routeKey identifies where a record is routed when the routing table has
+// expressions: either to the expression route at index expr, or, when expr is
+// negative, according to the value read from the routing attribute.
+type routeKey struct {
+	expr  int
+	value string
+}
+
+// fallbackRoute returns the route used for the records of the given resource
+// that are not matched by any expression.
+func (r *router) fallbackRoute(ctx context.Context, resource pcommon.Resource) routeKey {
+	if r.config.AttributeSource == resourceAttributeSource {
+		return routeKey{expr: -1, value: r.extractor.extractAttrFromResource(resource)}
+	}
+	return routeKey{expr: -1, value: r.extractor.extractFromContext(ctx)}
 }
 
 type routedMetrics struct {
@@ -64,6 +91,9 @@ type routedMetrics struct {
 }
 
 func (r *router) RouteMetrics(ctx context.Context, tm pmetric.Metrics) []routedMetrics {
+	if len(r.exprRoutes) > 0 {
+		return r.routeMetricsForExpressions(ctx, tm)
+	}
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeMetricsForResource(ctx, tm)
@@ -150,12 +180,151 @@ func (r *router) routeMetricsForContext(ctx context.Context, tm pmetric.Metrics)
 	}
 }
 
+func (r *router) routeMetricsForExpressions(ctx context.Context, tm pmetric.Metrics) []routedMetrics {
+	// routingEntry is used to group pmetric.ResourceMetrics that are routed to
+	// the same route.
+	type routingEntry struct {
+		exporters  []component.MetricsExporter
+		resMetrics pmetric.ResourceMetricsSlice
+	}
+	routingMap := map[routeKey]routingEntry{}
+
+	resMetricsSlice := tm.ResourceMetrics()
+	for i := 0; i < resMetricsSlice.Len(); i++ {
+		resMetrics := resMetricsSlice.At(i)
+
+		key := r.fallbackRoute(ctx, resMetrics.Resource())
+		if idx := r.matchExpression(resourceContext{resource: resMetrics.Resource()}); idx >= 0 {
+			key = routeKey{expr: idx}
+		}
+
+		exp, routedByValue := r.exportersFor(key, config.MetricsDataType)
+		if routedByValue && r.config.DropRoutingResourceAttribute {
+			r.removeRoutingAttribute(resMetrics.Resource())
+		}
+
+		rEntry, ok := routingMap[key]
+		if !ok {
+			rEntry = routingEntry{
+				exporters:  exp.metrics,
+				resMetrics: pmetric.NewResourceMetricsSlice(),
+			}
+			routingMap[key] = rEntry
+		}
+		resMetrics.MoveTo(rEntry.resMetrics.AppendEmpty())
+	}
+
+	ret := make([]routedMetrics, 0, len(routingMap))
+	for _, rEntry := range routingMap {
+		metrics := pmetric.NewMetrics()
+		metrics.ResourceMetrics().EnsureCapacity(rEntry.resMetrics.Len())
+		rEntry.resMetrics.MoveAndAppendTo(metrics.ResourceMetrics())
+
+		ret = append(ret, routedMetrics{
+			metrics:   metrics,
+			exporters: rEntry.exporters,
+		})
+	}
+
+	return ret
+}
+
+// routeExporters holds the exporters of a route for each pipeline type.
+type routeExporters struct {
+	logs    []component.LogsExporter
+	metrics []component.MetricsExporter
+	traces  []component.TracesExporter
+}
+
+// exportersFor returns the exporters of the given pipeline type for the given
+// route and whether they were found using the value of the routing attribute.
+func (r *router) exportersFor(key routeKey, dataType config.DataType) (routeExporters, bool) {
+	if key.expr >= 0 {
+		return r.exprRoutes[key.expr].exporters, false
+	}
+	var exp routeExporters
+	var ok bool
+	switch dataType {
+	case config.LogsDataType:
+		if exp.logs, ok = r.logsExporters[key.value]; !ok {
+			exp.logs = r.defaultLogsExporters
+		}
+	case config.MetricsDataType:
+		if exp.metrics, ok = r.metricsExporters[key.value]; !ok {
+			exp.metrics = r.defaultMetricsExporters
+		}
+	case config.TracesDataType:
+		if exp.traces, ok = r.tracesExporters[key.value]; !ok {
+			exp.traces = r.defaultTracesExporters
+		}
+	}
+	return exp, ok
+}
+
+// exprRecords gives access to the records of logs or traces, so that
+// routeRecords can route them on their own and regroup them per route.
+type exprRecords interface {
+	resourceLen() int
+	resource(i int) pcommon.Resource
+	scopeLen(i int) int
+	recordLen(i, j int) int
+	// context returns the context the expressions are evaluated in for the
+	// record k of the scope j of the resource i.
+	context(i, j, k int) tql.TransformContext
+	// appendResource appends a copy of the resource i to the data routed to
+	// key and returns the copied resource.
+	appendResource(key routeKey, i int) pcommon.Resource
+	// appendScope appends a copy of the scope j of the resource i to the last
+	// resource appended to the data routed to key.
+	appendScope(key routeKey, i, j int)
+	// appendRecord appends a copy of the record k of the scope j of the
+	// resource i to the last scope appended to the data routed to key.
+	appendRecord(key routeKey, i, j, k int)
+}
+
+// routeRecords routes each record on its own. Records of a resource and scope
+// that are routed to the same route are grouped under a copy of that resource
+// and scope.
+func (r *router) routeRecords(ctx context.Context, records exprRecords, dataType config.DataType) {
+	for i := 0; i < records.resourceLen(); i++ {
+		fallback := r.fallbackRoute(ctx, records.resource(i))
+		resDest := map[routeKey]bool{}
+
+		for j := 0; j < records.scopeLen(i); j++ {
+			scopeDest := map[routeKey]bool{}
+
+			for k := 0; k < records.recordLen(i, j); k++ {
+				key := fallback
+				if idx := r.matchExpression(records.context(i, j, k)); idx >= 0 {
+					key = routeKey{expr: idx}
+				}
+
+				if !scopeDest[key] {
+					if !resDest[key] {
+						res := records.appendResource(key, i)
+						if _, routedByValue := r.exportersFor(key, dataType); routedByValue && r.config.DropRoutingResourceAttribute {
+							r.removeRoutingAttribute(res)
+						}
+						resDest[key] = true
+					}
+					records.appendScope(key, i, j)
+					scopeDest[key] = true
+				}
+				records.appendRecord(key, i, j, k)
+			}
+		}
+	}
+}
+
 type routedTraces struct {
 	traces    ptrace.Traces
 	exporters []component.TracesExporter
 }
 
 func (r *router) RouteTraces(ctx context.Context, tr ptrace.Traces) []routedTraces {
+	if len(r.exprRoutes) > 0 {
+		return r.routeTracesForExpressions(ctx, tr)
+	}
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeTracesForResource(ctx, tr)
@@ -238,12 +407,90 @@ func (r *router) routeTracesForContext(ctx context.Context, tr ptrace.Traces) ro
 	}
 }
 
+func (r *router) routeTracesForExpressions(ctx context.Context, tr ptrace.Traces) []routedTraces {
+	records := &traceRecords{traces: tr, routes: map[routeKey]ptrace.Traces{}}
+	r.routeRecords(ctx, records, config.TracesDataType)
+
+	ret := make([]routedTraces, 0, len(records.routes))
+	for key, traces := range records.routes {
+		exp, _ := r.exportersFor(key, config.TracesDataType)
+		ret = append(ret, routedTraces{
+			traces:    traces,
+			exporters: exp.traces,
+		})
+	}
+
+	return ret
+}
+
+// traceRecords implements exprRecords for the spans of traces.
+type traceRecords struct {
+	traces ptrace.Traces
+	routes map[routeKey]ptrace.Traces
+}
+
+func (t *traceRecords) resourceLen() int {
+	return t.traces.ResourceSpans().Len()
+}
+
+func (t *traceRecords) resource(i int) pcommon.Resource {
+	return t.traces.ResourceSpans().At(i).Resource()
+}
+
+func (t *traceRecords) scopeLen(i int) int {
+	return t.traces.ResourceSpans().At(i).ScopeSpans().Len()
+}
+
+func (t *traceRecords) recordLen(i, j int) int {
+	return t.traces.ResourceSpans().At(i).ScopeSpans().At(j).Spans().Len()
+}
+
+func (t *traceRecords) context(i, j, k int) tql.TransformContext {
+	resSpans := t.traces.ResourceSpans().At(i)
+	scopeSpans := resSpans.ScopeSpans().At(j)
+	return spanContext{span: scopeSpans.Spans().At(k), scope: scopeSpans.Scope(), resource: resSpans.Resource()}
+}
+
+func (t *traceRecords) appendResource(key routeKey, i int) pcommon.Resource {
+	dest, ok := t.routes[key]
+	if !ok {
+		dest = ptrace.NewTraces()
+		t.routes[key] = dest
+	}
+	resSpans := t.traces.ResourceSpans().At(i)
+	rs := dest.ResourceSpans().AppendEmpty()
+	resSpans.Resource().CopyTo(rs.Resource())
+	rs.SetSchemaUrl(resSpans.SchemaUrl())
+	return rs.Resource()
+}
+
+func (t *traceRecords) appendScope(key routeKey, i, j int) {
+	scopeSpans := t.traces.ResourceSpans().At(i).ScopeSpans().At(j)
+	ss := t.lastResourceSpans(key).ScopeSpans().AppendEmpty()
+	scopeSpans.Scope().CopyTo(ss.Scope())
+	ss.SetSchemaUrl(scopeSpans.SchemaUrl())
+}
+
+func (t *traceRecords) appendRecord(key routeKey, i, j, k int) {
+	scopeSpansSlice := t.lastResourceSpans(key).ScopeSpans()
+	ss := scopeSpansSlice.At(scopeSpansSlice.Len() - 1)
+	t.traces.ResourceSpans().At(i).ScopeSpans().At(j).Spans().At(k).CopyTo(ss.Spans().AppendEmpty())
+}
+
+func (t *traceRecords) lastResourceSpans(key routeKey) ptrace.ResourceSpans {
+	resSpansSlice := t.routes[key].ResourceSpans()
+	return resSpansSlice.At(resSpansSlice.Len() - 1)
+}
+
 type routedLogs struct {
 	logs      plog.Logs
 	exporters []component.LogsExporter
 }
 
 func (r *router) RouteLogs(ctx context.Context, tl plog.Logs) []routedLogs {
+	if len(r.exprRoutes) > 0 {
+		return r.routeLogsForExpressions(ctx, tl)
+	}
 	switch r.config.AttributeSource {
 	case resourceAttributeSource:
 		return r.routeLogsForResource(ctx, tl)
@@ -326,6 +573,81 @@ func (r *router) routeLogsForContext(ctx context.Context, tl plog.Logs) routedLo
 	}
 }
 
+func (r *router) routeLogsForExpressions(ctx context.Context, tl plog.Logs) []routedLogs {
+	records := &logRecords{logs: tl, routes: map[routeKey]plog.Logs{}}
+	r.routeRecords(ctx, records, config.LogsDataType)
+
+	ret := make([]routedLogs, 0, len(records.routes))
+	for key, logs := range records.routes {
+		exp, _ := r.exportersFor(key, config.LogsDataType)
+		ret = append(ret, routedLogs{
+			logs:      logs,
+			exporters: exp.logs,
+		})
+	}
+
+	return ret
+}
+
+// logRecords implements exprRecords for the log records of logs.
+type logRecords struct {
+	logs   plog.Logs
+	routes map[routeKey]plog.Logs
+}
+
+func (l *logRecords) resourceLen() int {
+	return l.logs.ResourceLogs().Len()
+}
+
+func (l *logRecords) resource(i int) pcommon.Resource {
+	return l.logs.ResourceLogs().At(i).Resource()
+}
+
+func (l *logRecords) scopeLen(i int) int {
+	return l.logs.ResourceLogs().At(i).ScopeLogs().Len()
+}
+
+func (l *logRecords) recordLen(i, j int) int {
+	return l.logs.ResourceLogs().At(i).ScopeLogs().At(j).LogRecords().Len()
+}
+
+func (l *logRecords) context(i, j, k int) tql.TransformContext {
+	resLogs := l.logs.ResourceLogs().At(i)
+	scopeLogs := resLogs.ScopeLogs().At(j)
+	return logContext{logRecord: scopeLogs.LogRecords().At(k), scope: scopeLogs.Scope(), resource: resLogs.Resource()}
+}
+
+func (l *logRecords) appendResource(key routeKey, i int) pcommon.Resource {
+	dest, ok := l.routes[key]
+	if !ok {
+		dest = plog.NewLogs()
+		l.routes[key] = dest
+	}
+	resLogs := l.logs.ResourceLogs().At(i)
+	rl := dest.ResourceLogs().AppendEmpty()
+	resLogs.Resource().CopyTo(rl.Resource())
+	rl.SetSchemaUrl(resLogs.SchemaUrl())
+	return rl.Resource()
+}
+
+func (l *logRecords) appendScope(key routeKey, i, j int) {
+	scopeLogs := l.logs.ResourceLogs().At(i).ScopeLogs().At(j)
+	sl := l.lastResourceLogs(key).ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().CopyTo(sl.Scope())
+	sl.SetSchemaUrl(scopeLogs.SchemaUrl())
+}
+
+func (l *logRecords) appendRecord(key routeKey, i, j, k int) {
+	scopeLogsSlice := l.lastResourceLogs(key).ScopeLogs()
+	sl := scopeLogsSlice.At(scopeLogsSlice.Len() - 1)
+	l.logs.ResourceLogs().At(i).ScopeLogs().At(j).LogRecords().At(k).CopyTo(sl.LogRecords().AppendEmpty())
+}
+
+func (l *logRecords) lastResourceLogs(key routeKey) plog.ResourceLogs {
+	resLogsSlice := l.routes[key].ResourceLogs()
+	return resLogsSlice.At(resLogsSlice.Len() - 1)
+}
+
 // registerExporters registers the exporters as per the configured routing table
 // taking into account the provided map of available exporters.
 func (r *router) registerExporters(hostExporters map[config.DataType]map[config.ComponentID]component.Exporter) error {
@@ -364,7 +686,8 @@ func (r *router) registerExporters(hostExporters map[config.DataType]map[config.
 		len(r.defaultTracesExporters) == 0 &&
 		len(r.logsExporters) == 0 &&
 		len(r.metricsExporters) == 0 &&
-		len(r.tracesExporters) == 0 {
+		len(r.tracesExporters) == 0 &&
+		!r.hasExprRouteExporters() {
 		return errNoExportersAfterRegistration
 	}
 
@@ -435,8 +758,16 @@ func (r *router) registerExportersForRoutes(available ExporterMap) error {
 		return err
 	}
 
-	// exporters for each defined value
+	// exporters for each defined value or expression
+	exprIdx := 0
 	for _, item := range r.config.Table {
+		if len(item.Expression) > 0 {
+			if err := r.registerExportersForExprRoute(&r.exprRoutes[exprIdx], available, item.Exporters); err != nil {
+				return err
+			}
+			exprIdx++
+			continue
+		}
 		if err := r.registerExportersForRoute(item.Value, available, item.Exporters); err != nil {
 			return err
 		}
@@ -501,3 +832,46 @@ func (r *router) registerExportersForRoute(route string, available ExporterMap,
 
 	return nil
 }
+
+// registerExportersForExprRoute registers the requested exporters for the given
+// expression route using the provided available exporters map to check if they
+// were available.
+func (r *router) registerExportersForExprRoute(route *exprRoute, available ExporterMap, requested []string) error {
+	r.logger.Debug("Registering exporter for expression route",
+		zap.String("route", route.expression),
+		zap.Any("requested", requested),
+	)
+
+	for _, exp := range requested {
+		v, ok := available[exp]
+		if !ok {
+			return fmt.Errorf("error registering route %q for exporter %q: %w",
+				route.expression, exp, errExporterNotFound,
+			)
+		}
+
+		switch exp := v.(type) {
+		case component.TracesExporter:
+			route.exporters.traces = append(route.exporters.traces, exp)
+		case component.MetricsExporter:
+			route.exporters.metrics = append(route.exporters.metrics, exp)
+		case component.LogsExporter:
+			route.exporters.logs = append(route.exporters.logs, exp)
+		default:
+			return fmt.Errorf("unknown exporter type %T", v)
+		}
+	}
+
+	return nil
+}
+
+// hasExprRouteExporters returns whether any exporter was registered for an
+// expression route.
+func (r *router) hasExprRouteExporters() bool {
+	for _, route := range r.exprRoutes {
+		if len(route.exporters.logs) > 0 || len(route.exporters.metrics) > 0 || len(route.exporters.traces) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// AttributeChangeSet represents a rename_attributes type operation.
+// The listed changes are duplicated twice
+// to allow for simplified means of transition to or from a revision.
+type AttributeChangeSet struct {
+	updates  map[string]string
+	rollback map[string]string
+}
+
+// NewAttributeChangeSet allows for typed strings to be used as part
+// of the invocation that will be converted into the default string type.
+func NewAttributeChangeSet(mappings map[string]string) *AttributeChangeSet {
+	attr := &AttributeChangeSet{
+		updates:  make(map[string]string, len(mappings)),
+		rollback: make(map[string]string, len(mappings)),
+	}
+	for from, to := range mappings {
+		attr.updates[from] = to
+		attr.rollback[to] = from
+	}
+	return attr
+}
+
+// Apply renames the attributes using the old key names
+// to the new key names defined by the change set.
+func (acs *AttributeChangeSet) Apply(attrs pcommon.Map) {
+	rename(attrs, acs.updates)
+}
+
+// Rollback renames the attributes using the new key names
+// back to the old key names defined by the change set.
+func (acs *AttributeChangeSet) Rollback(attrs pcommon.Map) {
+	rename(attrs, acs.rollback)
+}
+
+// rename is done in two passes so that a change set
+// that swaps keys (a -> b, b -> a) is not applied twice to the same value.
+func rename(attrs pcommon.Map, mappings map[string]string) {
+	if len(mappings) == 0 {
+		return
+	}
+	renamed := pcommon.NewMap()
+	attrs.RemoveIf(func(k string, v pcommon.Value) bool {
+		to, match := mappings[k]
+		if match {
+			renamed.Upsert(to, v)
+		}
+		return match
+	})
+	renamed.Range(func(k string, v pcommon.Value) bool {
+		attrs.Upsert(k, v)
+		return true
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestAttributeChangeSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+		mappings map[string]string
+		in       map[string]interface{}
+		updated  map[string]interface{}
+	}{
+		{
+			scenario: "no changes defined",
+			mappings: map[string]string{},
+			in:       map[string]interface{}{"service.name": "test"},
+			updated:  map[string]interface{}{"service.name": "test"},
+		},
+		{
+			scenario: "rename matching attribute",
+			mappings: map[string]string{"k8s.pod.name": "kubernetes.pod.name"},
+			in:       map[string]interface{}{"k8s.pod.name": "pod-0", "service.name": "test"},
+			updated:  map[string]interface{}{"kubernetes.pod.name": "pod-0", "service.name": "test"},
+		},
+		{
+			scenario: "swapped attribute names",
+			mappings: map[string]string{"a": "b", "b": "a"},
+			in:       map[string]interface{}{"a": "foo", "b": int64(7)},
+			updated:  map[string]interface{}{"a": int64(7), "b": "foo"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.scenario, func(t *testing.T) {
+			acs := NewAttributeChangeSet(tc.mappings)
+
+			attrs := pcommon.NewMapFromRaw(tc.in)
+			acs.Apply(attrs)
+			assert.Equal(t, tc.updated, attrs.AsRaw(), "Must match the updated attributes")
+
+			acs.Rollback(attrs)
+			assert.Equal(t, tc.in, attrs.AsRaw(), "Must match the original attributes")
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, NewCondition().Matches("any"), "Must match when no names are defined")
+	assert.True(t, NewCondition("HTTP GET").Matches("HTTP GET"))
+	assert.False(t, NewCondition("HTTP GET").Matches("HTTP POST"))
+}
+
+func TestSignalNameChange(t *testing.T) {
+	t.Parallel()
+
+	sc := NewSignalNameChange(map[string]string{
+		"container.cpu.usage.total": "cpu.usage.total",
+	})
+
+	m := pmetric.NewMetric()
+	m.SetName("container.cpu.usage.total")
+
+	sc.Apply(m)
+	assert.Equal(t, "cpu.usage.total", m.Name())
+
+	sc.Rollback(m)
+	assert.Equal(t, "container.cpu.usage.total", m.Name())
+
+	m.SetName("memory.usage.max")
+	sc.Apply(m)
+	assert.Equal(t, "memory.usage.max", m.Name(), "Must not modify unmatched names")
+}
@@ -0,0 +1,38 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+
+// Condition is the set of signal names that a change is restricted to,
+// such as the `apply_to_spans` or `apply_to_metrics` values.
+// An empty condition matches every signal name.
+type Condition map[string]struct{}
+
+// NewCondition creates a condition that matches the provided names.
+func NewCondition(names ...string) Condition {
+	c := make(Condition, len(names))
+	for _, name := range names {
+		c[name] = struct{}{}
+	}
+	return c
+}
+
+// Matches checks if the name is part of the condition.
+func (c Condition) Matches(name string) bool {
+	if len(c) == 0 {
+		return true
+	}
+	_, match := c[name]
+	return match
+}
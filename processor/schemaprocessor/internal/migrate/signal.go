@@ -0,0 +1,54 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// SignalNameChange represents a rename_metrics or rename_events type operation
+// that is applied to the name of the signal.
+type SignalNameChange struct {
+	updates  map[string]string
+	rollback map[string]string
+}
+
+// NewSignalNameChange creates a change that maps the
+// old signal names to the new signal names.
+func NewSignalNameChange(mappings map[string]string) *SignalNameChange {
+	sc := &SignalNameChange{
+		updates:  make(map[string]string, len(mappings)),
+		rollback: make(map[string]string, len(mappings)),
+	}
+	for from, to := range mappings {
+		sc.updates[from] = to
+		sc.rollback[to] = from
+	}
+	return sc
+}
+
+// Apply updates the signal name to the new value if it matches a defined name.
+func (sc *SignalNameChange) Apply(signal alias.Signal) {
+	if name, match := sc.updates[signal.Name()]; match {
+		signal.SetName(name)
+	}
+}
+
+// Rollback reverts the signal name to the old value if it matches a defined name.
+func (sc *SignalNameChange) Rollback(signal alias.Signal) {
+	if name, match := sc.rollback[signal.Name()]; match {
+		signal.SetName(name)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+var (
+	errNilValueProvided = errors.New("nil value provided")
+	errNoProviders      = errors.New("no providers configured")
+)
+
+// Manager is responsible for ensuring that schemas are kept up to date
+// with the most recent version that are requested.
+type Manager interface {
+	// RequestTranslation will provide either the defined Translation
+	// if it is a known target, or, return a noop variation.
+	// In the event that a matched Translation, on a missed version
+	// there is a potential to block during this process.
+	// Concurrent requests for the same schema file share a single lookup,
+	// which isn't cancelled along with the request that started it,
+	// and a schema file that failed to be fetched is not looked up again
+	// until its backoff elapsed, the last error is returned meanwhile.
+	// Otherwise, the translation will allow concurrent reads.
+	RequestTranslation(ctx context.Context, schemaURL string) (Translation, error)
+
+	// SetProviders will update the list of providers used by the manager
+	// to look up schemaURLs
+	SetProviders(providers ...Provider) error
+}
+
+const (
+	// minRetryBackoff is how long to wait before looking up a schema file again
+	// after it failed to be fetched, doubled on each consecutive failure
+	minRetryBackoff = time.Second
+	maxRetryBackoff = 5 * time.Minute
+
+	// lookupTimeout bounds a lookup, as it's shared by the requests and not tied to any of them
+	lookupTimeout = time.Minute
+)
+
+type manager struct {
+	log *zap.Logger
+	now func() time.Time
+
+	rw        sync.RWMutex
+	providers []Provider
+	// match stores the target schemaURL for each schema family
+	match map[string]string
+	// translations caches the parsed translations by the schemaURL
+	// that was used to fetch the schema file
+	translations map[string]Translation
+	// fetches stores the lookups in progress by the schemaURL being fetched
+	fetches map[string]*fetch
+	// failures stores the last failed lookup of each schemaURL that couldn't be fetched
+	failures map[string]*failure
+}
+
+// fetch is a lookup in progress, done is closed once tn or err is set.
+type fetch struct {
+	done chan struct{}
+	tn   Translation
+	err  error
+}
+
+type failure struct {
+	err     error
+	backoff time.Duration
+	retryAt time.Time
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a manager that will allow for management
+// of schema, the options allow for additional properties to be
+// added to manager to enable additional locations of where to check
+// for translations file.
+func NewManager(targets []string, log *zap.Logger) (Manager, error) {
+	if log == nil {
+		return nil, fmt.Errorf("logger: %w", errNilValueProvided)
+	}
+
+	match := make(map[string]string, len(targets))
+	for _, target := range targets {
+		family, _, err := GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		match[family] = target
+	}
+
+	return &manager{
+		log:          log,
+		now:          time.Now,
+		match:        match,
+		translations: make(map[string]Translation),
+		fetches:      make(map[string]*fetch),
+		failures:     make(map[string]*failure),
+	}, nil
+}
+
+func (m *manager) RequestTranslation(ctx context.Context, schemaURL string) (Translation, error) {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := m.match[family]
+	if !ok {
+		return nopTranslation{}, nil
+	}
+	_, targetVersion, err := GetFamilyAndVersion(target)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schema file published at a version contains every revision up to and including it,
+	// so the newest of the two versions is fetched to be able to translate in either direction.
+	fetchURL := target
+	if version.GreaterThan(targetVersion) {
+		fetchURL = schemaURL
+	}
+
+	m.rw.RLock()
+	tn, exist := m.translations[fetchURL]
+	m.rw.RUnlock()
+	if exist {
+		return tn, nil
+	}
+
+	m.rw.Lock()
+	if tn, exist = m.translations[fetchURL]; exist {
+		m.rw.Unlock()
+		return tn, nil
+	}
+	if f, ok := m.failures[fetchURL]; ok && m.now().Before(f.retryAt) {
+		m.rw.Unlock()
+		return nil, f.err
+	}
+	f, ok := m.fetches[fetchURL]
+	if !ok {
+		if len(m.providers) == 0 {
+			m.rw.Unlock()
+			return nil, errNoProviders
+		}
+		f = &fetch{done: make(chan struct{})}
+		m.fetches[fetchURL] = f
+		go m.fetch(f, m.providers, target, fetchURL)
+	}
+	m.rw.Unlock()
+
+	select {
+	case <-f.done:
+		return f.tn, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch looks up the schema file for every request waiting on f, caching the translation
+// or backing off the next lookup, and closes f.done once it's done.
+func (m *manager) fetch(f *fetch, providers []Provider, target, fetchURL string) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	f.tn, f.err = m.lookup(ctx, providers, target, fetchURL)
+
+	m.rw.Lock()
+	delete(m.fetches, fetchURL)
+	if f.err == nil {
+		m.translations[fetchURL] = f.tn
+		delete(m.failures, fetchURL)
+	} else {
+		m.recordFailure(fetchURL, f.err)
+	}
+	m.rw.Unlock()
+	close(f.done)
+}
+
+// lookup fetches the schema file from the first provider that is able to.
+func (m *manager) lookup(ctx context.Context, providers []Provider, target, fetchURL string) (Translation, error) {
+	var errs error
+	for _, p := range providers {
+		content, err := p.Lookup(ctx, fetchURL)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		tn, err := NewTranslationFromReader(target, content)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		m.log.Debug("Cached schema translation",
+			zap.String("schema-url", fetchURL),
+			zap.String("target", target),
+		)
+		return tn, nil
+	}
+	return nil, errs
+}
+
+// recordFailure backs off the next lookup of the schemaURL, it must be called with the lock held.
+// The error is logged here since it is returned to every request made until the backoff elapsed.
+func (m *manager) recordFailure(fetchURL string, err error) {
+	backoff := minRetryBackoff
+	if prev, ok := m.failures[fetchURL]; ok {
+		backoff = prev.backoff * 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+	m.failures[fetchURL] = &failure{err: err, backoff: backoff, retryAt: m.now().Add(backoff)}
+
+	m.log.Error("Unable to fetch schema translation",
+		zap.String("schema-url", fetchURL),
+		zap.Duration("retry-in", backoff),
+		zap.Error(err),
+	)
+}
+
+func (m *manager) SetProviders(providers ...Provider) error {
+	if len(providers) == 0 {
+		return fmt.Errorf("zero providers set: %w", errNilValueProvided)
+	}
+	m.rw.Lock()
+	m.providers = append([]Provider(nil), providers...)
+	// The new providers may be able to fetch the schema files the previous ones couldn't.
+	m.failures = make(map[string]*failure)
+	m.rw.Unlock()
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+type fakeProvider struct {
+	mu        sync.Mutex
+	requested []string
+	err       error
+}
+
+func (fp *fakeProvider) Lookup(_ context.Context, schemaURL string) (io.Reader, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.requested = append(fp.requested, schemaURL)
+	if fp.err != nil {
+		return nil, fp.err
+	}
+	return os.Open("testdata/schema.yml")
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	assert.ErrorIs(t, err, errNoProviders, "Must error when no providers are set")
+
+	p := &fakeProvider{}
+	require.NoError(t, m.SetProviders(p))
+
+	tn, err := m.RequestTranslation(context.Background(), "https://other.example.com/schemas/1.0.0")
+	require.NoError(t, err, "Must not error for unmatched families")
+	assert.Equal(t, nopTranslation{}, tn, "Must return a nop translation for unmatched families")
+
+	for i := 0; i < 2; i++ {
+		tn, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+		require.NoError(t, err, "Must not error when requesting translation")
+		assert.Equal(t, "https://example.com/schemas/1.1.0", tn.SchemaURL())
+	}
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.2.0")
+	require.NoError(t, err, "Must not error when requesting translation")
+
+	assert.Equal(t, []string{
+		"https://example.com/schemas/1.1.0",
+		"https://example.com/schemas/1.2.0",
+	}, p.requested, "Must fetch the newest schema file once and reuse the cached translation")
+}
+
+func TestManagerProviderError(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	assert.ErrorIs(t, m.SetProviders(), errNilValueProvided)
+
+	errLookup := errors.New("lookup failed")
+	require.NoError(t, m.SetProviders(&fakeProvider{err: errLookup}))
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	assert.ErrorIs(t, err, errLookup, "Must return the provider error")
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1")
+	assert.ErrorIs(t, err, ErrInvalidVersion, "Must error on invalid schema urls")
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.2.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	require.NoError(t, m.SetProviders(&fakeProvider{}))
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		tn, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+		if err != nil {
+			return err
+		}
+		if !tn.SupportedVersion(&Version{1, 0, 0}) {
+			return errors.New("translation must support version 1.0.0")
+		}
+		return nil
+	})
+}
+
+func TestManagerBacksOffFailedLookups(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	now := time.Unix(0, 0)
+	m.(*manager).now = func() time.Time { return now }
+
+	errLookup := errors.New("lookup failed")
+	p := &fakeProvider{err: errLookup}
+	require.NoError(t, m.SetProviders(p))
+
+	request := func() error {
+		_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+		return err
+	}
+
+	assert.ErrorIs(t, request(), errLookup)
+	assert.ErrorIs(t, request(), errLookup, "Must return the last error during the backoff")
+	assert.Len(t, p.requested, 1, "Must not look up the schema again during the backoff")
+
+	now = now.Add(minRetryBackoff)
+	assert.ErrorIs(t, request(), errLookup)
+	assert.Len(t, p.requested, 2, "Must look up the schema again once the backoff elapsed")
+
+	now = now.Add(minRetryBackoff)
+	assert.ErrorIs(t, request(), errLookup)
+	assert.Len(t, p.requested, 2, "Must double the backoff on consecutive failures")
+
+	p.mu.Lock()
+	p.err = nil
+	p.mu.Unlock()
+	now = now.Add(minRetryBackoff)
+	assert.NoError(t, request(), "Must succeed once the schema can be fetched")
+	assert.Len(t, p.requested, 3)
+}
+
+func TestManagerSetProvidersResetsBackoff(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	require.NoError(t, m.SetProviders(&fakeProvider{err: errors.New("lookup failed")}))
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	require.Error(t, err)
+
+	require.NoError(t, m.SetProviders(&fakeProvider{}))
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	assert.NoError(t, err, "Must look up the schema with the new providers")
+}
+
+type blockingProvider struct {
+	fakeProvider
+	release chan struct{}
+}
+
+func (bp *blockingProvider) Lookup(ctx context.Context, schemaURL string) (io.Reader, error) {
+	<-bp.release
+	return bp.fakeProvider.Lookup(ctx, schemaURL)
+}
+
+func TestManagerLookupOutlivesCancelledRequest(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	p := &blockingProvider{release: make(chan struct{})}
+	require.NoError(t, m.SetProviders(p))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := m.RequestTranslation(ctx, "https://example.com/schemas/1.0.0")
+		first <- err
+	}()
+	require.Eventually(t, func() bool {
+		m.(*manager).rw.RLock()
+		defer m.(*manager).rw.RUnlock()
+		return len(m.(*manager).fetches) == 1
+	}, time.Second, time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+		second <- err
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled, "Must return once the request is cancelled")
+
+	close(p.release)
+	assert.NoError(t, <-second, "Must not fail because the request that started the lookup was cancelled")
+	assert.Len(t, p.requested, 1, "Must look up the schema once")
+}
+
+func TestManagerSingleLookupForConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://example.com/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	p := &blockingProvider{release: make(chan struct{})}
+	require.NoError(t, m.SetProviders(p))
+
+	const requests = 10
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		go func() {
+			_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+			errs <- err
+		}()
+	}
+
+	// wait for the lookup to be in progress before releasing it
+	require.Eventually(t, func() bool {
+		m.(*manager).rw.RLock()
+		defer m.(*manager).rw.RUnlock()
+		return len(m.(*manager).fetches) == 1
+	}, time.Second, time.Millisecond)
+	close(p.release)
+
+	for i := 0; i < requests; i++ {
+		assert.NoError(t, <-errs)
+	}
+	assert.Len(t, p.requested, 1, "Must look up the schema once for concurrent requests")
+}
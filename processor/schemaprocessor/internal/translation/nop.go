@@ -0,0 +1,35 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// nopTranslation is used when the schema family
+// does not match any of the configured targets.
+type nopTranslation struct{}
+
+var _ Translation = (*nopTranslation)(nil)
+
+func (nopTranslation) SchemaURL() string                                          { return "" }
+func (nopTranslation) SupportedVersion(_ *Version) bool                           { return false }
+func (nopTranslation) ApplyAllResourceChanges(_ pcommon.Resource, _ *Version)     {}
+func (nopTranslation) ApplyScopeLogChanges(_ plog.ScopeLogs, _ *Version)          {}
+func (nopTranslation) ApplyScopeMetricChanges(_ pmetric.ScopeMetrics, _ *Version) {}
+func (nopTranslation) ApplyScopeSpanChanges(_ ptrace.ScopeSpans, _ *Version)      {}
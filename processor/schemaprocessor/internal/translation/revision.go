@@ -0,0 +1,285 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/schema/v1.0/ast"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+)
+
+type spanChange struct {
+	spans migrate.Condition
+	attrs *migrate.AttributeChangeSet
+}
+
+type spanEventChange struct {
+	names  *migrate.SignalNameChange
+	spans  migrate.Condition
+	events migrate.Condition
+	attrs  *migrate.AttributeChangeSet
+}
+
+type metricChange struct {
+	names   *migrate.SignalNameChange
+	metrics migrate.Condition
+	attrs   *migrate.AttributeChangeSet
+}
+
+// Revision contains all the changes defined for a single version
+// of a schema file, stored in the order they are listed so that
+// they can be applied when updating, or undone in reverse when reverting.
+type Revision struct {
+	ver        *Version
+	all        []*migrate.AttributeChangeSet
+	resources  []*migrate.AttributeChangeSet
+	spans      []spanChange
+	spanEvents []spanEventChange
+	metrics    []metricChange
+	logs       []*migrate.AttributeChangeSet
+}
+
+// NewRevision converts the schema file definition of a version
+// into the set of changes that can be applied to pdata.
+func NewRevision(ver *Version, def ast.VersionDef) *Revision {
+	r := &Revision{ver: ver}
+	for _, c := range def.All.Changes {
+		if c.RenameAttributes != nil {
+			r.all = append(r.all, migrate.NewAttributeChangeSet(*c.RenameAttributes))
+		}
+	}
+	for _, c := range def.Resources.Changes {
+		if c.RenameAttributes != nil {
+			r.resources = append(r.resources, migrate.NewAttributeChangeSet(*c.RenameAttributes))
+		}
+	}
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes == nil {
+			continue
+		}
+		names := make([]string, 0, len(c.RenameAttributes.ApplyToSpans))
+		for _, name := range c.RenameAttributes.ApplyToSpans {
+			names = append(names, string(name))
+		}
+		r.spans = append(r.spans, spanChange{
+			spans: migrate.NewCondition(names...),
+			attrs: migrate.NewAttributeChangeSet(c.RenameAttributes.AttributeMap),
+		})
+	}
+	for _, c := range def.SpanEvents.Changes {
+		if c.RenameEvents != nil {
+			r.spanEvents = append(r.spanEvents, spanEventChange{
+				names: migrate.NewSignalNameChange(c.RenameEvents.EventNameMap),
+			})
+		}
+		if c.RenameAttributes != nil {
+			spans := make([]string, 0, len(c.RenameAttributes.ApplyToSpans))
+			for _, name := range c.RenameAttributes.ApplyToSpans {
+				spans = append(spans, string(name))
+			}
+			events := make([]string, 0, len(c.RenameAttributes.ApplyToEvents))
+			for _, name := range c.RenameAttributes.ApplyToEvents {
+				events = append(events, string(name))
+			}
+			r.spanEvents = append(r.spanEvents, spanEventChange{
+				spans:  migrate.NewCondition(spans...),
+				events: migrate.NewCondition(events...),
+				attrs:  migrate.NewAttributeChangeSet(c.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, c := range def.Metrics.Changes {
+		if len(c.RenameMetrics) > 0 {
+			mappings := make(map[string]string, len(c.RenameMetrics))
+			for from, to := range c.RenameMetrics {
+				mappings[string(from)] = string(to)
+			}
+			r.metrics = append(r.metrics, metricChange{
+				names: migrate.NewSignalNameChange(mappings),
+			})
+		}
+		if c.RenameAttributes != nil {
+			names := make([]string, 0, len(c.RenameAttributes.ApplyToMetrics))
+			for _, name := range c.RenameAttributes.ApplyToMetrics {
+				names = append(names, string(name))
+			}
+			r.metrics = append(r.metrics, metricChange{
+				metrics: migrate.NewCondition(names...),
+				attrs:   migrate.NewAttributeChangeSet(c.RenameAttributes.AttributeMap),
+			})
+		}
+	}
+	for _, c := range def.Logs.Changes {
+		if c.RenameAttributes != nil {
+			r.logs = append(r.logs, migrate.NewAttributeChangeSet(c.RenameAttributes.AttributeMap))
+		}
+	}
+	return r
+}
+
+// Version returns the schema version that the revision describes.
+func (r *Revision) Version() *Version {
+	return r.ver
+}
+
+func (r *Revision) applyResource(res pcommon.Resource, update bool) {
+	if update {
+		applyAttributeChanges(res.Attributes(), r.all, true)
+		applyAttributeChanges(res.Attributes(), r.resources, true)
+		return
+	}
+	applyAttributeChanges(res.Attributes(), r.resources, false)
+	applyAttributeChanges(res.Attributes(), r.all, false)
+}
+
+func (r *Revision) applyLogRecord(log plog.LogRecord, update bool) {
+	if update {
+		applyAttributeChanges(log.Attributes(), r.all, true)
+		applyAttributeChanges(log.Attributes(), r.logs, true)
+		return
+	}
+	applyAttributeChanges(log.Attributes(), r.logs, false)
+	applyAttributeChanges(log.Attributes(), r.all, false)
+}
+
+func (r *Revision) applySpan(span ptrace.Span, update bool) {
+	if update {
+		applyAttributeChanges(span.Attributes(), r.all, true)
+		for _, c := range r.spans {
+			if c.spans.Matches(span.Name()) {
+				c.attrs.Apply(span.Attributes())
+			}
+		}
+	} else {
+		for i := len(r.spans) - 1; i >= 0; i-- {
+			if c := r.spans[i]; c.spans.Matches(span.Name()) {
+				c.attrs.Rollback(span.Attributes())
+			}
+		}
+		applyAttributeChanges(span.Attributes(), r.all, false)
+	}
+	for e := 0; e < span.Events().Len(); e++ {
+		r.applySpanEvent(span.Name(), span.Events().At(e), update)
+	}
+}
+
+func (r *Revision) applySpanEvent(spanName string, event ptrace.SpanEvent, update bool) {
+	apply := func(c spanEventChange) {
+		if c.names != nil {
+			if update {
+				c.names.Apply(event)
+			} else {
+				c.names.Rollback(event)
+			}
+		}
+		if c.attrs != nil && c.spans.Matches(spanName) && c.events.Matches(event.Name()) {
+			if update {
+				c.attrs.Apply(event.Attributes())
+			} else {
+				c.attrs.Rollback(event.Attributes())
+			}
+		}
+	}
+	if update {
+		applyAttributeChanges(event.Attributes(), r.all, true)
+		for _, c := range r.spanEvents {
+			apply(c)
+		}
+		return
+	}
+	for i := len(r.spanEvents) - 1; i >= 0; i-- {
+		apply(r.spanEvents[i])
+	}
+	applyAttributeChanges(event.Attributes(), r.all, false)
+}
+
+func (r *Revision) applyMetric(metric pmetric.Metric, update bool) {
+	apply := func(c metricChange) {
+		if c.names != nil {
+			if update {
+				c.names.Apply(metric)
+			} else {
+				c.names.Rollback(metric)
+			}
+		}
+		if c.attrs != nil && c.metrics.Matches(metric.Name()) {
+			forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+				if update {
+					c.attrs.Apply(attrs)
+				} else {
+					c.attrs.Rollback(attrs)
+				}
+			})
+		}
+	}
+	if update {
+		forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+			applyAttributeChanges(attrs, r.all, true)
+		})
+		for _, c := range r.metrics {
+			apply(c)
+		}
+		return
+	}
+	for i := len(r.metrics) - 1; i >= 0; i-- {
+		apply(r.metrics[i])
+	}
+	forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+		applyAttributeChanges(attrs, r.all, false)
+	})
+}
+
+// applyAttributeChanges applies the changes in the order they were defined
+// when updating, and in reverse order when reverting.
+func applyAttributeChanges(attrs pcommon.Map, changes []*migrate.AttributeChangeSet, update bool) {
+	if update {
+		for _, c := range changes {
+			c.Apply(attrs)
+		}
+		return
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		changes[i].Rollback(attrs)
+	}
+}
+
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.0"
+)
+
+// Translation defines the complete abstraction of schema translation file
+// that is defined as part of the https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.0.0/
+// Each instance of Translation is "Target Aware", meaning that given a schemaURL as an input
+// it will convert from the given input, to the configured target.
+type Translation interface {
+	// SchemaURL is the schema identifier that all signals are converted to.
+	SchemaURL() string
+
+	// SupportedVersion checks to see if the provided version is defined as part
+	// of this translation since it is useful to know it the translation is missing
+	// updates that could be missing.
+	SupportedVersion(v *Version) bool
+
+	// ApplyAllResourceChanges will modify the resource attributes
+	// from the provided version to the target version.
+	ApplyAllResourceChanges(resource pcommon.Resource, from *Version)
+
+	// ApplyScopeLogChanges will modify each of the log records
+	// from the provided version to the target version.
+	ApplyScopeLogChanges(scope plog.ScopeLogs, from *Version)
+
+	// ApplyScopeMetricChanges will modify each of the metrics and their data points
+	// from the provided version to the target version.
+	ApplyScopeMetricChanges(scope pmetric.ScopeMetrics, from *Version)
+
+	// ApplyScopeSpanChanges will modify each of the spans and their events
+	// from the provided version to the target version.
+	ApplyScopeSpanChanges(scope ptrace.ScopeSpans, from *Version)
+}
+
+type translator struct {
+	schemaURL string
+	target    *Version
+	// revisions are sorted in ascending version order
+	revisions []*Revision
+}
+
+var _ Translation = (*translator)(nil)
+
+// NewTranslationFromReader parses the schema file content and returns a translation
+// that will convert any version defined inside the file to the target schemaURL.
+func NewTranslationFromReader(targetSchemaURL string, content io.Reader) (Translation, error) {
+	_, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	def, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	t := &translator{
+		schemaURL: targetSchemaURL,
+		target:    target,
+		revisions: make([]*Revision, 0, len(def.Versions)),
+	}
+	for key, changes := range def.Versions {
+		ver, err := NewVersion(string(key))
+		if err != nil {
+			return nil, fmt.Errorf("schema file version %q: %w", key, err)
+		}
+		t.revisions = append(t.revisions, NewRevision(ver, changes))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].Version().LessThan(t.revisions[j].Version())
+	})
+	if !t.SupportedVersion(target) {
+		return nil, fmt.Errorf("target version %s is not defined in schema file: %w", target, ErrInvalidVersion)
+	}
+	return t, nil
+}
+
+func (t *translator) SchemaURL() string {
+	return t.schemaURL
+}
+
+func (t *translator) SupportedVersion(v *Version) bool {
+	i := sort.Search(len(t.revisions), func(i int) bool {
+		return !t.revisions[i].Version().LessThan(v)
+	})
+	return i < len(t.revisions) && t.revisions[i].Version().Equal(v)
+}
+
+func (t *translator) ApplyAllResourceChanges(resource pcommon.Resource, from *Version) {
+	t.walk(from, func(rev *Revision, update bool) {
+		rev.applyResource(resource, update)
+	})
+}
+
+func (t *translator) ApplyScopeLogChanges(scope plog.ScopeLogs, from *Version) {
+	t.walk(from, func(rev *Revision, update bool) {
+		for i := 0; i < scope.LogRecords().Len(); i++ {
+			rev.applyLogRecord(scope.LogRecords().At(i), update)
+		}
+	})
+}
+
+func (t *translator) ApplyScopeMetricChanges(scope pmetric.ScopeMetrics, from *Version) {
+	t.walk(from, func(rev *Revision, update bool) {
+		for i := 0; i < scope.Metrics().Len(); i++ {
+			rev.applyMetric(scope.Metrics().At(i), update)
+		}
+	})
+}
+
+func (t *translator) ApplyScopeSpanChanges(scope ptrace.ScopeSpans, from *Version) {
+	t.walk(from, func(rev *Revision, update bool) {
+		for i := 0; i < scope.Spans().Len(); i++ {
+			rev.applySpan(scope.Spans().At(i), update)
+		}
+	})
+}
+
+// walk calls fn with each revision that sits between from and the target version.
+// When updating, revisions are visited in ascending order starting after from, and when
+// reverting, they are visited in descending order starting at from.
+func (t *translator) walk(from *Version, fn func(rev *Revision, update bool)) {
+	switch from.Compare(t.target) {
+	case Update:
+		for _, rev := range t.revisions {
+			if rev.Version().GreaterThan(from) && !rev.Version().GreaterThan(t.target) {
+				fn(rev, true)
+			}
+		}
+	case Revert:
+		for i := len(t.revisions) - 1; i >= 0; i-- {
+			rev := t.revisions[i]
+			if !rev.Version().GreaterThan(from) && rev.Version().GreaterThan(t.target) {
+				fn(rev, false)
+			}
+		}
+	}
+}
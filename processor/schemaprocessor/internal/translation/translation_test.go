@@ -0,0 +1,194 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTranslation(t *testing.T, target string) Translation {
+	f, err := os.Open("testdata/schema.yml")
+	require.NoError(t, err, "Must not error opening test schema")
+	t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+	tn, err := NewTranslationFromReader(target, f)
+	require.NoError(t, err, "Must not error when creating translation")
+	return tn
+}
+
+func TestTranslationSupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslation(t, "https://example.com/schemas/1.1.0")
+	assert.Equal(t, "https://example.com/schemas/1.1.0", tn.SchemaURL())
+
+	for _, v := range []*Version{{1, 0, 0}, {1, 1, 0}, {1, 2, 0}} {
+		assert.True(t, tn.SupportedVersion(v), "Must support version %s", v)
+	}
+	for _, v := range []*Version{{0, 9, 0}, {1, 0, 1}, {1, 3, 0}} {
+		assert.False(t, tn.SupportedVersion(v), "Must not support version %s", v)
+	}
+}
+
+func TestTranslationInvalidTarget(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("testdata/schema.yml")
+	require.NoError(t, err, "Must not error opening test schema")
+	defer f.Close()
+
+	_, err = NewTranslationFromReader("https://example.com/schemas/1.9.0", f)
+	assert.ErrorIs(t, err, ErrInvalidVersion, "Must error when the target is not defined in the schema")
+}
+
+func TestTranslationResource(t *testing.T) {
+	t.Parallel()
+
+	original := map[string]interface{}{
+		"k8s.pod.name":           "pod-0",
+		"telemetry.auto.version": "1.0.0",
+	}
+	updated := map[string]interface{}{
+		"kubernetes.pod.name":          "pod-0",
+		"telemetry.auto_instr.version": "1.0.0",
+	}
+
+	res := pcommon.NewResource()
+	pcommon.NewMapFromRaw(original).CopyTo(res.Attributes())
+
+	newTestTranslation(t, "https://example.com/schemas/1.2.0").ApplyAllResourceChanges(res, &Version{1, 0, 0})
+	assert.Equal(t, updated, res.Attributes().AsRaw(), "Must have updated the resource")
+
+	newTestTranslation(t, "https://example.com/schemas/1.0.0").ApplyAllResourceChanges(res, &Version{1, 2, 0})
+	assert.Equal(t, original, res.Attributes().AsRaw(), "Must have reverted the resource")
+}
+
+func TestTranslationPartialUpdate(t *testing.T) {
+	t.Parallel()
+
+	res := pcommon.NewResource()
+	res.Attributes().InsertString("k8s.pod.name", "pod-0")
+	res.Attributes().InsertString("telemetry.auto.version", "1.0.0")
+
+	newTestTranslation(t, "https://example.com/schemas/1.1.0").ApplyAllResourceChanges(res, &Version{1, 0, 0})
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":                 "pod-0",
+		"telemetry.auto_instr.version": "1.0.0",
+	}, res.Attributes().AsRaw(), "Must only apply changes up to the target version")
+}
+
+func TestTranslationSpans(t *testing.T) {
+	t.Parallel()
+
+	ss := ptrace.NewScopeSpans()
+	for _, name := range []string{"HTTP GET", "HTTP POST"} {
+		span := ss.Spans().AppendEmpty()
+		span.SetName(name)
+		span.Attributes().InsertString("peer.service", "backend")
+		span.Attributes().InsertString("k8s.pod.name", "pod-0")
+		event := span.Events().AppendEmpty()
+		event.SetName("stacktrace")
+		event.Attributes().InsertString("peer.service", "backend")
+	}
+	original := ptrace.NewScopeSpans()
+	ss.CopyTo(original)
+
+	newTestTranslation(t, "https://example.com/schemas/1.2.0").ApplyScopeSpanChanges(ss, &Version{1, 0, 0})
+
+	get := ss.Spans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"peer.service.name":   "backend",
+		"kubernetes.pod.name": "pod-0",
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", get.Events().At(0).Name())
+	assert.Equal(t, map[string]interface{}{"peer.service.name": "backend"}, get.Events().At(0).Attributes().AsRaw())
+
+	post := ss.Spans().At(1)
+	assert.Equal(t, map[string]interface{}{
+		"peer.service":        "backend",
+		"kubernetes.pod.name": "pod-0",
+	}, post.Attributes().AsRaw(), "Must only rename span attributes for matching span names")
+
+	newTestTranslation(t, "https://example.com/schemas/1.0.0").ApplyScopeSpanChanges(ss, &Version{1, 2, 0})
+	for i := 0; i < ss.Spans().Len(); i++ {
+		span, expect := ss.Spans().At(i), original.Spans().At(i)
+		assert.Equal(t, expect.Attributes().AsRaw(), span.Attributes().AsRaw(), "Must revert the span attributes")
+		assert.Equal(t, expect.Events().At(0).Name(), span.Events().At(0).Name(), "Must revert the event name")
+		assert.Equal(t, expect.Events().At(0).Attributes().AsRaw(), span.Events().At(0).Attributes().AsRaw(), "Must revert the event attributes")
+	}
+}
+
+func TestTranslationMetrics(t *testing.T) {
+	t.Parallel()
+
+	sm := pmetric.NewScopeMetrics()
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("container.cpu.usage.total")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("status", "idle")
+	dp.Attributes().InsertString("k8s.pod.name", "pod-0")
+
+	h := sm.Metrics().AppendEmpty()
+	h.SetName("request.duration")
+	h.SetDataType(pmetric.MetricDataTypeHistogram)
+	h.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("status", "ok")
+
+	original := pmetric.NewScopeMetrics()
+	sm.CopyTo(original)
+
+	newTestTranslation(t, "https://example.com/schemas/1.2.0").ApplyScopeMetricChanges(sm, &Version{1, 1, 0})
+	assert.Equal(t, "cpu.usage.total", m.Name())
+	assert.Equal(t, map[string]interface{}{
+		"state":               "idle",
+		"kubernetes.pod.name": "pod-0",
+	}, m.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"status": "ok"}, h.Histogram().DataPoints().At(0).Attributes().AsRaw(),
+		"Must only rename attributes of matching metrics",
+	)
+
+	newTestTranslation(t, "https://example.com/schemas/1.1.0").ApplyScopeMetricChanges(sm, &Version{1, 2, 0})
+	assert.Equal(t, "container.cpu.usage.total", m.Name(), "Must revert the metric name")
+	assert.Equal(t,
+		original.Metrics().At(0).Sum().DataPoints().At(0).Attributes().AsRaw(),
+		m.Sum().DataPoints().At(0).Attributes().AsRaw(),
+		"Must revert the data point attributes",
+	)
+}
+
+func TestTranslationLogs(t *testing.T) {
+	t.Parallel()
+
+	sl := plog.NewScopeLogs()
+	sl.LogRecords().AppendEmpty().Attributes().InsertString("process.executable_name", "otelcol")
+
+	newTestTranslation(t, "https://example.com/schemas/1.1.0").ApplyScopeLogChanges(sl, &Version{1, 0, 0})
+	assert.Equal(t, map[string]interface{}{
+		"process.executable.name": "otelcol",
+	}, sl.LogRecords().At(0).Attributes().AsRaw())
+
+	newTestTranslation(t, "https://example.com/schemas/1.1.0").ApplyScopeLogChanges(sl, &Version{1, 1, 0})
+	assert.Equal(t, map[string]interface{}{
+		"process.executable.name": "otelcol",
+	}, sl.LogRecords().At(0).Attributes().AsRaw(), "Must not modify signals already at the target")
+}
@@ -20,15 +20,22 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets   []string
+	prefetch  []string
+	log       *zap.Logger
+	manager   translation.Manager
+	client    confighttp.HTTPClientSettings
+	telemetry component.TelemetrySettings
 }
 
 func newTransformer(
@@ -40,29 +47,147 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	m, err := translation.NewManager(cfg.Targets, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:       set.Logger,
+		targets:   cfg.Targets,
+		prefetch:  cfg.Prefetch,
+		manager:   m,
+		client:    cfg.HTTPClientSettings,
+		telemetry: set.TelemetrySettings,
 	}, nil
 }
 
 func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLog.SchemaUrl()
+		if tn, from, ok := t.requestTranslation(ctx, resourceSchemaURL); ok {
+			tn.ApplyAllResourceChanges(rLog.Resource(), from)
+			rLog.SetSchemaUrl(tn.SchemaURL())
+		}
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			scope := rLog.ScopeLogs().At(sl)
+			schemaURL := scope.SchemaUrl()
+			if schemaURL == "" {
+				schemaURL = resourceSchemaURL
+			}
+			tn, from, ok := t.requestTranslation(ctx, schemaURL)
+			if !ok {
+				continue
+			}
+			tn.ApplyScopeLogChanges(scope, from)
+			if scope.SchemaUrl() != "" {
+				scope.SetSchemaUrl(tn.SchemaURL())
+			}
+		}
+	}
 	return ld, nil
 }
 
 func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetric.SchemaUrl()
+		if tn, from, ok := t.requestTranslation(ctx, resourceSchemaURL); ok {
+			tn.ApplyAllResourceChanges(rMetric.Resource(), from)
+			rMetric.SetSchemaUrl(tn.SchemaURL())
+		}
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			scope := rMetric.ScopeMetrics().At(sm)
+			schemaURL := scope.SchemaUrl()
+			if schemaURL == "" {
+				schemaURL = resourceSchemaURL
+			}
+			tn, from, ok := t.requestTranslation(ctx, schemaURL)
+			if !ok {
+				continue
+			}
+			tn.ApplyScopeMetricChanges(scope, from)
+			if scope.SchemaUrl() != "" {
+				scope.SetSchemaUrl(tn.SchemaURL())
+			}
+		}
+	}
 	return md, nil
 }
 
 func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rs := 0; rs < td.ResourceSpans().Len(); rs++ {
+		rSpan := td.ResourceSpans().At(rs)
+		resourceSchemaURL := rSpan.SchemaUrl()
+		if tn, from, ok := t.requestTranslation(ctx, resourceSchemaURL); ok {
+			tn.ApplyAllResourceChanges(rSpan.Resource(), from)
+			rSpan.SetSchemaUrl(tn.SchemaURL())
+		}
+		for ss := 0; ss < rSpan.ScopeSpans().Len(); ss++ {
+			scope := rSpan.ScopeSpans().At(ss)
+			schemaURL := scope.SchemaUrl()
+			if schemaURL == "" {
+				schemaURL = resourceSchemaURL
+			}
+			tn, from, ok := t.requestTranslation(ctx, schemaURL)
+			if !ok {
+				continue
+			}
+			tn.ApplyScopeSpanChanges(scope, from)
+			if scope.SchemaUrl() != "" {
+				scope.SetSchemaUrl(tn.SchemaURL())
+			}
+		}
+	}
 	return td, nil
 }
 
+// requestTranslation returns the translation for the schemaURL along with
+// the version that the signal is currently in. Signals that do not define a schemaURL,
+// or that are not part of a configured target family, are left untouched.
+func (t transformer) requestTranslation(ctx context.Context, schemaURL string) (translation.Translation, *translation.Version, bool) {
+	if schemaURL == "" {
+		return nil, nil, false
+	}
+	_, from, err := translation.GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		t.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, nil, false
+	}
+	tn, err := t.manager.RequestTranslation(ctx, schemaURL)
+	if err != nil {
+		// The manager logs each failed lookup once, the same error is then
+		// returned for every signal until the lookup is retried.
+		t.log.Debug("Leaving signal untranslated", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, nil, false
+	}
+	if !tn.SupportedVersion(from) {
+		return nil, nil, false
+	}
+	return tn, from, true
+}
+
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	var extensions map[config.ComponentID]component.Extension
+	if host != nil {
+		extensions = host.GetExtensions()
+	}
+	client, err := t.client.ToClient(extensions, t.telemetry)
+	if err != nil {
+		return err
+	}
+	if err := t.manager.SetProviders(translation.NewHTTPProvider(client)); err != nil {
+		return err
+	}
+	for _, schemaURL := range append(append([]string(nil), t.targets...), t.prefetch...) {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		if _, err := t.manager.RequestTranslation(ctx, schemaURL); err != nil {
+			// Failing to prefetch is not fatal since the schema
+			// will be requested again once a signal makes use of it.
+			t.log.Debug("Unable to prefetch schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -110,3 +111,79 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerSchemaTranslation(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(SchemaHandler(t)))
+	t.Cleanup(s.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{s.URL + "/schemas/1.1.0"}
+
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), nil), "Must not error when starting")
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl(s.URL + "/schemas/1.0.0")
+		rm.Resource().Attributes().InsertString("k8s.pod.name", "pod-0")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("container.cpu.usage.total")
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		m.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("k8s.node.name", "node-0")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		rm = out.ResourceMetrics().At(0)
+		assert.Equal(t, s.URL+"/schemas/1.1.0", rm.SchemaUrl(), "Must update the schema url")
+		assert.Equal(t, map[string]interface{}{"kubernetes.pod.name": "pod-0"}, rm.Resource().Attributes().AsRaw())
+		m = rm.ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, "cpu.usage.total", m.Name(), "Must rename the metric")
+		assert.Equal(t, map[string]interface{}{"kubernetes.node.name": "node-0"}, m.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(s.URL + "/schemas/1.1.0")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl(s.URL + "/schemas/1.0.0")
+		span := ss.Spans().AppendEmpty()
+		span.SetName("HTTP GET")
+		span.Attributes().InsertString("peer.service", "backend")
+		span.Events().AppendEmpty().SetName("stacktrace")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		ss = out.ResourceSpans().At(0).ScopeSpans().At(0)
+		assert.Equal(t, s.URL+"/schemas/1.1.0", ss.SchemaUrl(), "Must update the scope schema url")
+		assert.Equal(t, map[string]interface{}{"peer.service.name": "backend"}, ss.Spans().At(0).Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", ss.Spans().At(0).Events().At(0).Name())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://unknown.example.com/schemas/1.0.0")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+
+		rl = out.ResourceLogs().At(0)
+		assert.Equal(t, "https://unknown.example.com/schemas/1.0.0", rl.SchemaUrl(), "Must not modify unmatched schema families")
+		assert.Equal(t,
+			map[string]interface{}{"process.executable_name": "otelcol"},
+			rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw(),
+		)
+	})
+}
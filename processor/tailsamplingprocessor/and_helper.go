@@ -54,6 +54,9 @@ func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tcfCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tcfCfg.Conditions, tcfCfg.Match)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -48,6 +48,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// TQLCondition sample traces whose spans match conditions written in the telemetry query language.
+	TQLCondition PolicyType = "tql_condition"
 )
 
 // SubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -74,6 +76,8 @@ type SubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state policy evaluator.
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for tql_condition policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 type AndSubPolicyCfg struct {
@@ -97,6 +101,8 @@ type AndSubPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for trace_state filter sampling policy evaluator
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for tql_condition filter sampling policy evaluator.
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 type TraceStateCfg struct {
@@ -106,6 +112,17 @@ type TraceStateCfg struct {
 	Values []string `mapstructure:"values"`
 }
 
+// TQLConditionCfg holds the configurable settings to create a telemetry query language
+// condition filter sampling policy evaluator.
+type TQLConditionCfg struct {
+	// Conditions are boolean expressions evaluated against each span of a trace.
+	// A span matches when any of the conditions is true.
+	Conditions []string `mapstructure:"conditions"`
+	// Match is either "any", to sample a trace when at least one span matches,
+	// or "all", to sample a trace when all of its spans match. Defaults to "any".
+	Match string `mapstructure:"match"`
+}
+
 type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
@@ -151,6 +168,8 @@ type PolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for defining tql_condition policy
+	TQLConditionCfg TQLConditionCfg `mapstructure:"tql_condition"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -223,6 +242,23 @@ type SpanCountCfg struct {
 	MinSpans int32 `mapstructure:"min_spans"`
 }
 
+// DecisionCacheCfg holds the configurable settings of the caches keeping the
+// sampling decisions of traces that were already released from memory.
+type DecisionCacheCfg struct {
+	// SampledCacheSize is the number of sampled trace IDs to remember, so that spans
+	// arriving after their trace was released are sampled as well. Set to 0 to disable.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the number of non-sampled trace IDs to remember, so that
+	// spans arriving after their trace was released are dropped. Set to 0 to disable.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// StorageID is the ID of the storage extension used to persist the caches
+	// between restarts.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+	// FlushInterval is how often the caches are saved to the storage extension, so
+	// that recent decisions survive a crash as well. Set to 0 to only save them on shutdown.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -238,4 +274,7 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache holds the settings of the caches of recent sampling decisions,
+	// which are consulted before a trace is buffered.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
 }
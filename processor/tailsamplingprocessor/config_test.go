@@ -43,6 +43,11 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheCfg{
+				SampledCacheSize:    500,
+				NonSampledCacheSize: 1000,
+				FlushInterval:       30 * time.Second,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -88,6 +93,14 @@ func TestLoadConfig(t *testing.T) {
 					Type:          TraceState,
 					TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 				},
+				{
+					Name: "test-policy-10",
+					Type: TQLCondition,
+					TQLConditionCfg: TQLConditionCfg{
+						Match:      "all",
+						Conditions: []string{`trace.root_span.name == "GET /checkout"`, `kind == SPAN_KIND_SERVER`},
+					},
+				},
 				{
 					Name: "and-policy-1",
 					Type: And,
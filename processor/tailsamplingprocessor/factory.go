@@ -53,6 +53,9 @@ func createDefaultConfig() config.Processor {
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
 		DecisionWait:      30 * time.Second,
 		NumTraces:         50000,
+		DecisionCache: DecisionCacheCfg{
+			FlushInterval: time.Minute,
+		},
 	}
 }
 
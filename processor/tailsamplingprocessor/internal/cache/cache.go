@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"container/list"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Cache is a bounded set of trace IDs for which a sampling decision was made.
+type Cache interface {
+	// Contains reports whether the trace ID is in the cache.
+	Contains(id pcommon.TraceID) bool
+	// Add adds the trace ID to the cache, evicting the oldest entry when the cache is full.
+	Add(id pcommon.TraceID)
+	// IDs returns the trace IDs in the cache, from the least to the most recently used.
+	IDs() []pcommon.TraceID
+}
+
+type lruCache struct {
+	sync.Mutex
+	size    int
+	ll      *list.List
+	entries map[pcommon.TraceID]*list.Element
+}
+
+var _ Cache = (*lruCache)(nil)
+
+// NewLRU returns a Cache keeping the size most recently used trace IDs.
+// A Cache that keeps nothing is returned if size is not positive.
+func NewLRU(size int) Cache {
+	if size <= 0 {
+		return NewNop()
+	}
+	return &lruCache{
+		size:    size,
+		ll:      list.New(),
+		entries: make(map[pcommon.TraceID]*list.Element, size),
+	}
+}
+
+func (c *lruCache) Contains(id pcommon.TraceID) bool {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[id]; ok {
+		c.ll.MoveToFront(e)
+		return true
+	}
+	return false
+}
+
+func (c *lruCache) Add(id pcommon.TraceID) {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[id]; ok {
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.entries[id] = c.ll.PushFront(id)
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(pcommon.TraceID))
+	}
+}
+
+func (c *lruCache) IDs() []pcommon.TraceID {
+	c.Lock()
+	defer c.Unlock()
+	ids := make([]pcommon.TraceID, 0, c.ll.Len())
+	for e := c.ll.Back(); e != nil; e = e.Prev() {
+		ids = append(ids, e.Value.(pcommon.TraceID))
+	}
+	return ids
+}
+
+type nopCache struct{}
+
+var _ Cache = (*nopCache)(nil)
+
+// NewNop returns a Cache that keeps nothing.
+func NewNop() Cache {
+	return nopCache{}
+}
+
+func (nopCache) Contains(pcommon.TraceID) bool {
+	return false
+}
+
+func (nopCache) Add(pcommon.TraceID) {}
+
+func (nopCache) IDs() []pcommon.TraceID {
+	return nil
+}
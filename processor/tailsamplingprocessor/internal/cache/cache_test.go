@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLRU(t *testing.T) {
+	id1 := pcommon.NewTraceID([16]byte{1})
+	id2 := pcommon.NewTraceID([16]byte{2})
+	id3 := pcommon.NewTraceID([16]byte{3})
+
+	c := NewLRU(2)
+	c.Add(id1)
+	c.Add(id2)
+	assert.True(t, c.Contains(id1))
+	assert.Equal(t, []pcommon.TraceID{id2, id1}, c.IDs())
+
+	// id2 is now the least recently used and is evicted
+	c.Add(id3)
+	assert.False(t, c.Contains(id2))
+	assert.True(t, c.Contains(id1))
+	assert.True(t, c.Contains(id3))
+	assert.Equal(t, []pcommon.TraceID{id1, id3}, c.IDs())
+
+	c.Add(id1)
+	assert.Equal(t, []pcommon.TraceID{id3, id1}, c.IDs())
+}
+
+func TestNop(t *testing.T) {
+	id := pcommon.NewTraceID([16]byte{1})
+	for _, c := range []Cache{NewNop(), NewLRU(0)} {
+		c.Add(id)
+		assert.False(t, c.Contains(id))
+		assert.Empty(t, c.IDs())
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	tqltraces "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/contexts/traces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/tql"
+)
+
+const (
+	// MatchAny samples a trace when at least one of its spans matches.
+	MatchAny = "any"
+	// MatchAll samples a trace when all of its spans match.
+	MatchAll = "all"
+)
+
+type tqlConditionFilter struct {
+	logger     *zap.Logger
+	conditions []tql.BoolExpressionEvaluator
+	matchAll   bool
+}
+
+var _ PolicyEvaluator = (*tqlConditionFilter)(nil)
+
+// NewTQLConditionFilter creates a policy evaluator that samples traces based on conditions
+// written in the telemetry query language. A span matches when any of the conditions is true.
+// Depending on match, a trace is sampled when any or all of its spans match.
+func NewTQLConditionFilter(logger *zap.Logger, conditions []string, match string) (PolicyEvaluator, error) {
+	var matchAll bool
+	switch match {
+	case "", MatchAny:
+	case MatchAll:
+		matchAll = true
+	default:
+		return nil, fmt.Errorf("unknown match %q, expected %q or %q", match, MatchAny, MatchAll)
+	}
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("at least one condition is required")
+	}
+
+	evaluators, err := tql.ParseConditions(conditions, tqlfuncs.ConditionFunctions(), parseTQLPath, tqltraces.ParseEnum)
+	if err != nil {
+		return nil, err
+	}
+	return &tqlConditionFilter{
+		logger:     logger,
+		conditions: evaluators,
+		matchAll:   matchAll,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (tcf *tqlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	info := newTraceInfo(batches)
+	matched := false
+	for _, batch := range batches {
+		rspans := batch.ResourceSpans()
+		for i := 0; i < rspans.Len(); i++ {
+			rs := rspans.At(i)
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				spans := ils.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					ctx := spanContext{
+						span:     spans.At(k),
+						il:       ils.Scope(),
+						resource: rs.Resource(),
+						trace:    info,
+					}
+					switch {
+					case tcf.matches(ctx):
+						if !tcf.matchAll {
+							return Sampled, nil
+						}
+						matched = true
+					case tcf.matchAll:
+						return NotSampled, nil
+					}
+				}
+			}
+		}
+	}
+
+	if matched {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (tcf *tqlConditionFilter) matches(ctx spanContext) bool {
+	for _, condition := range tcf.conditions {
+		if condition(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// traceInfo holds the trace level values that conditions can refer to.
+type traceInfo struct {
+	rootSpan  *spanContext
+	spanCount int64
+}
+
+func newTraceInfo(batches []ptrace.Traces) *traceInfo {
+	info := &traceInfo{}
+	for _, batch := range batches {
+		info.spanCount += int64(batch.SpanCount())
+		rspans := batch.ResourceSpans()
+		for i := 0; i < rspans.Len() && info.rootSpan == nil; i++ {
+			rs := rspans.At(i)
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len() && info.rootSpan == nil; j++ {
+				ils := ilss.At(j)
+				spans := ils.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					if spans.At(k).ParentSpanID().IsEmpty() {
+						info.rootSpan = &spanContext{
+							span:     spans.At(k),
+							il:       ils.Scope(),
+							resource: rs.Resource(),
+						}
+						break
+					}
+				}
+			}
+		}
+	}
+	return info
+}
+
+// spanContext is the tql.TransformContext conditions are evaluated against.
+type spanContext struct {
+	span     ptrace.Span
+	il       pcommon.InstrumentationScope
+	resource pcommon.Resource
+	trace    *traceInfo
+}
+
+func (ctx spanContext) GetItem() interface{} {
+	return ctx.span
+}
+
+func (ctx spanContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.il
+}
+
+func (ctx spanContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+// parseTQLPath resolves the span, resource and scope paths of the traces context, and
+// the trace level paths under "trace", such as trace.span_count or trace.root_span.name.
+func parseTQLPath(val *tql.Path) (tql.GetSetter, error) {
+	if val == nil || len(val.Fields) == 0 || val.Fields[0].Name != "trace" {
+		return tqltraces.ParsePath(val)
+	}
+
+	fields := val.Fields[1:]
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("trace requires a field")
+	}
+	switch fields[0].Name {
+	case "span_count":
+		if len(fields) == 1 {
+			return readOnlyGetter(func(ctx spanContext) interface{} {
+				return ctx.trace.spanCount
+			}), nil
+		}
+	case "root_span":
+		if len(fields) == 1 {
+			return nil, fmt.Errorf("trace.root_span requires a field")
+		}
+		getter, err := tqltraces.ParsePath(&tql.Path{Fields: fields[1:]})
+		if err != nil {
+			return nil, err
+		}
+		return readOnlyGetter(func(ctx spanContext) interface{} {
+			if ctx.trace.rootSpan == nil {
+				return nil
+			}
+			return getter.Get(*ctx.trace.rootSpan)
+		}), nil
+	}
+	return nil, fmt.Errorf("invalid trace path expression %v", val.Fields)
+}
+
+// readOnlyGetter returns a tql.GetSetter for values conditions can only read.
+func readOnlyGetter(get func(ctx spanContext) interface{}) tql.GetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			return get(ctx.(spanContext))
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {},
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestTQLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc       string
+		Conditions []string
+		Match      string
+		Decision   Decision
+	}{
+		{
+			Desc:       "any span matches",
+			Conditions: []string{`name == "child"`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "no span matches",
+			Conditions: []string{`name == "unknown"`},
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "not all spans match",
+			Conditions: []string{`name == "child"`},
+			Match:      MatchAll,
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "all spans match",
+			Conditions: []string{`resource.attributes["service.name"] == "checkout"`},
+			Match:      MatchAll,
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "any of the conditions matches",
+			Conditions: []string{`name == "unknown"`, `attributes["http.status_code"] == 500`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "enum",
+			Conditions: []string{`kind == SPAN_KIND_CLIENT`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "instrumentation scope",
+			Conditions: []string{`instrumentation_library.name == "http"`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "root span name",
+			Conditions: []string{`trace.root_span.name == "GET /checkout"`},
+			Match:      MatchAll,
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "root span name mismatch",
+			Conditions: []string{`trace.root_span.name == "GET /health"`},
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "span count",
+			Conditions: []string{`trace.span_count == 2`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "span count and span attribute",
+			Conditions: []string{`trace.span_count == 3 or (name == "child" and attributes["http.status_code"] == 500)`},
+			Decision:   Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewTQLConditionFilter(zap.NewNop(), c.Conditions, c.Match)
+			require.NoError(t, err)
+			decision, err := filter.Evaluate(pcommon.NewTraceID([16]byte{1}), newTQLTrace(true))
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestTQLConditionFilterWithoutRootSpan(t *testing.T) {
+	filter, err := NewTQLConditionFilter(zap.NewNop(), []string{`trace.root_span.name == nil`}, MatchAny)
+	require.NoError(t, err)
+	decision, err := filter.Evaluate(pcommon.NewTraceID([16]byte{1}), newTQLTrace(false))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestTQLConditionFilterInvalid(t *testing.T) {
+	cases := []struct {
+		Desc       string
+		Conditions []string
+		Match      string
+	}{
+		{
+			Desc:       "unknown match",
+			Conditions: []string{`name == "child"`},
+			Match:      "some",
+		},
+		{
+			Desc: "no conditions",
+		},
+		{
+			Desc:       "invalid syntax",
+			Conditions: []string{`name ==`},
+		},
+		{
+			Desc:       "unknown path",
+			Conditions: []string{`unknown == "child"`},
+		},
+		{
+			Desc:       "trace without field",
+			Conditions: []string{`trace == nil`},
+		},
+		{
+			Desc:       "root span without field",
+			Conditions: []string{`trace.root_span == nil`},
+		},
+		{
+			Desc:       "unknown trace field",
+			Conditions: []string{`trace.duration == 1`},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			_, err := NewTQLConditionFilter(zap.NewNop(), c.Conditions, c.Match)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func newTQLTrace(withRoot bool) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ils := rs.ScopeSpans().AppendEmpty()
+	ils.Scope().SetName("http")
+
+	root := ils.Spans().AppendEmpty()
+	root.SetName("GET /checkout")
+	root.SetKind(ptrace.SpanKindServer)
+	root.SetSpanID(pcommon.NewSpanID([8]byte{1}))
+	if !withRoot {
+		root.SetParentSpanID(pcommon.NewSpanID([8]byte{9}))
+	}
+
+	child := ils.Spans().AppendEmpty()
+	child.SetName("child")
+	child.SetKind(ptrace.SpanKindClient)
+	child.SetSpanID(pcommon.NewSpanID([8]byte{2}))
+	child.SetParentSpanID(pcommon.NewSpanID([8]byte{1}))
+	child.Attributes().InsertInt("http.status_code", 500)
+
+	return &TraceData{
+		ReceivedBatches: []ptrace.Traces{traces},
+	}
+}
@@ -24,13 +24,17 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -60,6 +64,15 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	id                config.ComponentID
+	sampledIDCache    cache.Cache
+	nonSampledIDCache cache.Cache
+	storageID         *config.ComponentID
+	storageClient     storage.Client
+	flushInterval     time.Duration
+	flushStop         chan struct{}
+	flushDone         chan struct{}
 }
 
 const (
@@ -108,6 +121,12 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		id:                cfg.ID(),
+		sampledIDCache:    cache.NewLRU(cfg.DecisionCache.SampledCacheSize),
+		nonSampledIDCache: cache.NewLRU(cfg.DecisionCache.NonSampledCacheSize),
+		storageID:         cfg.DecisionCache.StorageID,
+		flushInterval:     cfg.DecisionCache.FlushInterval,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -150,6 +169,9 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case TQLCondition:
+		tcfCfg := cfg.TQLConditionCfg
+		return sampling.NewTQLConditionFilter(logger, tcfCfg.Conditions, tcfCfg.Match)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -175,7 +197,16 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
+		evaluateErrorCount := metrics.evaluateErrorCount
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
+		switch {
+		case decision == sampling.Sampled:
+			tsp.sampledIDCache.Add(id)
+		case decision == sampling.NotSampled && metrics.evaluateErrorCount == evaluateErrorCount:
+			// a trace that wasn't sampled because a policy failed to evaluate it isn't
+			// remembered, so that its late spans get evaluated again
+			tsp.nonSampledIDCache.Add(id)
+		}
 
 		// Sampled or not, remove the batches
 		trace.Lock()
@@ -325,6 +356,18 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		// Spans of a trace that was already released follow the decision made for it
+		if tsp.sampledIDCache.Contains(id) {
+			traceTd := prepareTraceBatch(resourceSpans, spans)
+			if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+				tsp.logger.Warn("Error sending late arrived spans to destination", zap.Error(err))
+			}
+			continue
+		}
+		if tsp.nonSampledIDCache.Contains(id) {
+			continue
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -408,16 +451,37 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
 	tsp.policyTicker.Start(tsp.tickerFrequency)
+	client, err := getStorageClient(ctx, host, tsp.storageID, tsp.id)
+	if err != nil {
+		return err
+	}
+	tsp.storageClient = client
+	if err = tsp.loadDecisionCaches(ctx); err != nil {
+		return err
+	}
+	if tsp.storageID != nil && tsp.flushInterval > 0 {
+		tsp.flushStop = make(chan struct{})
+		tsp.flushDone = make(chan struct{})
+		go tsp.periodicallySaveDecisionCaches()
+	}
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	if tsp.storageClient == nil {
+		return nil
+	}
+	if tsp.flushStop != nil {
+		close(tsp.flushStop)
+		<-tsp.flushDone
+		tsp.flushStop = nil
+	}
+	return multierr.Combine(tsp.saveDecisionCaches(ctx), tsp.storageClient.Close(ctx))
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -32,6 +32,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -198,6 +199,9 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNop(),
+		nonSampledIDCache: cache.NewNop(),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -259,6 +263,9 @@ func TestSamplingPolicyInvertSampled(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNop(),
+		nonSampledIDCache: cache.NewNop(),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -327,6 +334,9 @@ func TestSamplingMultiplePolicies(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNop(),
+		nonSampledIDCache: cache.NewNop(),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -390,6 +400,9 @@ func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNop(),
+		nonSampledIDCache: cache.NewNop(),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -453,6 +466,9 @@ func TestSamplingPolicyDecisionInvertNotSampled(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNop(),
+		nonSampledIDCache: cache.NewNop(),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -516,6 +532,9 @@ func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNop(),
+		nonSampledIDCache: cache.NewNop(),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -570,6 +589,105 @@ func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	}
 }
 
+func TestLateSpansFollowCachedDecision(t *testing.T) {
+	const maxSize = 100
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    &manualTTicker{},
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewLRU(maxSize),
+		nonSampledIDCache: cache.NewLRU(maxSize),
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	sampledID := pcommon.NewTraceID([16]byte{1})
+	nonSampledID := pcommon.NewTraceID([16]byte{2})
+
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, msp.SpanCount())
+
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(nonSampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, msp.SpanCount())
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+
+	// Release both traces from memory, as if more traces had arrived
+	tsp.dropTrace(sampledID, time.Now())
+	tsp.dropTrace(nonSampledID, time.Now())
+
+	// Late spans follow the original decisions without being evaluated again
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(nonSampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 2, msp.SpanCount())
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+	require.EqualValues(t, 0, tsp.numTracesOnMap.Load())
+}
+
+func TestFailedEvaluationIsNotCached(t *testing.T) {
+	const maxSize = 100
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    &manualTTicker{},
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewLRU(maxSize),
+		nonSampledIDCache: cache.NewLRU(maxSize),
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.NewTraceID([16]byte{1})
+
+	mpe.NextError = errors.New("mock policy error")
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 0, msp.SpanCount())
+	require.False(t, tsp.nonSampledIDCache.Contains(traceID), "A trace that failed to be evaluated must not be remembered as not sampled")
+
+	// Late spans are evaluated again
+	tsp.dropTrace(traceID, time.Now())
+	mpe.NextError = nil
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, msp.SpanCount())
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+}
+
 func collectSpanIds(trace *ptrace.Traces) []pcommon.SpanID {
 	spanIDs := make([]pcommon.SpanID, 0)
 
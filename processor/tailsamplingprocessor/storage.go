@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+)
+
+const (
+	sampledIDsKey    = "sampled_trace_ids"
+	nonSampledIDsKey = "non_sampled_trace_ids"
+
+	traceIDSize = 16
+)
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+	ext, found := host.GetExtensions()[*storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// loadDecisionCaches restores the decision caches saved by a previous run.
+func (tsp *tailSamplingSpanProcessor) loadDecisionCaches(ctx context.Context) error {
+	for key, c := range map[string]cache.Cache{sampledIDsKey: tsp.sampledIDCache, nonSampledIDsKey: tsp.nonSampledIDCache} {
+		buf, err := tsp.storageClient.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to load %s from storage: %w", key, err)
+		}
+		ids, err := decodeTraceIDs(buf)
+		if err != nil {
+			tsp.logger.Warn("Ignoring invalid decision cache found in storage", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		for _, id := range ids {
+			c.Add(id)
+		}
+	}
+	return nil
+}
+
+// saveDecisionCaches persists the decision caches, so they can be restored on the next start.
+func (tsp *tailSamplingSpanProcessor) saveDecisionCaches(ctx context.Context) error {
+	return tsp.storageClient.Batch(ctx,
+		storage.SetOperation(sampledIDsKey, encodeTraceIDs(tsp.sampledIDCache.IDs())),
+		storage.SetOperation(nonSampledIDsKey, encodeTraceIDs(tsp.nonSampledIDCache.IDs())),
+	)
+}
+
+// periodicallySaveDecisionCaches saves the decision caches every flush interval until flushStop is closed.
+func (tsp *tailSamplingSpanProcessor) periodicallySaveDecisionCaches() {
+	defer close(tsp.flushDone)
+	ticker := time.NewTicker(tsp.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := tsp.saveDecisionCaches(context.Background()); err != nil {
+				tsp.logger.Warn("Failed to save the decision caches", zap.Error(err))
+			}
+		case <-tsp.flushStop:
+			return
+		}
+	}
+}
+
+func encodeTraceIDs(ids []pcommon.TraceID) []byte {
+	buf := make([]byte, 0, len(ids)*traceIDSize)
+	for _, id := range ids {
+		b := id.Bytes()
+		buf = append(buf, b[:]...)
+	}
+	return buf
+}
+
+func decodeTraceIDs(buf []byte) ([]pcommon.TraceID, error) {
+	if len(buf)%traceIDSize != 0 {
+		return nil, fmt.Errorf("unexpected length %d, expected a multiple of %d", len(buf), traceIDSize)
+	}
+	ids := make([]pcommon.TraceID, 0, len(buf)/traceIDSize)
+	for i := 0; i < len(buf); i += traceIDSize {
+		var b [traceIDSize]byte
+		copy(b[:], buf[i:i+traceIDSize])
+		ids = append(ids, pcommon.NewTraceID(b))
+	}
+	return ids, nil
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestDecisionCachePersistence(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	storageID := config.NewComponentID("nop")
+	for id := range host.GetExtensions() {
+		storageID = id
+	}
+	cfg := Config{
+		ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:            defaultTestDecisionWait,
+		NumTraces:               100,
+		ExpectedNewTracesPerSec: 64,
+		PolicyCfgs:              testPolicy,
+		DecisionCache: DecisionCacheCfg{
+			SampledCacheSize:    10,
+			NonSampledCacheSize: 10,
+			StorageID:           &storageID,
+		},
+	}
+	sampledID := pcommon.NewTraceID([16]byte{1})
+	nonSampledID := pcommon.NewTraceID([16]byte{2})
+
+	sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	tsp.sampledIDCache.Add(sampledID)
+	tsp.nonSampledIDCache.Add(nonSampledID)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	sink := new(consumertest.TracesSink)
+	sp, err = newTracesProcessor(zap.NewNop(), sink, cfg)
+	require.NoError(t, err)
+	tsp = sp.(*tailSamplingSpanProcessor)
+	tsp.tickerFrequency = time.Hour
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	assert.Equal(t, []pcommon.TraceID{sampledID}, tsp.sampledIDCache.IDs())
+	assert.Equal(t, []pcommon.TraceID{nonSampledID}, tsp.nonSampledIDCache.IDs())
+
+	// spans of restored decisions are not buffered again
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(nonSampledID)))
+	assert.Equal(t, 1, sink.SpanCount())
+	assert.EqualValues(t, 0, tsp.numTracesOnMap.Load())
+}
+
+func TestDecisionCachePeriodicFlush(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	storageID := config.NewComponentID("nop")
+	for id := range host.GetExtensions() {
+		storageID = id
+	}
+	cfg := Config{
+		ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		DecisionWait:            defaultTestDecisionWait,
+		NumTraces:               100,
+		ExpectedNewTracesPerSec: 64,
+		PolicyCfgs:              testPolicy,
+		DecisionCache: DecisionCacheCfg{
+			SampledCacheSize: 10,
+			StorageID:        &storageID,
+			FlushInterval:    10 * time.Millisecond,
+		},
+	}
+	sampledID := pcommon.NewTraceID([16]byte{1})
+
+	sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	tsp.sampledIDCache.Add(sampledID)
+
+	// the caches are saved without waiting for the shutdown
+	assert.Eventually(t, func() bool {
+		buf, err := tsp.storageClient.Get(context.Background(), sampledIDsKey)
+		if err != nil {
+			return false
+		}
+		ids, err := decodeTraceIDs(buf)
+		return err == nil && len(ids) == 1 && ids[0] == sampledID
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDecisionCacheStorageNotFound(t *testing.T) {
+	storageID := config.NewComponentID("missing")
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     100,
+		PolicyCfgs:    testPolicy,
+		DecisionCache: DecisionCacheCfg{StorageID: &storageID},
+	}
+	sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	assert.Error(t, sp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestTraceIDsEncoding(t *testing.T) {
+	ids := []pcommon.TraceID{pcommon.NewTraceID([16]byte{1, 2}), pcommon.NewTraceID([16]byte{3, 4})}
+	got, err := decodeTraceIDs(encodeTraceIDs(ids))
+	require.NoError(t, err)
+	assert.Equal(t, ids, got)
+
+	_, err = decodeTraceIDs([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
@@ -14,10 +14,23 @@
 
 package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/telemetryquerylanguage/functions/tqlfuncs"
+)
+
 var registry = map[string]interface{}{
 	"TraceID":              traceID,
 	"SpanID":               spanID,
 	"IsMatch":              isMatch,
+	"Concat":               tqlfuncs.Concat,
+	"Split":                tqlfuncs.Split,
+	"Int":                  tqlfuncs.Int,
+	"Double":               tqlfuncs.Double,
+	"String":               tqlfuncs.String,
+	"SHA256":               tqlfuncs.SHA256,
+	"ParseJSON":            tqlfuncs.ParseJSON,
+	"ConvertCase":          tqlfuncs.ConvertCase,
+	"Substring":            tqlfuncs.Substring,
 	"keep_keys":            keepKeys,
 	"set":                  set,
 	"truncate_all":         truncateAll,
@@ -28,6 +41,7 @@ var registry = map[string]interface{}{
 	"replace_all_patterns": replaceAllPatterns,
 	"delete_key":           deleteKey,
 	"delete_matching_keys": deleteMatchingKeys,
+	"merge_maps":           tqlfuncs.MergeMaps,
 }
 
 func DefaultFunctions() map[string]interface{} {
@@ -96,11 +96,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 		}
 
 		if path[1].Name == "attributes" {
-			mapKey := path[1].MapKey
-			if mapKey == nil {
+			keys := path[1].Keys
+			if len(keys) == 0 {
 				return accessResourceAttributes(), nil
 			}
-			return accessResourceAttributesKey(mapKey), nil
+			return accessResourceAttributesKey(keys), nil
 		}
 	case "instrumentation_scope":
 		if len(path) == 1 {
@@ -121,13 +121,17 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	case "severity_text":
 		return accessSeverityText(), nil
 	case "body":
-		return accessBody(), nil
+		keys := path[0].Keys
+		if len(keys) == 0 {
+			return accessBody(), nil
+		}
+		return accessBodyKey(keys), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "flags":
@@ -179,13 +183,13 @@ func accessResourceAttributes() tql.StandardGetSetter {
 	}
 }
 
-func accessResourceAttributesKey(mapKey *string) tql.StandardGetSetter {
+func accessResourceAttributesKey(keys []tql.Key) tql.StandardGetSetter {
 	return tql.StandardGetSetter{
 		Getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
+			return getAttr(ctx.GetResource().Attributes(), keys)
 		},
 		Setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
+			setAttr(ctx.GetResource().Attributes(), keys, val)
 		},
 	}
 }
@@ -292,6 +296,28 @@ func accessBody() tql.StandardGetSetter {
 	}
 }
 
+func accessBodyKey(keys []tql.Key) tql.StandardGetSetter {
+	return tql.StandardGetSetter{
+		Getter: func(ctx tql.TransformContext) interface{} {
+			body := ctx.GetItem().(plog.LogRecord).Body()
+			if body.Type() != pcommon.ValueTypeMap {
+				return nil
+			}
+			return getAttr(body.MapVal(), keys)
+		},
+		Setter: func(ctx tql.TransformContext, val interface{}) {
+			body := ctx.GetItem().(plog.LogRecord).Body()
+			if body.Type() == pcommon.ValueTypeEmpty {
+				pcommon.NewValueMap().CopyTo(body)
+			}
+			if body.Type() != pcommon.ValueTypeMap {
+				return
+			}
+			setAttr(body.MapVal(), keys, val)
+		},
+	}
+}
+
 func accessAttributes() tql.StandardGetSetter {
 	return tql.StandardGetSetter{
 		Getter: func(ctx tql.TransformContext) interface{} {
@@ -306,13 +332,13 @@ func accessAttributes() tql.StandardGetSetter {
 	}
 }
 
-func accessAttributesKey(mapKey *string) tql.StandardGetSetter {
+func accessAttributesKey(keys []tql.Key) tql.StandardGetSetter {
 	return tql.StandardGetSetter{
 		Getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey)
+			return getAttr(ctx.GetItem().(plog.LogRecord).Attributes(), keys)
 		},
 		Setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetItem().(plog.LogRecord).Attributes(), *mapKey, val)
+			setAttr(ctx.GetItem().(plog.LogRecord).Attributes(), keys, val)
 		},
 	}
 }
@@ -399,8 +425,8 @@ func accessStringSpanID() tql.StandardGetSetter {
 	}
 }
 
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
+func getAttr(attrs pcommon.Map, keys []tql.Key) interface{} {
+	val, ok := tql.GetMapValue(attrs, keys)
 	if !ok {
 		return nil
 	}
@@ -427,50 +453,75 @@ func getValue(val pcommon.Value) interface{} {
 	return nil
 }
 
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
+func setAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) {
+	parent, mapKey, ok := tql.GetParentMap(attrs, keys)
+	if !ok {
+		return
+	}
 	switch v := val.(type) {
 	case string:
-		attrs.UpsertString(mapKey, v)
+		parent.UpsertString(mapKey, v)
 	case bool:
-		attrs.UpsertBool(mapKey, v)
+		parent.UpsertBool(mapKey, v)
 	case int64:
-		attrs.UpsertInt(mapKey, v)
+		parent.UpsertInt(mapKey, v)
 	case float64:
-		attrs.UpsertDouble(mapKey, v)
+		parent.UpsertDouble(mapKey, v)
 	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
+		parent.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
 	case []string:
 		arr := pcommon.NewValueSlice()
 		for _, str := range v {
 			arr.SliceVal().AppendEmpty().SetStringVal(str)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case []bool:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBoolVal(b)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case []int64:
 		arr := pcommon.NewValueSlice()
 		for _, i := range v {
 			arr.SliceVal().AppendEmpty().SetIntVal(i)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case []float64:
 		arr := pcommon.NewValueSlice()
 		for _, f := range v {
 			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case [][]byte:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-		attrs.Upsert(mapKey, arr)
-	default:
-		// TODO(anuraaga): Support set of map type.
+		parent.Upsert(mapKey, arr)
+	case []interface{}:
+		arr := pcommon.NewValueSlice()
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				arr.SliceVal().AppendEmpty().SetStringVal(i)
+			case bool:
+				arr.SliceVal().AppendEmpty().SetBoolVal(i)
+			case int64:
+				arr.SliceVal().AppendEmpty().SetIntVal(i)
+			case float64:
+				arr.SliceVal().AppendEmpty().SetDoubleVal(i)
+			case []byte:
+				arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(i))
+			default:
+				arr.SliceVal().AppendEmpty()
+			}
+		}
+		parent.Upsert(mapKey, arr)
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		parent.Upsert(mapKey, m)
 	}
 }
 
@@ -521,7 +572,9 @@ func setValue(value pcommon.Value, val interface{}) {
 		for _, b := range v {
 			value.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-	default:
-		// TODO(anuraaga): Support set of map type.
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		m.CopyTo(value)
 	}
 }
@@ -216,8 +216,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "setting an attribute to nil is a no-op",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -230,8 +234,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -244,8 +252,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig: true,
@@ -258,8 +270,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig: int64(10),
@@ -272,8 +288,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig: float64(1.2),
@@ -286,8 +306,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -300,8 +324,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -317,8 +345,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -334,8 +366,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -351,8 +387,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -368,8 +408,12 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -450,8 +494,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -467,8 +515,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig: true,
@@ -484,8 +536,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig: int64(10),
@@ -501,8 +557,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig: float64(1.2),
@@ -518,8 +578,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -535,8 +599,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -555,8 +623,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -575,8 +647,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -595,8 +671,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -615,8 +695,12 @@ func Test_newPathGetSetter(t *testing.T) {
 					Name: "resource",
 				},
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -659,6 +743,127 @@ func Test_newPathGetSetter(t *testing.T) {
 	}
 }
 
+func Test_newPathGetSetter_MapBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     func(body pcommon.Value)
+		keys     []tql.Key
+		orig     interface{}
+		newVal   interface{}
+		modified func(body pcommon.Value)
+	}{
+		{
+			name: "nested key",
+			body: func(body pcommon.Value) {
+				pcommon.NewValueMap().CopyTo(body)
+				body.MapVal().UpsertString("message", "hello")
+				nested := pcommon.NewValueMap()
+				nested.MapVal().UpsertInt("code", 200)
+				body.MapVal().Upsert("http", nested)
+			},
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("http"),
+				},
+				{
+					String: tqltest.Strp("code"),
+				},
+			},
+			orig:   int64(200),
+			newVal: int64(404),
+			modified: func(body pcommon.Value) {
+				pcommon.NewValueMap().CopyTo(body)
+				body.MapVal().UpsertString("message", "hello")
+				nested := pcommon.NewValueMap()
+				nested.MapVal().UpsertInt("code", 404)
+				body.MapVal().Upsert("http", nested)
+			},
+		},
+		{
+			name: "missing key",
+			body: func(body pcommon.Value) {
+				pcommon.NewValueMap().CopyTo(body)
+				body.MapVal().UpsertString("message", "hello")
+			},
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("http"),
+				},
+				{
+					String: tqltest.Strp("method"),
+				},
+			},
+			orig:   nil,
+			newVal: "GET",
+			modified: func(body pcommon.Value) {
+				pcommon.NewValueMap().CopyTo(body)
+				body.MapVal().UpsertString("message", "hello")
+				nested := pcommon.NewValueMap()
+				nested.MapVal().UpsertString("method", "GET")
+				body.MapVal().Upsert("http", nested)
+			},
+		},
+		{
+			name: "empty body",
+			body: func(body pcommon.Value) {},
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("message"),
+				},
+			},
+			orig:   nil,
+			newVal: "hello",
+			modified: func(body pcommon.Value) {
+				pcommon.NewValueMap().CopyTo(body)
+				body.MapVal().UpsertString("message", "hello")
+			},
+		},
+		{
+			name: "string body",
+			body: func(body pcommon.Value) {
+				body.SetStringVal("hello")
+			},
+			keys: []tql.Key{
+				{
+					String: tqltest.Strp("message"),
+				},
+			},
+			orig:   nil,
+			newVal: "world",
+			modified: func(body pcommon.Value) {
+				body.SetStringVal("hello")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter([]tql.Field{
+				{
+					Name: "body",
+					Keys: tt.keys,
+				},
+			})
+			assert.NoError(t, err)
+
+			log := plog.NewLogRecord()
+			tt.body(log.Body())
+			ctx := logTransformContext{
+				log:      log,
+				il:       pcommon.NewInstrumentationScope(),
+				resource: pcommon.NewResource(),
+			}
+
+			assert.Equal(t, tt.orig, accessor.Get(ctx))
+
+			accessor.Set(ctx, tt.newVal)
+
+			expected := plog.NewLogRecord()
+			tt.modified(expected.Body())
+			assert.Equal(t, expected, log)
+		})
+	}
+}
+
 func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Resource) {
 	log := plog.NewLogRecord()
 	log.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
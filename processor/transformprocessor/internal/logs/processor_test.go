@@ -136,6 +136,24 @@ func TestProcess(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("http.url", "http://localhost/health")
 			},
 		},
+		{
+			query: `merge_maps(attributes, ParseJSON("{\"json_test\":\"pass\"}"), "insert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("json_test", "pass")
+			},
+		},
+		{
+			query: `set(attributes["test"], Concat([attributes["http.method"], attributes["http.path"]], " ")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "get /health")
+			},
+		},
+		{
+			query: `set(attributes["test"], ConvertCase(body, "upper")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().InsertString("test", "OPERATIONA")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,8 +135,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "setting an attribute to nil is a no-op",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -149,8 +153,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -163,8 +171,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig: true,
@@ -177,8 +189,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig: int64(10),
@@ -191,8 +207,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig: float64(1.2),
@@ -205,8 +225,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -219,8 +243,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -236,8 +264,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -253,8 +285,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -270,8 +306,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -287,8 +327,12 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -481,8 +525,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -495,8 +543,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig: true,
@@ -509,8 +561,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig: int64(10),
@@ -523,8 +579,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig: float64(1.2),
@@ -537,8 +597,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -551,8 +615,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -568,8 +636,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -585,8 +657,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -602,8 +678,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -619,8 +699,12 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -909,8 +993,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -923,8 +1011,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig: true,
@@ -937,8 +1029,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig: int64(10),
@@ -951,8 +1047,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig: 1.2,
@@ -965,8 +1065,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -979,8 +1083,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -996,8 +1104,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1013,8 +1125,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1030,8 +1146,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1047,8 +1167,12 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1222,8 +1346,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("str"),
+						},
+					},
 				},
 			},
 			orig: "val",
@@ -1236,8 +1364,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bool"),
+						},
+					},
 				},
 			},
 			orig: true,
@@ -1250,8 +1382,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("int"),
+						},
+					},
 				},
 			},
 			orig: int64(10),
@@ -1264,8 +1400,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("double"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("double"),
+						},
+					},
 				},
 			},
 			orig: 1.2,
@@ -1278,8 +1418,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("bytes"),
+						},
+					},
 				},
 			},
 			orig: []byte{1, 3, 2},
@@ -1292,8 +1436,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array string",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_str"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1309,8 +1457,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array bool",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bool"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1326,8 +1478,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array int",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_int"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1343,8 +1499,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array float",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_float"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -1360,8 +1520,12 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 			name: "attributes array bytes",
 			path: []tql.Field{
 				{
-					Name:   "attributes",
-					MapKey: tqltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []tql.Key{
+						{
+							String: tqltest.Strp("arr_bytes"),
+						},
+					},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -161,6 +161,29 @@ func TestProcess(t *testing.T) {
 				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetKind(2)
 			},
 		},
+		{
+			query: `set(attributes["duration_ms"], (end_time_unix_nano - start_time_unix_nano) / 1000000) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().InsertInt("duration_ms", TestSpanEndTime.Sub(TestSpanStartTime).Milliseconds())
+			},
+		},
+		{
+			query: `set(attributes["test"], ["pass", 1]) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				arr := pcommon.NewValueSlice()
+				arr.SliceVal().AppendEmpty().SetStringVal("pass")
+				arr.SliceVal().AppendEmpty().SetIntVal(1)
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Insert("test", arr)
+			},
+		},
+		{
+			query: `set(attributes["nested"]["test"], "pass") where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				nested := pcommon.NewValueMap()
+				nested.MapVal().InsertString("test", "pass")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Insert("nested", nested)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -82,11 +82,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 		}
 		switch path[1].Name {
 		case "attributes":
-			mapKey := path[1].MapKey
-			if mapKey == nil {
+			keys := path[1].Keys
+			if len(keys) == 0 {
 				return accessResourceAttributes(), nil
 			}
-			return accessResourceAttributesKey(mapKey), nil
+			return accessResourceAttributesKey(keys), nil
 		}
 	case "instrumentation_library":
 		if len(path) == 1 {
@@ -115,11 +115,14 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 			return accessStringSpanID(), nil
 		}
 	case "trace_state":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessTraceState(), nil
 		}
-		return accessTraceStateKey(mapKey), nil
+		if len(keys) > 1 || keys[0].String == nil {
+			return nil, fmt.Errorf("invalid path expression %v, trace_state only supports a single string key", path)
+		}
+		return accessTraceStateKey(keys[0].String), nil
 	case "parent_span_id":
 		return accessParentSpanID(), nil
 	case "name":
@@ -131,11 +134,11 @@ func newPathGetSetter(path []tql.Field) (tql.GetSetter, error) {
 	case "end_time_unix_nano":
 		return accessEndTimeUnixNano(), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if len(keys) == 0 {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(keys), nil
 	case "dropped_attributes_count":
 		return accessDroppedAttributesCount(), nil
 	case "events":
@@ -191,13 +194,13 @@ func accessResourceAttributes() tql.StandardGetSetter {
 	}
 }
 
-func accessResourceAttributesKey(mapKey *string) tql.StandardGetSetter {
+func accessResourceAttributesKey(keys []tql.Key) tql.StandardGetSetter {
 	return tql.StandardGetSetter{
 		Getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetResource().Attributes(), *mapKey)
+			return getAttr(ctx.GetResource().Attributes(), keys)
 		},
 		Setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetResource().Attributes(), *mapKey, val)
+			setAttr(ctx.GetResource().Attributes(), keys, val)
 		},
 	}
 }
@@ -409,13 +412,13 @@ func accessAttributes() tql.StandardGetSetter {
 	}
 }
 
-func accessAttributesKey(mapKey *string) tql.StandardGetSetter {
+func accessAttributesKey(keys []tql.Key) tql.StandardGetSetter {
 	return tql.StandardGetSetter{
 		Getter: func(ctx tql.TransformContext) interface{} {
-			return getAttr(ctx.GetItem().(ptrace.Span).Attributes(), *mapKey)
+			return getAttr(ctx.GetItem().(ptrace.Span).Attributes(), keys)
 		},
 		Setter: func(ctx tql.TransformContext, val interface{}) {
-			setAttr(ctx.GetItem().(ptrace.Span).Attributes(), *mapKey, val)
+			setAttr(ctx.GetItem().(ptrace.Span).Attributes(), keys, val)
 		},
 	}
 }
@@ -530,8 +533,8 @@ func accessStatusMessage() tql.StandardGetSetter {
 	}
 }
 
-func getAttr(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
+func getAttr(attrs pcommon.Map, keys []tql.Key) interface{} {
+	val, ok := tql.GetMapValue(attrs, keys)
 	if !ok {
 		return nil
 	}
@@ -554,49 +557,74 @@ func getAttr(attrs pcommon.Map, mapKey string) interface{} {
 	return nil
 }
 
-func setAttr(attrs pcommon.Map, mapKey string, val interface{}) {
+func setAttr(attrs pcommon.Map, keys []tql.Key, val interface{}) {
+	parent, mapKey, ok := tql.GetParentMap(attrs, keys)
+	if !ok {
+		return
+	}
 	switch v := val.(type) {
 	case string:
-		attrs.UpsertString(mapKey, v)
+		parent.UpsertString(mapKey, v)
 	case bool:
-		attrs.UpsertBool(mapKey, v)
+		parent.UpsertBool(mapKey, v)
 	case int64:
-		attrs.UpsertInt(mapKey, v)
+		parent.UpsertInt(mapKey, v)
 	case float64:
-		attrs.UpsertDouble(mapKey, v)
+		parent.UpsertDouble(mapKey, v)
 	case []byte:
-		attrs.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
+		parent.UpsertBytes(mapKey, pcommon.NewImmutableByteSlice(v))
 	case []string:
 		arr := pcommon.NewValueSlice()
 		for _, str := range v {
 			arr.SliceVal().AppendEmpty().SetStringVal(str)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case []bool:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBoolVal(b)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case []int64:
 		arr := pcommon.NewValueSlice()
 		for _, i := range v {
 			arr.SliceVal().AppendEmpty().SetIntVal(i)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case []float64:
 		arr := pcommon.NewValueSlice()
 		for _, f := range v {
 			arr.SliceVal().AppendEmpty().SetDoubleVal(f)
 		}
-		attrs.Upsert(mapKey, arr)
+		parent.Upsert(mapKey, arr)
 	case [][]byte:
 		arr := pcommon.NewValueSlice()
 		for _, b := range v {
 			arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(b))
 		}
-		attrs.Upsert(mapKey, arr)
-	default:
-		// TODO(anuraaga): Support set of map type.
+		parent.Upsert(mapKey, arr)
+	case []interface{}:
+		arr := pcommon.NewValueSlice()
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				arr.SliceVal().AppendEmpty().SetStringVal(i)
+			case bool:
+				arr.SliceVal().AppendEmpty().SetBoolVal(i)
+			case int64:
+				arr.SliceVal().AppendEmpty().SetIntVal(i)
+			case float64:
+				arr.SliceVal().AppendEmpty().SetDoubleVal(i)
+			case []byte:
+				arr.SliceVal().AppendEmpty().SetBytesVal(pcommon.NewImmutableByteSlice(i))
+			default:
+				arr.SliceVal().AppendEmpty()
+			}
+		}
+		parent.Upsert(mapKey, arr)
+	case pcommon.Map:
+		m := pcommon.NewValueMap()
+		v.CopyTo(m.MapVal())
+		parent.Upsert(mapKey, m)
 	}
 }
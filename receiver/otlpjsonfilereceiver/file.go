@@ -16,6 +16,9 @@ package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -33,6 +36,12 @@ const (
 	typeStr   = "otlpjsonfile"
 	stability = component.StabilityLevelAlpha
 	transport = "file"
+
+	// formatJSON reads one Protobuf-JSON message per line.
+	formatJSON = "json"
+	// formatProto reads Protobuf messages, each prefixed with its size as a varint, as written by
+	// the file exporter using its "proto" format.
+	formatProto = "proto"
 )
 
 // NewFactory creates a factory for file receiver
@@ -48,12 +57,57 @@ func NewFactory() component.ReceiverFactory {
 type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	fileconsumer.Config     `mapstructure:",squash"`
+
+	// Format is the encoding of the files: "json" (default) or "proto".
+	Format string `mapstructure:"format"`
 }
 
 func createDefaultConfig() config.Receiver {
 	return &Config{
 		Config:           *fileconsumer.NewConfig(),
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Format:           formatJSON,
+	}
+}
+
+// Validate checks if the receiver configuration is valid
+func (c *Config) Validate() error {
+	switch c.Format {
+	case formatJSON, formatProto:
+		return nil
+	default:
+		return fmt.Errorf("format %q is not supported, must be either %q or %q", c.Format, formatJSON, formatProto)
+	}
+}
+
+// buildInput builds the file consumer, splitting the files according to the format.
+func (c *Config) buildInput(settings component.ReceiverCreateSettings, emit fileconsumer.EmitFunc) (*fileconsumer.Input, error) {
+	if c.Format == formatProto {
+		return c.Config.BuildWithSplitFunc(settings.Logger.Sugar(), emit, splitLengthDelimited(int(c.MaxLogSize)))
+	}
+	return c.Config.Build(settings.Logger.Sugar(), emit)
+}
+
+// splitLengthDelimited splits the messages prefixed with their size as a varint. Messages larger than
+// maxSize can't be read, as they would not fit in the buffer of the file consumer.
+func splitLengthDelimited(maxSize int) func(data []byte, atEOF bool) (int, []byte, error) {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		size, n := binary.Uvarint(data)
+		if n == 0 {
+			// the size isn't complete yet
+			return 0, nil, nil
+		}
+		if n < 0 {
+			return 0, nil, errors.New("invalid message size")
+		}
+		if size > uint64(maxSize) || int(size)+n > maxSize {
+			return 0, nil, fmt.Errorf("message of %d bytes is larger than max_log_size", size)
+		}
+		end := n + int(size)
+		if len(data) < end {
+			return 0, nil, nil
+		}
+		return end, data[n:end], nil
 	}
 }
 
@@ -75,13 +129,17 @@ func (f *receiver) Shutdown(ctx context.Context) error {
 }
 
 func createLogsReceiver(_ context.Context, settings component.ReceiverCreateSettings, configuration config.Receiver, logs consumer.Logs) (component.LogsReceiver, error) {
+	cfg := configuration.(*Config)
 	logsUnmarshaler := plog.NewJSONUnmarshaler()
+	if cfg.Format == formatProto {
+		logsUnmarshaler = plog.NewProtoUnmarshaler()
+	}
 	obsrecv := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             configuration.ID(),
 		Transport:              transport,
 		ReceiverCreateSettings: settings,
 	})
-	input, err := configuration.(*Config).Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	input, err := cfg.buildInput(settings, func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
 		ctx = obsrecv.StartMetricsOp(ctx)
 		l, err := logsUnmarshaler.UnmarshalLogs(token)
 		if err != nil {
@@ -99,13 +157,17 @@ func createLogsReceiver(_ context.Context, settings component.ReceiverCreateSett
 }
 
 func createMetricsReceiver(_ context.Context, settings component.ReceiverCreateSettings, configuration config.Receiver, metrics consumer.Metrics) (component.MetricsReceiver, error) {
+	cfg := configuration.(*Config)
 	metricsUnmarshaler := pmetric.NewJSONUnmarshaler()
+	if cfg.Format == formatProto {
+		metricsUnmarshaler = pmetric.NewProtoUnmarshaler()
+	}
 	obsrecv := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             configuration.ID(),
 		Transport:              transport,
 		ReceiverCreateSettings: settings,
 	})
-	input, err := configuration.(*Config).Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	input, err := cfg.buildInput(settings, func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
 		ctx = obsrecv.StartMetricsOp(ctx)
 		m, err := metricsUnmarshaler.UnmarshalMetrics(token)
 		if err != nil {
@@ -123,13 +185,17 @@ func createMetricsReceiver(_ context.Context, settings component.ReceiverCreateS
 }
 
 func createTracesReceiver(ctx context.Context, settings component.ReceiverCreateSettings, configuration config.Receiver, traces consumer.Traces) (component.TracesReceiver, error) {
+	cfg := configuration.(*Config)
 	tracesUnmarshaler := ptrace.NewJSONUnmarshaler()
+	if cfg.Format == formatProto {
+		tracesUnmarshaler = ptrace.NewProtoUnmarshaler()
+	}
 	obsrecv := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             configuration.ID(),
 		Transport:              transport,
 		ReceiverCreateSettings: settings,
 	})
-	input, err := configuration.(*Config).Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	input, err := cfg.buildInput(settings, func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
 		ctx = obsrecv.StartTracesOp(ctx)
 		t, err := tracesUnmarshaler.UnmarshalTraces(token)
 		if err != nil {
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
@@ -138,11 +139,13 @@ func testdataConfigYamlAsMap() *Config {
 			FingerprintSize:         1000,
 			MaxLogSize:              1024 * 1024,
 			MaxConcurrentFiles:      1024,
+			DeleteQuietPeriod:       helper.Duration{Duration: time.Minute},
 			Finder: fileconsumer.Finder{
 				Include: []string{"/var/log/*.log"},
 				Exclude: []string{"/var/log/example.log"},
 			},
 		},
+		Format: formatJSON,
 	}
 }
 
@@ -160,3 +163,70 @@ func TestLoadConfig(t *testing.T) {
 
 	assert.Equal(t, testdataConfigYamlAsMap(), cfg.Receivers[config.NewComponentID("otlpjsonfile")])
 }
+
+func TestProtoFileRoundTrip(t *testing.T) {
+	tempFolder := t.TempDir()
+	path := filepath.Join(tempFolder, "telemetry.pb")
+
+	// write the data with the file exporter
+	exporterFactory := fileexporter.NewFactory()
+	exporterCfg := exporterFactory.CreateDefaultConfig().(*fileexporter.Config)
+	exporterCfg.Path = path
+	exporterCfg.FormatType = formatProto
+	exporter, err := exporterFactory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), exporterCfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+
+	td1 := testdata.GenerateTracesTwoSpansSameResource()
+	td2 := testdata.GenerateTracesManySpansSameResource(10)
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td1))
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td2))
+	require.NoError(t, exporter.Shutdown(context.Background()))
+
+	// read it back with the receiver
+	cfg := createDefaultConfig().(*Config)
+	cfg.Config.Include = []string{path}
+	cfg.Config.StartAt = "beginning"
+	cfg.Format = formatProto
+	sink := new(consumertest.TracesSink)
+	receiver, err := NewFactory().CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), nil))
+	defer func() {
+		assert.NoError(t, receiver.Shutdown(context.Background()))
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(sink.AllTraces()) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.EqualValues(t, td1, sink.AllTraces()[0])
+	assert.EqualValues(t, td2, sink.AllTraces()[1])
+}
+
+func TestSplitLengthDelimited(t *testing.T) {
+	split := splitLengthDelimited(16)
+
+	// incomplete size and message
+	advance, token, err := split([]byte{0x80}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, advance)
+	assert.Nil(t, token)
+	advance, token, err = split([]byte{3, 'a', 'b'}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, advance)
+	assert.Nil(t, token)
+
+	advance, token, err = split([]byte{3, 'a', 'b', 'c', 1}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, advance)
+	assert.Equal(t, []byte("abc"), token)
+
+	_, _, err = split([]byte{16}, false)
+	assert.EqualError(t, err, "message of 16 bytes is larger than max_log_size")
+}
+
+func TestInvalidFormat(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Format = "xml"
+	assert.EqualError(t, cfg.Validate(), `format "xml" is not supported, must be either "json" or "proto"`)
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -31,6 +32,7 @@ import (
 	"github.com/prometheus/prometheus/discovery/kubernetes"
 	"github.com/prometheus/prometheus/discovery/targetgroup"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
 	"gopkg.in/yaml.v2"
 )
@@ -55,12 +57,27 @@ type Config struct {
 	UseStartTimeMetric   bool   `mapstructure:"use_start_time_metric"`
 	StartTimeMetricRegex string `mapstructure:"start_time_metric_regex"`
 
+	// TargetAllocator configures a target allocator, which assigns scrape configs and targets to
+	// each collector, so that the scraping can be spread across multiple collectors.
+	TargetAllocator *targetAllocator `mapstructure:"target_allocator"`
+
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
 	ConfigPlaceholder interface{} `mapstructure:"config"`
 }
 
+// targetAllocator configures the target allocator client.
+type targetAllocator struct {
+	// HTTPClientSettings configures the client used to reach the target allocator. Its Endpoint is
+	// the base URL of the target allocator, e.g. http://target-allocator:80.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	// Interval is how often the scrape configs are fetched from the target allocator. Default: 30s.
+	Interval time.Duration `mapstructure:"interval"`
+	// CollectorID identifies this collector among the ones sharing the target allocator.
+	CollectorID string `mapstructure:"collector_id"`
+}
+
 var _ config.Receiver = (*Config)(nil)
 var _ config.Unmarshallable = (*Config)(nil)
 
@@ -128,11 +145,16 @@ func checkSDFile(filename string) error {
 
 // Validate checks the receiver configuration is valid.
 func (cfg *Config) Validate() error {
+	if err := cfg.validateTargetAllocator(); err != nil {
+		return err
+	}
+
 	promConfig := cfg.PrometheusConfig
 	if promConfig == nil {
 		return nil // noop receiver
 	}
-	if len(promConfig.ScrapeConfigs) == 0 {
+	// the scrape configs can be assigned by the target allocator only
+	if len(promConfig.ScrapeConfigs) == 0 && cfg.TargetAllocator == nil {
 		return errors.New("no Prometheus scrape_configs")
 	}
 
@@ -209,6 +231,23 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+func (cfg *Config) validateTargetAllocator() error {
+	allocConfig := cfg.TargetAllocator
+	if allocConfig == nil {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(allocConfig.Endpoint); err != nil {
+		return fmt.Errorf("invalid target_allocator endpoint %q: %w", allocConfig.Endpoint, err)
+	}
+	if allocConfig.Interval < 0 {
+		return errors.New("target_allocator interval must not be negative")
+	}
+	if allocConfig.CollectorID == "" {
+		return errors.New("target_allocator collector_id must be set")
+	}
+	return nil
+}
+
 // Unmarshal a config.Parser into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -254,3 +256,85 @@ func TestFileSDConfigYamlNilTargetGroup(t *testing.T) {
 	gotErrMsg := err.Error()
 	require.Equal(t, wantErrMsg, gotErrMsg)
 }
+
+func TestLoadTargetAllocatorConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config_target_allocator.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	assert.Nil(t, r0.PrometheusConfig)
+	assert.Equal(t, &targetAllocator{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "http://localhost:8080",
+			Headers:  map[string]string{"X-Scope": "collectors"},
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "/etc/ssl/target-allocator-ca.pem"},
+			},
+		},
+		Interval:    30 * time.Second,
+		CollectorID: "collector-1",
+	}, r0.TargetAllocator)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "withscrape")].(*Config)
+	assert.Equal(t, "collector-1", r1.TargetAllocator.CollectorID)
+	assert.Equal(t, "demo", r1.PrometheusConfig.ScrapeConfigs[0].JobName)
+}
+
+func TestInvalidTargetAllocatorConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfig(filepath.Join("testdata", "invalid-config-prometheus-target-allocator.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	err = cfg.Validate()
+	require.NotNil(t, err, "Expected a non-nil error")
+
+	wantErrMsg := `receiver "prometheus" has invalid configuration: target_allocator collector_id must be set`
+
+	gotErrMsg := err.Error()
+	require.Equal(t, wantErrMsg, gotErrMsg)
+}
+
+func TestValidateTargetAllocator(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *targetAllocator
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg:  &targetAllocator{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8080"}, CollectorID: "collector-1"},
+		},
+		{
+			name:    "invalid endpoint",
+			cfg:     &targetAllocator{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "localhost"}, CollectorID: "collector-1"},
+			wantErr: `invalid target_allocator endpoint "localhost"`,
+		},
+		{
+			name:    "negative interval",
+			cfg:     &targetAllocator{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:8080"}, Interval: -time.Second, CollectorID: "collector-1"},
+			wantErr: "target_allocator interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{TargetAllocator: tt.cfg}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -61,6 +61,14 @@ func NewAppendable(
 	}
 }
 
+// SetGCInterval changes how long the jobs and timeseries used to adjust the start times of the
+// metrics are kept, so that it can follow the scrape configs applied after the appendable was created.
+func (o *appendable) SetGCInterval(gcInterval time.Duration) {
+	if o.jobsMap != nil {
+		o.jobsMap.SetGCInterval(gcInterval)
+	}
+}
+
 func (o *appendable) Appender(ctx context.Context) storage.Appender {
 	return newTransaction(ctx, o.jobsMap, o.useStartTimeMetric, o.startTimeMetricRegex, o.receiverID, o.sink, o.externalLabels, o.settings)
 }
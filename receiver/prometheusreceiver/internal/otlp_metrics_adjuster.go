@@ -152,6 +152,13 @@ func NewJobsMap(gcInterval time.Duration) *JobsMap {
 	return &JobsMap{gcInterval: gcInterval, lastGC: time.Now(), jobsMap: make(map[string]*timeseriesMap)}
 }
 
+// SetGCInterval changes how long jobs and timeseries are kept once they're no longer updated.
+func (jm *JobsMap) SetGCInterval(gcInterval time.Duration) {
+	jm.Lock()
+	defer jm.Unlock()
+	jm.gcInterval = gcInterval
+}
+
 // Remove jobs and timeseries that have aged out.
 func (jm *JobsMap) gc() {
 	jm.Lock()
@@ -21,6 +21,7 @@ import (
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/scrape"
+	"github.com/prometheus/prometheus/storage"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
@@ -39,8 +40,17 @@ type pReceiver struct {
 	consumer   consumer.Metrics
 	cancelFunc context.CancelFunc
 
-	settings      component.ReceiverCreateSettings
-	scrapeManager *scrape.Manager
+	settings         component.ReceiverCreateSettings
+	store            storage.Appendable
+	scrapeManager    *scrape.Manager
+	discoveryManager *discovery.Manager
+	targetAllocator  *targetAllocatorClient
+}
+
+// gcIntervalSetter is implemented by the appendable, whose garbage collection interval has to follow
+// the scrape configs assigned by the target allocator.
+type gcIntervalSetter interface {
+	SetGCInterval(time.Duration)
 }
 
 // New creates a new prometheus.Receiver reference.
@@ -55,20 +65,31 @@ func newPrometheusReceiver(set component.ReceiverCreateSettings, cfg *Config, ne
 
 // Start is the method that starts Prometheus scraping and it
 // is controlled by having previously defined a Configuration using perhaps New.
-func (r *pReceiver) Start(_ context.Context, host component.Host) error {
+func (r *pReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.cfg.TargetAllocator != nil {
+		targetAllocator, err := newTargetAllocatorClient(r.cfg.TargetAllocator, host, r.settings.TelemetrySettings)
+		if err != nil {
+			return err
+		}
+		r.targetAllocator = targetAllocator
+	}
+
 	discoveryCtx, cancel := context.WithCancel(context.Background())
 	r.cancelFunc = cancel
 
 	logger := internal.NewZapToGokitLogAdapter(r.settings.Logger)
 
-	discoveryManager := discovery.NewManager(discoveryCtx, logger)
-	discoveryCfg := make(map[string]discovery.Configs)
-	for _, scrapeConfig := range r.cfg.PrometheusConfig.ScrapeConfigs {
-		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
+	baseCfg := r.cfg.PrometheusConfig
+	if baseCfg == nil {
+		// the scrape configs are all assigned by the target allocator
+		baseCfg = &config.Config{GlobalConfig: config.DefaultGlobalConfig}
 	}
-	if err := discoveryManager.ApplyConfig(discoveryCfg); err != nil {
+
+	r.discoveryManager = discovery.NewManager(discoveryCtx, logger)
+	if err := r.applyDiscoveryConfig(baseCfg); err != nil {
 		return err
 	}
+	discoveryManager := r.discoveryManager
 	go func() {
 		if err := discoveryManager.Run(); err != nil {
 			r.settings.Logger.Error("Discovery manager failed", zap.Error(err))
@@ -76,17 +97,17 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 		}
 	}()
 
-	store := internal.NewAppendable(
+	r.store = internal.NewAppendable(
 		r.consumer,
 		r.settings,
-		gcInterval(r.cfg.PrometheusConfig),
+		gcInterval(baseCfg),
 		r.cfg.UseStartTimeMetric,
 		r.cfg.StartTimeMetricRegex,
 		r.cfg.ID(),
-		r.cfg.PrometheusConfig.GlobalConfig.ExternalLabels,
+		baseCfg.GlobalConfig.ExternalLabels,
 	)
-	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, store)
-	if err := r.scrapeManager.ApplyConfig(r.cfg.PrometheusConfig); err != nil {
+	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, r.store)
+	if err := r.scrapeManager.ApplyConfig(baseCfg); err != nil {
 		return err
 	}
 	go func() {
@@ -95,9 +116,68 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 			host.ReportFatalError(err)
 		}
 	}()
+
+	if r.targetAllocator != nil {
+		// the target allocator may not be reachable yet, the scrape configs are fetched again on the next poll
+		if err := r.syncTargetAllocator(ctx, baseCfg); err != nil {
+			r.settings.Logger.Error("Failed to sync the scrape configs from the target allocator", zap.Error(err))
+		}
+		go r.pollTargetAllocator(discoveryCtx, baseCfg)
+	}
+	return nil
+}
+
+// applyDiscoveryConfig sets the service discovery configs of the scrape configs.
+func (r *pReceiver) applyDiscoveryConfig(cfg *config.Config) error {
+	discoveryCfg := make(map[string]discovery.Configs)
+	for _, scrapeConfig := range cfg.ScrapeConfigs {
+		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
+	}
+	return r.discoveryManager.ApplyConfig(discoveryCfg)
+}
+
+// syncTargetAllocator fetches the scrape configs from the target allocator and, when they changed,
+// replaces the scrape configs of the running managers. The scrape configs from the receiver's
+// configuration are kept, and the garbage collection interval is recomputed to cover the scrape
+// intervals of the new jobs.
+func (r *pReceiver) syncTargetAllocator(ctx context.Context, baseCfg *config.Config) error {
+	scrapeConfigs, response, err := r.targetAllocator.scrapeConfigs(ctx, baseCfg.GlobalConfig)
+	if err != nil || response == nil {
+		return err
+	}
+
+	cfg := *baseCfg
+	cfg.ScrapeConfigs = append(append([]*config.ScrapeConfig(nil), baseCfg.ScrapeConfigs...), scrapeConfigs...)
+	if err = r.scrapeManager.ApplyConfig(&cfg); err != nil {
+		return err
+	}
+	if err = r.applyDiscoveryConfig(&cfg); err != nil {
+		return err
+	}
+	if store, ok := r.store.(gcIntervalSetter); ok {
+		store.SetGCInterval(gcInterval(&cfg))
+	}
+	// the response is only recorded once applied, so that a failed apply is retried on the next poll
+	r.targetAllocator.applied(response)
+	r.settings.Logger.Info("Applied the scrape configs from the target allocator", zap.Int("jobs", len(scrapeConfigs)))
 	return nil
 }
 
+func (r *pReceiver) pollTargetAllocator(ctx context.Context, baseCfg *config.Config) {
+	ticker := time.NewTicker(r.targetAllocator.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.syncTargetAllocator(ctx, baseCfg); err != nil {
+				r.settings.Logger.Error("Failed to sync the scrape configs from the target allocator", zap.Error(err))
+			}
+		}
+	}
+}
+
 // gcInterval returns the longest scrape interval used by a scrape config,
 // plus a delta to prevent race conditions.
 // This ensures jobs are not garbage collected between scrapes.
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/discovery/refresh"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultTargetAllocatorInterval = 30 * time.Second
+	defaultTargetAllocatorTimeout  = 10 * time.Second
+	// how often the targets of each job are refreshed by the HTTP service discovery
+	defaultTargetsRefreshInterval = 30 * time.Second
+)
+
+// targetAllocatorClient fetches the scrape configs assigned to this collector from a target allocator.
+//
+// The target allocator is an HTTP server exposing two endpoints:
+//   - GET <endpoint>/scrape_configs returns a YAML or JSON object, mapping job names to Prometheus scrape configs.
+//   - GET <endpoint>/jobs/<job>/targets?collector_id=<id> returns the targets of the job assigned to the
+//     collector, using the Prometheus HTTP service discovery format.
+//
+// The targets of each job are refreshed by the discovery manager, while the scrape configs are polled on an
+// interval. Both use the HTTP client built from the receiver's configuration, so that its TLS and authentication
+// settings apply to every request made to the target allocator.
+type targetAllocatorClient struct {
+	cfg    *targetAllocator
+	client *http.Client
+	logger *zap.Logger
+
+	// last response whose scrape configs were applied, used to skip applying configs that did not change
+	lastResponse []byte
+}
+
+func newTargetAllocatorClient(cfg *targetAllocator, host component.Host, settings component.TelemetrySettings) (*targetAllocatorClient, error) {
+	httpSettings := cfg.HTTPClientSettings
+	if httpSettings.Timeout == 0 {
+		httpSettings.Timeout = defaultTargetAllocatorTimeout
+	}
+	client, err := httpSettings.ToClient(host.GetExtensions(), settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the target allocator client: %w", err)
+	}
+	return &targetAllocatorClient{
+		cfg:    cfg,
+		client: client,
+		logger: settings.Logger,
+	}, nil
+}
+
+func (c *targetAllocatorClient) interval() time.Duration {
+	if c.cfg.Interval == 0 {
+		return defaultTargetAllocatorInterval
+	}
+	return c.cfg.Interval
+}
+
+// scrapeConfigs returns the scrape configs assigned by the target allocator, with their targets discovered
+// through the target allocator as well, along with the response they were read from. The response is nil
+// when it did not change since the last one marked as applied.
+func (c *targetAllocatorClient) scrapeConfigs(ctx context.Context, global config.GlobalConfig) ([]*config.ScrapeConfig, []byte, error) {
+	endpoint := strings.TrimSuffix(c.cfg.Endpoint, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/scrape_configs", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch the scrape configs from the target allocator: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the scrape configs from the target allocator: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("target allocator returned status %q", resp.Status)
+	}
+	if c.lastResponse != nil && bytes.Equal(body, c.lastResponse) {
+		return nil, nil, nil
+	}
+
+	jobs := map[string]*config.ScrapeConfig{}
+	if err = yaml.Unmarshal(body, &jobs); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the scrape configs from the target allocator: %w", err)
+	}
+
+	// sort the jobs for a deterministic order of the scrape configs
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scrapeConfigs := make([]*config.ScrapeConfig, 0, len(jobs))
+	for _, name := range names {
+		sc := jobs[name]
+		if sc == nil {
+			return nil, nil, fmt.Errorf("empty scrape config for job %q", name)
+		}
+		if err = applyGlobalDefaults(sc, global); err != nil {
+			return nil, nil, fmt.Errorf("invalid scrape config for job %q: %w", name, err)
+		}
+
+		sc.ServiceDiscoveryConfigs = discovery.Configs{&targetsDiscoveryConfig{
+			client:          c.client,
+			url:             fmt.Sprintf("%s/jobs/%s/targets?collector_id=%s", endpoint, url.PathEscape(name), url.QueryEscape(c.cfg.CollectorID)),
+			refreshInterval: defaultTargetsRefreshInterval,
+		}}
+		scrapeConfigs = append(scrapeConfigs, sc)
+	}
+
+	return scrapeConfigs, body, nil
+}
+
+// applied records the response whose scrape configs have been applied, so that they aren't applied again
+// until the target allocator returns a different response.
+func (c *targetAllocatorClient) applied(response []byte) {
+	c.lastResponse = response
+}
+
+// applyGlobalDefaults sets the scrape interval and timeout from the global config, as Prometheus does
+// when loading its configuration file.
+func applyGlobalDefaults(sc *config.ScrapeConfig, global config.GlobalConfig) error {
+	if sc.ScrapeInterval == 0 {
+		sc.ScrapeInterval = global.ScrapeInterval
+	}
+	if sc.ScrapeTimeout > sc.ScrapeInterval {
+		return errors.New("scrape timeout greater than scrape interval")
+	}
+	if sc.ScrapeTimeout == 0 {
+		if global.ScrapeTimeout > sc.ScrapeInterval {
+			sc.ScrapeTimeout = sc.ScrapeInterval
+		} else {
+			sc.ScrapeTimeout = global.ScrapeTimeout
+		}
+	}
+	return nil
+}
+
+// targetsDiscoveryConfig discovers the targets of a job from the target allocator, which serves them using the
+// Prometheus HTTP service discovery format.
+type targetsDiscoveryConfig struct {
+	client          *http.Client
+	url             string
+	refreshInterval time.Duration
+}
+
+var _ discovery.Config = (*targetsDiscoveryConfig)(nil)
+
+func (c *targetsDiscoveryConfig) Name() string {
+	return "target_allocator"
+}
+
+func (c *targetsDiscoveryConfig) NewDiscoverer(opts discovery.DiscovererOptions) (discovery.Discoverer, error) {
+	d := &targetsDiscoverer{cfg: c}
+	return refresh.NewDiscovery(opts.Logger, c.Name(), c.refreshInterval, d.refresh), nil
+}
+
+type targetsDiscoverer struct {
+	cfg *targetsDiscoveryConfig
+	// number of target groups returned by the last refresh, used to clear the ones that disappeared
+	lastLength int
+}
+
+func (d *targetsDiscoverer) refresh(ctx context.Context) ([]*targetgroup.Group, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.cfg.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.cfg.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the targets from the target allocator: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the targets from the target allocator: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("target allocator returned status %q", resp.Status)
+	}
+
+	var groups []*targetgroup.Group
+	if err = json.Unmarshal(body, &groups); err != nil {
+		return nil, fmt.Errorf("failed to parse the targets from the target allocator: %w", err)
+	}
+	for i, group := range groups {
+		if group == nil {
+			return nil, errors.New("empty target group returned by the target allocator")
+		}
+		group.Source = fmt.Sprintf("%s:%d", d.cfg.url, i)
+		if group.Labels == nil {
+			group.Labels = model.LabelSet{}
+		}
+	}
+
+	// send empty groups for the sources that disappeared, so that their targets are dropped
+	length := len(groups)
+	for i := length; i < d.lastLength; i++ {
+		groups = append(groups, &targetgroup.Group{Source: fmt.Sprintf("%s:%d", d.cfg.url, i)})
+	}
+	d.lastLength = length
+	return groups, nil
+}
@@ -0,0 +1,379 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/scrape"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	otelconfig "go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
+)
+
+// mockTargetAllocator serves the scrape configs and the targets of the configured jobs.
+type mockTargetAllocator struct {
+	mu           sync.Mutex
+	scrapeConfig string
+	targets      map[string][]string
+	collectorIDs []string
+	// authorizations holds the Authorization header of every request
+	authorizations []string
+	srv            *httptest.Server
+}
+
+func newMockTargetAllocator(scrapeConfig string, targets map[string][]string) *mockTargetAllocator {
+	ta := &mockTargetAllocator{scrapeConfig: scrapeConfig, targets: targets}
+	ta.srv = httptest.NewServer(http.HandlerFunc(ta.ServeHTTP))
+	return ta
+}
+
+func (ta *mockTargetAllocator) setScrapeConfig(scrapeConfig string) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.scrapeConfig = scrapeConfig
+}
+
+func (ta *mockTargetAllocator) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	ta.authorizations = append(ta.authorizations, req.Header.Get("Authorization"))
+	if req.URL.Path == "/scrape_configs" {
+		_, _ = rw.Write([]byte(ta.scrapeConfig))
+		return
+	}
+	job := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/jobs/"), "/targets")
+	targets, ok := ta.targets[job]
+	if !ok {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+	ta.collectorIDs = append(ta.collectorIDs, req.URL.Query().Get("collector_id"))
+	body, _ := json.Marshal([]map[string]interface{}{{
+		"targets": targets,
+		"labels":  map[string]string{},
+	}})
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(body)
+}
+
+func (ta *mockTargetAllocator) requestedCollectorIDs() []string {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	return append([]string(nil), ta.collectorIDs...)
+}
+
+func (ta *mockTargetAllocator) requestAuthorizations() []string {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	return append([]string(nil), ta.authorizations...)
+}
+
+func newTestTargetAllocatorClient(t *testing.T, cfg *targetAllocator) *targetAllocatorClient {
+	client, err := newTargetAllocatorClient(cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return client
+}
+
+// gcIntervalRecorder records the garbage collection intervals set on the appendable it wraps.
+type gcIntervalRecorder struct {
+	storage.Appendable
+	mu        sync.Mutex
+	intervals []time.Duration
+}
+
+func (r *gcIntervalRecorder) SetGCInterval(gcInterval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.intervals = append(r.intervals, gcInterval)
+	if setter, ok := r.Appendable.(gcIntervalSetter); ok {
+		setter.SetGCInterval(gcInterval)
+	}
+}
+
+func (r *gcIntervalRecorder) lastInterval() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.intervals) == 0 {
+		return 0
+	}
+	return r.intervals[len(r.intervals)-1]
+}
+
+func TestTargetAllocatorScrapeConfigs(t *testing.T) {
+	ta := newMockTargetAllocator(`
+job1:
+  job_name: job1
+  scrape_interval: 5s
+job/2:
+  job_name: job/2
+  metrics_path: /custom
+`, nil)
+	defer ta.srv.Close()
+
+	client := newTestTargetAllocatorClient(t, &targetAllocator{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ta.srv.URL + "/"},
+		CollectorID:        "collector 1",
+	})
+	assert.Equal(t, defaultTargetAllocatorInterval, client.interval())
+
+	scrapeConfigs, response, err := client.scrapeConfigs(context.Background(), config.DefaultGlobalConfig)
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+	require.Len(t, scrapeConfigs, 2)
+
+	// jobs are sorted by name
+	assert.Equal(t, "job/2", scrapeConfigs[0].JobName)
+	assert.Equal(t, "/custom", scrapeConfigs[0].MetricsPath)
+	assert.Equal(t, config.DefaultGlobalConfig.ScrapeInterval, scrapeConfigs[0].ScrapeInterval)
+	assert.Equal(t, config.DefaultGlobalConfig.ScrapeTimeout, scrapeConfigs[0].ScrapeTimeout)
+	require.Len(t, scrapeConfigs[0].ServiceDiscoveryConfigs, 1)
+	assert.Equal(t, ta.srv.URL+"/jobs/job%2F2/targets?collector_id=collector+1", sdURL(t, scrapeConfigs[0]))
+
+	assert.Equal(t, "job1", scrapeConfigs[1].JobName)
+	assert.Equal(t, 5*time.Second, time.Duration(scrapeConfigs[1].ScrapeInterval))
+	assert.Equal(t, ta.srv.URL+"/jobs/job1/targets?collector_id=collector+1", sdURL(t, scrapeConfigs[1]))
+
+	// the response is returned again until it's marked as applied
+	scrapeConfigs, response, err = client.scrapeConfigs(context.Background(), config.DefaultGlobalConfig)
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	assert.Len(t, scrapeConfigs, 2)
+
+	// an unchanged response is not applied again
+	client.applied(response)
+	scrapeConfigs, response, err = client.scrapeConfigs(context.Background(), config.DefaultGlobalConfig)
+	require.NoError(t, err)
+	assert.Nil(t, response)
+	assert.Nil(t, scrapeConfigs)
+}
+
+func TestTargetAllocatorInvalidResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		scrapeConfig string
+		wantErr      string
+	}{
+		{
+			name:         "invalid yaml",
+			scrapeConfig: "job1: [",
+			wantErr:      "failed to parse the scrape configs from the target allocator",
+		},
+		{
+			name:         "empty scrape config",
+			scrapeConfig: "job1:",
+			wantErr:      `empty scrape config for job "job1"`,
+		},
+		{
+			name:         "invalid scrape timeout",
+			scrapeConfig: "job1:\n  job_name: job1\n  scrape_interval: 5s\n  scrape_timeout: 10s",
+			wantErr:      `invalid scrape config for job "job1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta := newMockTargetAllocator(tt.scrapeConfig, nil)
+			defer ta.srv.Close()
+
+			client := newTestTargetAllocatorClient(t, &targetAllocator{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ta.srv.URL},
+				CollectorID:        "collector-1",
+			})
+			_, _, err := client.scrapeConfigs(context.Background(), config.DefaultGlobalConfig)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTargetAllocatorUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := newTestTargetAllocatorClient(t, &targetAllocator{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: srv.URL},
+		CollectorID:        "collector-1",
+	})
+	_, _, err := client.scrapeConfigs(context.Background(), config.DefaultGlobalConfig)
+	assert.EqualError(t, err, `target allocator returned status "503 Service Unavailable"`)
+}
+
+func TestReceiverWithTargetAllocator(t *testing.T) {
+	metrics := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte("# TYPE test_gauge gauge\ntest_gauge 1\n"))
+	}))
+	defer metrics.Close()
+	u, err := url.Parse(metrics.URL)
+	require.NoError(t, err)
+
+	jobConfig := "%s:\n  job_name: %s\n  scrape_interval: 100ms\n  scrape_timeout: 100ms\n"
+	ta := newMockTargetAllocator(fmt.Sprintf(jobConfig, "job1", "job1"), map[string][]string{
+		"job1": {u.Host},
+		"job2": {u.Host},
+	})
+	defer ta.srv.Close()
+
+	cms := new(consumertest.MetricsSink)
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), &Config{
+		ReceiverSettings: otelconfig.NewReceiverSettings(otelconfig.NewComponentID(typeStr)),
+		TargetAllocator: &targetAllocator{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: ta.srv.URL,
+				Headers:  map[string]string{"Authorization": "Bearer token"},
+			},
+			Interval:    100 * time.Millisecond,
+			CollectorID: "collector-1",
+		},
+	}, cms)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	}()
+
+	require.Eventually(t, func() bool {
+		return cms.DataPointCount() > 0
+	}, 10*time.Second, 50*time.Millisecond, "no metrics scraped from the targets of the target allocator")
+	assert.Contains(t, receiver.scrapeManager.TargetsAll(), "job1")
+	assert.Contains(t, ta.requestedCollectorIDs(), "collector-1")
+	for _, authorization := range ta.requestAuthorizations() {
+		assert.Equal(t, "Bearer token", authorization, "the client settings should apply to the scrape configs and the targets")
+	}
+
+	// the jobs assigned by the target allocator replace the previous ones
+	ta.setScrapeConfig(fmt.Sprintf(jobConfig, "job2", "job2"))
+	require.Eventually(t, func() bool {
+		targets := receiver.scrapeManager.TargetsAll()
+		_, hasJob1 := targets["job1"]
+		_, hasJob2 := targets["job2"]
+		return !hasJob1 && hasJob2
+	}, 10*time.Second, 50*time.Millisecond, "the scrape configs of the target allocator were not applied")
+}
+
+func TestSyncTargetAllocatorUpdatesGCInterval(t *testing.T) {
+	ta := newMockTargetAllocator("job1:\n  job_name: job1\n  scrape_interval: 5m\n", nil)
+	defer ta.srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := internal.NewZapToGokitLogAdapter(zap.NewNop())
+	recorder := &gcIntervalRecorder{}
+	receiver := &pReceiver{
+		settings:         componenttest.NewNopReceiverCreateSettings(),
+		store:            recorder,
+		scrapeManager:    scrape.NewManager(&scrape.Options{}, logger, recorder),
+		discoveryManager: discovery.NewManager(ctx, logger),
+		targetAllocator: newTestTargetAllocatorClient(t, &targetAllocator{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ta.srv.URL},
+			CollectorID:        "collector-1",
+		}),
+	}
+	defer receiver.scrapeManager.Stop()
+
+	// the scrape interval of the job assigned by the target allocator is longer than the default interval
+	require.NoError(t, receiver.syncTargetAllocator(ctx, &config.Config{GlobalConfig: config.DefaultGlobalConfig}))
+	assert.Equal(t, 5*time.Minute+gcIntervalDelta, recorder.lastInterval())
+}
+
+func TestSyncTargetAllocatorRetriesFailedApply(t *testing.T) {
+	metrics := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte("# TYPE test_gauge gauge\ntest_gauge 1\n"))
+	}))
+	defer metrics.Close()
+	u, err := url.Parse(metrics.URL)
+	require.NoError(t, err)
+
+	ta := newMockTargetAllocator("job1:\n  job_name: job1\n", map[string][]string{"job1": {u.Host}})
+	defer ta.srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := internal.NewZapToGokitLogAdapter(zap.NewNop())
+	recorder := &gcIntervalRecorder{}
+	receiver := &pReceiver{
+		settings:         componenttest.NewNopReceiverCreateSettings(),
+		store:            recorder,
+		scrapeManager:    scrape.NewManager(&scrape.Options{}, logger, recorder),
+		discoveryManager: discovery.NewManager(ctx, logger),
+		targetAllocator: newTestTargetAllocatorClient(t, &targetAllocator{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ta.srv.URL},
+			CollectorID:        "collector-1",
+		}),
+	}
+	go func() { _ = receiver.discoveryManager.Run() }()
+	go func() { _ = receiver.scrapeManager.Run(receiver.discoveryManager.SyncCh()) }()
+	defer receiver.scrapeManager.Stop()
+
+	baseCfg := &config.Config{GlobalConfig: config.DefaultGlobalConfig}
+	require.NoError(t, receiver.syncTargetAllocator(ctx, baseCfg))
+	require.Eventually(t, func() bool {
+		_, ok := receiver.scrapeManager.TargetsAll()["job1"]
+		return ok
+	}, 10*time.Second, 50*time.Millisecond, "the scrape pool of the job was not created")
+
+	// the scrape pool can't be reloaded while the CA file of the new scrape config is missing
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	ta.setScrapeConfig(fmt.Sprintf("job1:\n  job_name: job1\n  scrape_interval: 5m\n  tls_config:\n    ca_file: %s\n", caFile))
+	require.Error(t, receiver.syncTargetAllocator(ctx, baseCfg))
+	assert.Equal(t, defaultGCInterval, recorder.lastInterval())
+
+	// the same response is applied on the next poll, once the CA file is available
+	ca, err := ioutil.ReadFile(filepath.Join("testdata", "ca.crt"))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(caFile, ca, 0600))
+	require.NoError(t, receiver.syncTargetAllocator(ctx, baseCfg))
+	assert.Equal(t, 5*time.Minute+gcIntervalDelta, recorder.lastInterval())
+}
+
+func TestNewTargetAllocatorClientInvalidTLS(t *testing.T) {
+	cfg := &targetAllocator{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint:   "https://localhost:8080",
+			TLSSetting: configtls.TLSClientSetting{TLSSetting: configtls.TLSSetting{CAFile: "/nonexistent/ca.pem"}},
+		},
+		CollectorID: "collector-1",
+	}
+	_, err := newTargetAllocatorClient(cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "failed to create the target allocator client")
+}
+
+func sdURL(t *testing.T, sc *config.ScrapeConfig) string {
+	sd, ok := sc.ServiceDiscoveryConfigs[0].(*targetsDiscoveryConfig)
+	require.True(t, ok)
+	return sd.url
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+var (
+	errInvalidPath                    = errors.New("the path must start with a '/'")
+	errInvalidMaxDecompressedBodySize = errors.New("the max decompressed body size must be positive")
+)
+
+// Config defines configuration for the Prometheus remote write receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the URL path on which the remote write requests are accepted, default is '/api/v1/write'
+	Path string `mapstructure:"path"`
+
+	// MaxDecompressedBodySize is the maximum size in bytes of the decompressed body of a remote write request,
+	// larger requests are rejected. Default is 32MiB.
+	MaxDecompressedBodySize int `mapstructure:"max_decompressed_body_size"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errInvalidPath
+	}
+	if cfg.MaxDecompressedBodySize <= 0 {
+		return errInvalidMaxDecompressedBodySize
+	}
+	return nil
+}
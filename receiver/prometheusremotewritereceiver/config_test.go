@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	assert.Equal(t, factory.CreateDefaultConfig(), r0)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "customname")]
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "customname")),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: "localhost:9090",
+		},
+		Path:                    "/receive",
+		MaxDecompressedBodySize: 1024,
+	}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Path = "receive"
+	assert.ErrorIs(t, cfg.Validate(), errInvalidPath)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.MaxDecompressedBodySize = 0
+	assert.ErrorIs(t, cfg.Validate(), errInvalidMaxDecompressedBodySize)
+}
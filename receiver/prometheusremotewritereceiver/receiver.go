@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+)
+
+const dataFormat = "prometheus_remote_write"
+
+// metricsReceiver accepts remote write requests over HTTP, converting them to metrics
+type metricsReceiver struct {
+	config       *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	// metadata is kept across requests since Prometheus sends it separately from the samples
+	metadata *prometheusremotewrite.MetadataCache
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+var _ component.MetricsReceiver = (*metricsReceiver)(nil)
+
+func newMetricsReceiver(config *Config, settings component.ReceiverCreateSettings, nextConsumer consumer.Metrics) (*metricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+
+	return &metricsReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		metadata:     prometheusremotewrite.NewMetadataCache(),
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: settings,
+		}),
+	}, nil
+}
+
+// Start binds the HTTP server and starts serving remote write requests.
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.config.Path, r.handleWrite)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *metricsReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.wg.Wait()
+	return err
+}
+
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// the compressed body can't be larger than the encoding of the largest accepted body
+	maxCompressedSize := int64(snappy.MaxEncodedLen(r.config.MaxDecompressedBodySize))
+	if maxCompressedSize < 0 {
+		maxCompressedSize = math.MaxUint32
+	}
+	compressed, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxCompressedSize))
+	if err != nil {
+		if int64(len(compressed)) >= maxCompressedSize {
+			http.Error(w, "the request body is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read the request body", http.StatusBadRequest)
+		return
+	}
+
+	// the remote write protocol requires the snappy block format
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		http.Error(w, "failed to decompress the request body, it must be compressed using snappy", http.StatusBadRequest)
+		return
+	}
+	if size > r.config.MaxDecompressedBodySize {
+		http.Error(w, "the decompressed request body is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		http.Error(w, "failed to decompress the request body, it must be compressed using snappy", http.StatusBadRequest)
+		return
+	}
+
+	var wr prompb.WriteRequest
+	if err = wr.Unmarshal(body); err != nil {
+		http.Error(w, "failed to decode the write request", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	md, err := prometheusremotewrite.ToMetricsWithCache(&wr, r.metadata)
+	if err != nil {
+		// the series that could be converted are still forwarded, retrying the request wouldn't help
+		r.settings.Logger.Debug("some time series couldn't be converted", zap.Error(err))
+	}
+
+	numPoints := md.DataPointCount()
+	if numPoints == 0 {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, numPoints, err)
+	if err != nil {
+		r.settings.Logger.Debug("failed to pass metrics to next consumer", zap.Error(err))
+		// Prometheus retries requests failing with a 5xx status code, but not the ones failing with a 4xx one
+		if consumererror.IsPermanent(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
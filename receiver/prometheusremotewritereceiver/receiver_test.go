@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, next consumer.Metrics) string {
+	return startReceiverWithConfig(t, createDefaultConfig().(*Config), next)
+}
+
+func startReceiverWithConfig(t *testing.T, cfg *Config, next consumer.Metrics) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r, err := newMetricsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return fmt.Sprintf("http://%s%s", cfg.Endpoint, cfg.Path)
+}
+
+func encode(t *testing.T, req *prompb.WriteRequest) []byte {
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, data)
+}
+
+func writeRequest() *prompb.WriteRequest {
+	return &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "code", Value: "200"},
+					{Name: "instance", Value: "localhost:8080"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []prompb.Sample{{Value: 42, Timestamp: time.Now().UnixMilli()}},
+			},
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests_total", Help: "Number of requests"},
+		},
+	}
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(encode(t, writeRequest())))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.Equal(t, 1, md.DataPointCount())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":        "api",
+		"service.instance.id": "localhost:8080",
+	}, rm.Resource().Attributes().AsRaw())
+
+	metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "http_requests_total", metric.Name())
+	assert.Equal(t, pmetric.MetricDataTypeSum, metric.DataType())
+	assert.Equal(t, 42.0, metric.Sum().DataPoints().At(0).DoubleVal())
+}
+
+func TestReceiveInvalidRequests(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	tests := []struct {
+		name     string
+		method   string
+		body     []byte
+		expected int
+	}{
+		{
+			name:     "wrong method",
+			method:   http.MethodGet,
+			expected: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "not compressed",
+			method:   http.MethodPost,
+			body:     []byte("not snappy"),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "not a write request",
+			method:   http.MethodPost,
+			body:     snappy.Encode(nil, []byte("not protobuf")),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:   "no convertible series",
+			method: http.MethodPost,
+			body: encode(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{{Samples: []prompb.Sample{{Value: 1}}}},
+			}),
+			expected: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestReceiveTooLargeRequests(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxDecompressedBodySize = 64
+	url := startReceiverWithConfig(t, cfg, sink)
+
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{
+			name: "compressed body over the limit",
+			body: bytes.Repeat([]byte{0xff}, snappy.MaxEncodedLen(cfg.MaxDecompressedBodySize)+1),
+		},
+		{
+			name: "decoded length over the limit",
+			body: snappy.Encode(nil, make([]byte, cfg.MaxDecompressedBodySize+1)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		})
+	}
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestReceiveMetadataInSeparateRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	samples := writeRequest()
+	metadata := &prompb.WriteRequest{Metadata: samples.Metadata}
+	samples.Metadata = nil
+
+	// a request with only metadata has no series to convert
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(encode(t, metadata)))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	resp, err = http.Post(url, "application/x-protobuf", bytes.NewReader(encode(t, samples)))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metric := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.MetricDataTypeSum, metric.DataType())
+	assert.Equal(t, "Number of requests", metric.Description())
+}
+
+func TestReceiveConsumerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "retryable",
+			err:      errors.New("temporary failure"),
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:     "permanent",
+			err:      consumererror.NewPermanent(errors.New("bad data")),
+			expected: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tt.err))
+
+			resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(encode(t, writeRequest())))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
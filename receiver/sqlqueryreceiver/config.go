@@ -15,6 +15,8 @@
 package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -29,6 +31,9 @@ type Config struct {
 	Driver                                  string  `mapstructure:"driver"`
 	DataSource                              string  `mapstructure:"datasource"`
 	Queries                                 []Query `mapstructure:"queries"`
+	// StorageID is the ID of the storage extension used to persist the last
+	// value of each query's tracking column between restarts.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
 
 func (c Config) Validate() error {
@@ -41,17 +46,32 @@ func (c Config) Validate() error {
 	if len(c.Queries) == 0 {
 		return errors.New("'queries' cannot be empty")
 	}
+	trackingKeys := make(map[string]struct{}, len(c.Queries))
 	for _, query := range c.Queries {
 		if err := query.Validate(); err != nil {
 			return err
 		}
+		if query.TrackingColumn == "" {
+			continue
+		}
+		key := query.trackingKey()
+		if _, found := trackingKeys[key]; found {
+			return fmt.Errorf("queries with a tracking column must have distinct 'sql' or 'tracking_id', '%s' is repeated", key)
+		}
+		trackingKeys[key] = struct{}{}
 	}
 	return nil
 }
 
 type Query struct {
-	SQL     string      `mapstructure:"sql"`
-	Metrics []MetricCfg `mapstructure:"metrics"`
+	SQL                string      `mapstructure:"sql"`
+	Metrics            []MetricCfg `mapstructure:"metrics"`
+	Logs               []LogsCfg   `mapstructure:"logs"`
+	TrackingColumn     string      `mapstructure:"tracking_column"`
+	TrackingStartValue string      `mapstructure:"tracking_start_value"`
+	// TrackingID identifies the persisted tracking value of the query. It defaults to a hash of SQL,
+	// and can be set to keep the tracking value when the sql statement is modified.
+	TrackingID string `mapstructure:"tracking_id"`
 }
 
 func (q Query) Validate() error {
@@ -59,17 +79,54 @@ func (q Query) Validate() error {
 	if q.SQL == "" {
 		errs = multierr.Append(errs, errors.New("'query.sql' cannot be empty"))
 	}
-	if len(q.Metrics) == 0 {
-		errs = multierr.Append(errs, errors.New("'query.metrics' cannot be empty"))
+	if len(q.Metrics) == 0 && len(q.Logs) == 0 {
+		errs = multierr.Append(errs, errors.New("'query.metrics' and 'query.logs' cannot both be empty"))
 	}
 	for _, metric := range q.Metrics {
 		if err := metric.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
 		}
 	}
+	for _, logs := range q.Logs {
+		if err := logs.Validate(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	if q.TrackingColumn != "" {
+		if len(q.Metrics) > 0 {
+			errs = multierr.Append(errs, errors.New("'query.tracking_column' is only supported for logs queries"))
+		}
+		if q.TrackingStartValue == "" {
+			errs = multierr.Append(errs, errors.New("'query.tracking_start_value' cannot be empty when 'query.tracking_column' is set"))
+		}
+	} else if q.TrackingID != "" {
+		errs = multierr.Append(errs, errors.New("'query.tracking_id' can only be set when 'query.tracking_column' is set"))
+	}
 	return errs
 }
 
+// trackingKey returns the storage key of the query's tracking value, which doesn't depend on the
+// position of the query so that queries can be reordered or added without mixing up their values.
+func (q Query) trackingKey() string {
+	if q.TrackingID != "" {
+		return "tracking_id-" + q.TrackingID
+	}
+	sum := sha256.Sum256([]byte(q.SQL))
+	return "sql-" + hex.EncodeToString(sum[:])
+}
+
+type LogsCfg struct {
+	BodyColumn       string   `mapstructure:"body_column"`
+	AttributeColumns []string `mapstructure:"attribute_columns"`
+}
+
+func (c LogsCfg) Validate() error {
+	if c.BodyColumn == "" {
+		return errors.New("'body_column' cannot be empty")
+	}
+	return nil
+}
+
 type MetricCfg struct {
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
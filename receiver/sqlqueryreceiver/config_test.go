@@ -51,6 +51,21 @@ func TestParseConfig(t *testing.T) {
 	assert.Equal(t, MetricAggregationCumulative, metric.Aggregation)
 }
 
+func TestParseConfig_Logs(t *testing.T) {
+	cfg, err := servicetest.LoadConfigAndValidate(path.Join("testdata", "config-logs.yaml"), testFactories(t))
+	require.NoError(t, err)
+	sqlCfg := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	require.NotNil(t, sqlCfg.StorageID)
+	assert.Equal(t, config.NewComponentID("nop"), *sqlCfg.StorageID)
+	q := sqlCfg.Queries[0]
+	assert.Equal(t, "select id, action, username from audit where id > ? order by id", q.SQL)
+	assert.Equal(t, "id", q.TrackingColumn)
+	assert.Equal(t, "0", q.TrackingStartValue)
+	assert.Equal(t, "audit", q.TrackingID)
+	assert.Empty(t, q.Metrics)
+	assert.Equal(t, []LogsCfg{{BodyColumn: "action", AttributeColumns: []string{"username"}}}, q.Logs)
+}
+
 func TestValidateConfig_Invalid(t *testing.T) {
 	tests := []struct {
 		fname     string
@@ -90,7 +105,23 @@ func TestValidateConfig_Invalid(t *testing.T) {
 		},
 		{
 			fname:     "config-invalid-missing-metrics.yaml",
-			errSubstr: "'query.metrics' cannot be empty",
+			errSubstr: "'query.metrics' and 'query.logs' cannot both be empty",
+		},
+		{
+			fname:     "config-invalid-missing-bodycolumn.yaml",
+			errSubstr: "'body_column' cannot be empty",
+		},
+		{
+			fname:     "config-invalid-missing-trackingstartvalue.yaml",
+			errSubstr: "'query.tracking_start_value' cannot be empty when 'query.tracking_column' is set",
+		},
+		{
+			fname:     "config-invalid-unnecessary-trackingid.yaml",
+			errSubstr: "'query.tracking_id' can only be set when 'query.tracking_column' is set",
+		},
+		{
+			fname:     "config-invalid-duplicate-trackingid.yaml",
+			errSubstr: "queries with a tracking column must have distinct 'sql' or 'tracking_id', 'tracking_id-audit' is repeated",
 		},
 		{
 			fname:     "config-invalid-missing-datasource.yaml",
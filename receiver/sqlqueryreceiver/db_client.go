@@ -31,7 +31,7 @@ import (
 )
 
 type dbClient interface {
-	metricRows(ctx context.Context) ([]metricRow, error)
+	queryRows(ctx context.Context, args ...interface{}) ([]stringMap, error)
 }
 
 type dbSQLClient struct {
@@ -48,14 +48,14 @@ func newDbClient(db *sql.DB, sql string, logger *zap.Logger) dbClient {
 	}
 }
 
-type metricRow map[string]string
+type stringMap map[string]string
 
-func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
-	sqlRows, err := cl.db.QueryContext(ctx, cl.sql)
+func (cl dbSQLClient) queryRows(ctx context.Context, args ...interface{}) ([]stringMap, error) {
+	sqlRows, err := cl.db.QueryContext(ctx, cl.sql, args...)
 	if err != nil {
 		return nil, err
 	}
-	var out []metricRow
+	var out []stringMap
 	row := reusableRow{
 		attrs: map[string]func() string{},
 	}
@@ -67,6 +67,10 @@ func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
 		colName := sqlType.Name()
 		var v interface{}
 		row.attrs[colName] = func() string {
+			// NULL values are rendered as empty strings
+			if v == nil {
+				return ""
+			}
 			format := "%v"
 			if reflect.TypeOf(v).Kind() == reflect.Slice {
 				// The Postgres driver returns a []uint8 (a string) for decimal and numeric types,
@@ -83,7 +87,7 @@ func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
 		if err != nil {
 			return nil, err
 		}
-		out = append(out, row.toMetricRow())
+		out = append(out, row.toStringMap())
 	}
 	return out, nil
 }
@@ -93,8 +97,8 @@ type reusableRow struct {
 	scanDest []interface{}
 }
 
-func (row reusableRow) toMetricRow() metricRow {
-	out := metricRow{}
+func (row reusableRow) toStringMap() stringMap {
+	out := stringMap{}
 	for k, f := range row.attrs {
 		out[k] = f()
 	}
@@ -14,15 +14,28 @@
 
 package sqlqueryreceiver
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
 
 type fakeDBClient struct {
 	requestCounter int
-	responses      [][]metricRow
+	responses      [][]stringMap
 	err            error
+	args           [][]interface{}
 }
 
-func (c *fakeDBClient) metricRows(context.Context) ([]metricRow, error) {
+func (c *fakeDBClient) queryRows(_ context.Context, args ...interface{}) ([]stringMap, error) {
+	c.args = append(c.args, args)
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -30,3 +43,76 @@ func (c *fakeDBClient) metricRows(context.Context) ([]metricRow, error) {
 	c.requestCounter++
 	return c.responses[idx], nil
 }
+
+func TestDBSQLClient_NullColumn(t *testing.T) {
+	db := sql.OpenDB(rowsConnector{
+		columns: []string{"id", "user", "amount"},
+		rows: [][]driver.Value{
+			{int64(1), nil, []byte("4.1")},
+			{int64(2), "alice", nil},
+		},
+	})
+	defer db.Close()
+
+	client := newDbClient(db, "select id, user, amount from audit", zap.NewNop())
+	rows, err := client.queryRows(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []stringMap{
+		{"id": "1", "user": "", "amount": "4.1"},
+		{"id": "2", "user": "alice", "amount": ""},
+	}, rows)
+}
+
+// rowsConnector returns the same rows for every query.
+type rowsConnector struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c rowsConnector) Connect(context.Context) (driver.Conn, error) {
+	return rowsConn(c), nil
+}
+
+func (c rowsConnector) Driver() driver.Driver {
+	return nil
+}
+
+type rowsConn rowsConnector
+
+func (c rowsConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{columns: c.columns, rows: c.rows}, nil
+}
+
+func (rowsConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (rowsConn) Close() error {
+	return nil
+}
+
+func (rowsConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
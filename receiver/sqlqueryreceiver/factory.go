@@ -21,8 +21,9 @@ import (
 )
 
 const (
-	typeStr   = "sqlquery"
-	stability = component.StabilityLevelUndefined
+	typeStr       = "sqlquery"
+	stability     = component.StabilityLevelUndefined
+	logsStability = component.StabilityLevelInDevelopment
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -30,5 +31,6 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiverAndStabilityLevel(createReceiverFunc(sql.Open, newDbClient), stability),
+		component.WithLogsReceiverAndStabilityLevel(createLogsReceiverFunc(sql.Open, newDbClient), logsStability),
 	)
 }
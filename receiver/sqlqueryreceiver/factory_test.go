@@ -38,3 +38,18 @@ func TestNewFactory(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestNewFactory_Logs(t *testing.T) {
+	factory := NewFactory()
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		component.ReceiverCreateSettings{
+			TelemetrySettings: component.TelemetrySettings{
+				TracerProvider: trace.NewNoopTracerProvider(),
+			},
+		},
+		factory.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) component.CreateLogsReceiverFunc {
+	return func(
+		ctx context.Context,
+		settings component.ReceiverCreateSettings,
+		cfg config.Receiver,
+		consumer consumer.Logs,
+	) (component.LogsReceiver, error) {
+		sqlCfg := cfg.(*Config)
+		return &logsReceiver{
+			id:       sqlCfg.ID(),
+			config:   sqlCfg,
+			logger:   settings.TelemetrySettings.Logger,
+			consumer: consumer,
+			dbProviderFunc: func() (*sql.DB, error) {
+				return sqlOpenerFunc(sqlCfg.Driver, sqlCfg.DataSource)
+			},
+			clientProviderFunc: clientProviderFunc,
+		}, nil
+	}
+}
+
+type logsReceiver struct {
+	id                 config.ComponentID
+	config             *Config
+	logger             *zap.Logger
+	consumer           consumer.Logs
+	dbProviderFunc     dbProviderFunc
+	clientProviderFunc clientProviderFunc
+	db                 *sql.DB
+	storageClient      storage.Client
+	queries            []*logsQuery
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+}
+
+var _ component.LogsReceiver = (*logsReceiver)(nil)
+
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.storageClient, err = getStorageClient(ctx, host, r.config.StorageID, r.id)
+	if err != nil {
+		return err
+	}
+	r.db, err = r.dbProviderFunc()
+	if err != nil {
+		return multierr.Append(fmt.Errorf("failed to open db connection: %w", err), r.closeStorageClient(ctx))
+	}
+	for _, query := range r.config.Queries {
+		if len(query.Logs) == 0 {
+			continue
+		}
+		q := &logsQuery{
+			key:           query.trackingKey(),
+			query:         query,
+			client:        r.clientProviderFunc(r.db, query.SQL, r.logger),
+			storageClient: r.storageClient,
+			trackingValue: query.TrackingStartValue,
+		}
+		if err = q.loadTrackingValue(ctx); err != nil {
+			r.queries = nil
+			if r.db != nil {
+				err = multierr.Append(err, r.db.Close())
+				r.db = nil
+			}
+			return multierr.Append(err, r.closeStorageClient(ctx))
+		}
+		r.queries = append(r.queries, q)
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.poll(pollCtx)
+	return nil
+}
+
+func (r *logsReceiver) poll(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.config.CollectionInterval)
+	defer ticker.Stop()
+	for {
+		r.collect(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *logsReceiver) collect(ctx context.Context) {
+	for _, q := range r.queries {
+		logs, lastTrackingValue, err := q.collect(ctx)
+		if err != nil {
+			// Nothing is emitted and the tracking value is kept, so that the rows are collected again once fixed
+			r.logger.Error("Error running logs query", zap.String("query", q.query.SQL), zap.Error(err))
+			continue
+		}
+		if logs.LogRecordCount() == 0 {
+			continue
+		}
+		if err = r.consumer.ConsumeLogs(ctx, logs); err != nil {
+			r.logger.Error("Error consuming logs", zap.String("query", q.query.SQL), zap.Error(err))
+			continue
+		}
+		if err = q.storeTrackingValue(ctx, lastTrackingValue); err != nil {
+			r.logger.Error("Error storing tracking value", zap.String("query", q.query.SQL), zap.Error(err))
+		}
+	}
+}
+
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	var errs error
+	if r.db != nil {
+		errs = multierr.Append(errs, r.db.Close())
+	}
+	return multierr.Append(errs, r.closeStorageClient(ctx))
+}
+
+// closeStorageClient closes the storage client, if any, so that it isn't closed again by Shutdown.
+func (r *logsReceiver) closeStorageClient(ctx context.Context) error {
+	if r.storageClient == nil {
+		return nil
+	}
+	err := r.storageClient.Close(ctx)
+	r.storageClient = nil
+	return err
+}
+
+// logsQuery turns the rows returned by a single query into log records,
+// keeping track of the last value seen in the query's tracking column.
+type logsQuery struct {
+	key           string
+	query         Query
+	client        dbClient
+	storageClient storage.Client
+	trackingValue string
+}
+
+func (q *logsQuery) collect(ctx context.Context) (plog.Logs, string, error) {
+	out := plog.NewLogs()
+	var args []interface{}
+	if q.query.TrackingColumn != "" {
+		args = append(args, q.trackingValue)
+	}
+	rows, err := q.client.queryRows(ctx, args...)
+	if err != nil {
+		return out, q.trackingValue, fmt.Errorf("logsQuery: %w", err)
+	}
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	lrs := out.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lastTrackingValue := q.trackingValue
+	var errs error
+	for i, row := range rows {
+		for _, logsCfg := range q.query.Logs {
+			if err = rowToLog(row, logsCfg, lrs.AppendEmpty(), ts); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("row %d: %w", i, err))
+			}
+		}
+		if q.query.TrackingColumn == "" {
+			continue
+		}
+		if v, found := row[q.query.TrackingColumn]; found {
+			lastTrackingValue = v
+		} else {
+			errs = multierr.Append(errs, fmt.Errorf("row %d: tracking_column not found: '%s'", i, q.query.TrackingColumn))
+		}
+	}
+	if errs != nil {
+		errs = fmt.Errorf("logsQuery row conversion errors: %w", errs)
+	}
+	return out, lastTrackingValue, errs
+}
+
+func (q *logsQuery) loadTrackingValue(ctx context.Context) error {
+	if q.query.TrackingColumn == "" {
+		return nil
+	}
+	v, err := q.storageClient.Get(ctx, q.key)
+	if err != nil {
+		return fmt.Errorf("failed to read tracking value: %w", err)
+	}
+	if v != nil {
+		q.trackingValue = string(v)
+	}
+	return nil
+}
+
+func (q *logsQuery) storeTrackingValue(ctx context.Context, value string) error {
+	if q.query.TrackingColumn == "" || value == q.trackingValue {
+		return nil
+	}
+	q.trackingValue = value
+	return q.storageClient.Set(ctx, q.key, []byte(value))
+}
+
+func rowToLog(row stringMap, cfg LogsCfg, dest plog.LogRecord, ts pcommon.Timestamp) error {
+	dest.SetObservedTimestamp(ts)
+	body, found := row[cfg.BodyColumn]
+	if !found {
+		return fmt.Errorf("rowToLog: body_column '%s' not found in result set", cfg.BodyColumn)
+	}
+	dest.Body().SetStringVal(body)
+	attrs := dest.Attributes()
+	for _, columnName := range cfg.AttributeColumns {
+		if attrVal, found := row[columnName]; found {
+			attrs.InsertString(columnName, attrVal)
+		} else {
+			return fmt.Errorf("rowToLog: attribute_column not found: '%s'", columnName)
+		}
+	}
+	return nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+	ext, found := host.GetExtensions()[*storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindReceiver, componentID, "")
+}
@@ -0,0 +1,357 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+)
+
+func TestLogsQuery_Collect(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{{
+			{"id": "1", "action": "login", "user": "alice"},
+			{"id": "2", "action": "logout", "user": "bob"},
+		}},
+	}
+	q := &logsQuery{
+		query: Query{
+			Logs:           []LogsCfg{{BodyColumn: "action", AttributeColumns: []string{"user"}}},
+			TrackingColumn: "id",
+		},
+		client:        client,
+		trackingValue: "0",
+	}
+	logs, lastTrackingValue, err := q.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2", lastTrackingValue)
+	assert.Equal(t, [][]interface{}{{"0"}}, client.args)
+	require.Equal(t, 2, logs.LogRecordCount())
+	lrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "login", lrs.At(0).Body().StringVal())
+	user, _ := lrs.At(0).Attributes().Get("user")
+	assert.Equal(t, "alice", user.StringVal())
+	assert.NotZero(t, lrs.At(0).ObservedTimestamp())
+	assert.Equal(t, "logout", lrs.At(1).Body().StringVal())
+}
+
+func TestLogsQuery_CollectWithoutTracking(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{{{"action": "login"}}},
+	}
+	q := &logsQuery{
+		query:  Query{Logs: []LogsCfg{{BodyColumn: "action"}}},
+		client: client,
+	}
+	logs, _, err := q.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, [][]interface{}{nil}, client.args)
+}
+
+func TestLogsQuery_CollectRowErrors(t *testing.T) {
+	client := &fakeDBClient{
+		responses: [][]stringMap{{{"id": "1", "msg": "hello"}}},
+	}
+	q := &logsQuery{
+		query: Query{
+			Logs:           []LogsCfg{{BodyColumn: "action", AttributeColumns: []string{"user"}}},
+			TrackingColumn: "seq",
+		},
+		client:        client,
+		trackingValue: "0",
+	}
+	_, lastTrackingValue, err := q.collect(context.Background())
+	assert.ErrorContains(t, err, "row 0: rowToLog: body_column 'action' not found in result set")
+	assert.ErrorContains(t, err, "row 0: tracking_column not found: 'seq'")
+	assert.Equal(t, "0", lastTrackingValue)
+}
+
+func TestLogsQuery_ClientError(t *testing.T) {
+	q := &logsQuery{
+		query:  Query{Logs: []LogsCfg{{BodyColumn: "action"}}},
+		client: &fakeDBClient{err: errors.New("oops")},
+	}
+	logs, _, err := q.collect(context.Background())
+	assert.EqualError(t, err, "logsQuery: oops")
+	assert.Equal(t, 0, logs.LogRecordCount())
+}
+
+func TestLogsReceiver_TrackingValuePersisted(t *testing.T) {
+	storageExt := &fakeStorageExtension{client: &fakeStorageClient{data: map[string][]byte{
+		Query{SQL: "select id, action from audit where id > ? order by id"}.trackingKey(): []byte("41"),
+	}}}
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("nop"): storageExt},
+	}
+	client := &fakeDBClient{
+		responses: [][]stringMap{
+			{{"id": "42", "action": "login"}, {"id": "43", "action": "logout"}},
+			{},
+		},
+	}
+	sink := &consumertest.LogsSink{}
+	storageID := config.NewComponentID("nop")
+	rcvr, err := createLogsReceiverFunc(
+		func(string, string) (*sql.DB, error) { return nil, nil },
+		func(*sql.DB, string, *zap.Logger) dbClient { return client },
+	)(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				CollectionInterval: time.Hour,
+			},
+			StorageID: &storageID,
+			Queries: []Query{
+				{
+					SQL:     "select count(*) as count from audit",
+					Metrics: []MetricCfg{{MetricName: "audit.count", ValueColumn: "count"}},
+				},
+				{
+					SQL:                "select id, action from audit where id > ? order by id",
+					Logs:               []LogsCfg{{BodyColumn: "action"}},
+					TrackingColumn:     "id",
+					TrackingStartValue: "0",
+				},
+			},
+		},
+		sink,
+	)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 2
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	assert.Equal(t, []interface{}{"41"}, client.args[0])
+	value, err := storageExt.client.Get(context.Background(), Query{SQL: "select id, action from audit where id > ? order by id"}.trackingKey())
+	require.NoError(t, err)
+	assert.Equal(t, "43", string(value))
+	assert.True(t, storageExt.client.closed)
+}
+
+func TestLogsReceiver_CollectErrorKeepsTrackingValue(t *testing.T) {
+	storageExt := &fakeStorageExtension{client: &fakeStorageClient{data: map[string][]byte{}}}
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("nop"): storageExt},
+	}
+	client := &fakeDBClient{
+		responses: [][]stringMap{{{"id": "1", "action": "login"}}},
+	}
+	sink := &consumertest.LogsSink{}
+	storageID := config.NewComponentID("nop")
+	query := Query{
+		SQL:                "select id, action from audit where id > ? order by id",
+		Logs:               []LogsCfg{{BodyColumn: "action", AttributeColumns: []string{"user"}}},
+		TrackingColumn:     "id",
+		TrackingStartValue: "0",
+	}
+	rcvr, err := createLogsReceiverFunc(
+		func(string, string) (*sql.DB, error) { return nil, nil },
+		func(*sql.DB, string, *zap.Logger) dbClient { return client },
+	)(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				CollectionInterval: time.Hour,
+			},
+			StorageID: &storageID,
+			Queries:   []Query{query},
+		},
+		sink,
+	)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// the user attribute column is missing, so the row is neither emitted nor skipped
+	require.Len(t, client.args, 1)
+	assert.Equal(t, 0, sink.LogRecordCount())
+	value, err := storageExt.client.Get(context.Background(), query.trackingKey())
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestLogsReceiver_LoadTrackingValueError(t *testing.T) {
+	storageExt := &fakeStorageExtension{client: &fakeStorageClient{getErr: errors.New("oops")}}
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("nop"): storageExt},
+	}
+	db := sql.OpenDB(nopConnector{})
+	storageID := config.NewComponentID("nop")
+	rcvr, err := createLogsReceiverFunc(
+		func(string, string) (*sql.DB, error) { return db, nil },
+		mkFakeClient,
+	)(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		&Config{
+			StorageID: &storageID,
+			Queries: []Query{{
+				SQL:                "select id, action from audit where id > ? order by id",
+				Logs:               []LogsCfg{{BodyColumn: "action"}},
+				TrackingColumn:     "id",
+				TrackingStartValue: "0",
+			}},
+		},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	err = rcvr.Start(context.Background(), host)
+	assert.EqualError(t, err, "failed to read tracking value: oops")
+	assert.EqualError(t, db.Ping(), "sql: database is closed")
+	assert.True(t, storageExt.client.closed)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+}
+
+func TestLogsReceiver_OpenDBErrorClosesStorageClient(t *testing.T) {
+	storageExt := &fakeStorageExtension{client: &fakeStorageClient{data: map[string][]byte{}}}
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("nop"): storageExt},
+	}
+	storageID := config.NewComponentID("nop")
+	rcvr, err := createLogsReceiverFunc(
+		func(string, string) (*sql.DB, error) { return nil, errors.New("oops") },
+		mkFakeClient,
+	)(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		&Config{StorageID: &storageID},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	err = rcvr.Start(context.Background(), host)
+	assert.EqualError(t, err, "failed to open db connection: oops")
+	assert.True(t, storageExt.client.closed)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+}
+
+func TestLogsReceiver_StorageNotFound(t *testing.T) {
+	storageID := config.NewComponentID("missing")
+	rcvr, err := createLogsReceiverFunc(fakeDBConnect, mkFakeClient)(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		&Config{StorageID: &storageID},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	err = rcvr.Start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "storage extension 'missing' not found")
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+}
+
+func TestGetStorageClient_NonStorageExtension(t *testing.T) {
+	storageID := config.NewComponentID("nop")
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{storageID: &nopExtension{}},
+	}
+	_, err := getStorageClient(context.Background(), host, &storageID, config.NewComponentID(typeStr))
+	assert.EqualError(t, err, "non-storage extension 'nop' found")
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type fakeStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *fakeStorageClient
+}
+
+func (e *fakeStorageExtension) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return e.client, nil
+}
+
+type fakeStorageClient struct {
+	mu     sync.Mutex
+	data   map[string][]byte
+	getErr error
+	closed bool
+}
+
+func (c *fakeStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], c.getErr
+}
+
+func (c *fakeStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *fakeStorageClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *fakeStorageClient) Batch(context.Context, ...storage.Operation) error {
+	return errors.New("not implemented")
+}
+
+func (c *fakeStorageClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
+
+type nopConnector struct{}
+
+func (nopConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (nopConnector) Driver() driver.Driver {
+	return nil
+}
@@ -16,6 +16,7 @@ package statsdreceiver // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -33,6 +34,14 @@ type Config struct {
 	EnableMetricType        bool                             `mapstructure:"enable_metric_type"`
 	IsMonotonicCounter      bool                             `mapstructure:"is_monotonic_counter"`
 	TimerHistogramMapping   []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+
+	// TCPIdleTimeout is the timeout for idle TCP connections, it is ignored
+	// if the transport being used is not TCP.
+	TCPIdleTimeout time.Duration `mapstructure:"tcp_idle_timeout"`
+
+	// TCPMaxConnections is the maximum number of concurrent TCP connections,
+	// zero means no limit. It is ignored if the transport being used is not TCP.
+	TCPMaxConnections int `mapstructure:"tcp_max_connections"`
 }
 
 func (c *Config) validate() error {
@@ -43,6 +52,14 @@ func (c *Config) validate() error {
 		errs = multierr.Append(errs, fmt.Errorf("aggregation_interval must be a positive duration"))
 	}
 
+	if c.TCPIdleTimeout < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("tcp_idle_timeout cannot be negative"))
+	}
+
+	if c.TCPMaxConnections < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("tcp_max_connections cannot be negative"))
+	}
+
 	var TimerHistogramMappingMissingObjectName bool
 	for _, eachMap := range c.TimerHistogramMapping {
 
@@ -52,7 +69,7 @@ func (c *Config) validate() error {
 		}
 
 		switch eachMap.StatsdType {
-		case protocol.TimingTypeName, protocol.TimingAltTypeName, protocol.HistogramTypeName:
+		case protocol.TimingTypeName, protocol.TimingAltTypeName, protocol.HistogramTypeName, protocol.DistributionTypeName:
 		default:
 			errs = multierr.Append(errs, fmt.Errorf("statsd_type is not a supported mapping: %s", eachMap.StatsdType))
 		}
@@ -64,6 +81,16 @@ func (c *Config) validate() error {
 
 		switch eachMap.ObserverType {
 		case protocol.GaugeObserver, protocol.SummaryObserver:
+		case protocol.HistogramObserver:
+			if eachMap.Histogram.MaxSize < 0 {
+				errs = multierr.Append(errs, fmt.Errorf("histogram max_size cannot be negative: %s", eachMap.StatsdType))
+			}
+		case protocol.ExplicitHistogramObserver:
+			if len(eachMap.Histogram.ExplicitBounds) == 0 {
+				errs = multierr.Append(errs, fmt.Errorf("histogram explicit_bounds must be specified for the explicit_histogram observer: %s", eachMap.StatsdType))
+			} else if !sort.Float64sAreSorted(eachMap.Histogram.ExplicitBounds) {
+				errs = multierr.Append(errs, fmt.Errorf("histogram explicit_bounds must be sorted in increasing order: %s", eachMap.StatsdType))
+			}
 		default:
 			errs = multierr.Append(errs, fmt.Errorf("observer_type is not supported: %s", eachMap.ObserverType))
 		}
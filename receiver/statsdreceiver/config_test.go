@@ -41,7 +41,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
 	assert.Equal(t, factory.CreateDefaultConfig(), r0)
@@ -56,6 +56,17 @@ func TestLoadConfig(t *testing.T) {
 		AggregationInterval:   70 * time.Second,
 		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}},
 	}, r1)
+
+	r2 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "tcp")].(*Config)
+	assert.Equal(t, confignet.NetAddr{Endpoint: "localhost:8125", Transport: "tcp"}, r2.NetAddr)
+	assert.Equal(t, 10*time.Second, r2.TCPIdleTimeout)
+	assert.Equal(t, 100, r2.TCPMaxConnections)
+
+	r3 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "dogstatsd")].(*Config)
+	assert.Equal(t, []protocol.TimerHistogramMapping{
+		{StatsdType: "distribution", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: 100}},
+		{StatsdType: "timing", ObserverType: "explicit_histogram", Histogram: protocol.HistogramConfig{ExplicitBounds: []float64{10, 100, 1000}}},
+	}, r3.TimerHistogramMapping)
 }
 
 func TestValidate(t *testing.T) {
@@ -70,6 +81,11 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		negativeTCPIdleTimeoutErr      = "tcp_idle_timeout cannot be negative"
+		negativeTCPMaxConnectionsErr   = "tcp_max_connections cannot be negative"
+		negativeHistogramMaxSizeErr    = "histogram max_size cannot be negative: %s"
+		noExplicitBoundsErr            = "histogram explicit_bounds must be specified for the explicit_histogram observer: %s"
+		unsortedExplicitBoundsErr      = "histogram explicit_bounds must be sorted in increasing order: %s"
 	)
 
 	tests := []test{
@@ -123,6 +139,52 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "negativeTCPIdleTimeout",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TCPIdleTimeout:      -1,
+			},
+			expectedErr: negativeTCPIdleTimeoutErr,
+		},
+		{
+			name: "negativeTCPMaxConnections",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TCPMaxConnections:   -1,
+			},
+			expectedErr: negativeTCPMaxConnectionsErr,
+		},
+		{
+			name: "negativeHistogramMaxSize",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "distribution", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: -1}},
+				},
+			},
+			expectedErr: fmt.Sprintf(negativeHistogramMaxSizeErr, "distribution"),
+		},
+		{
+			name: "noExplicitBounds",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "distribution", ObserverType: "explicit_histogram"},
+				},
+			},
+			expectedErr: fmt.Sprintf(noExplicitBoundsErr, "distribution"),
+		},
+		{
+			name: "unsortedExplicitBounds",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "explicit_histogram", Histogram: protocol.HistogramConfig{ExplicitBounds: []float64{10, 1}}},
+				},
+			},
+			expectedErr: fmt.Sprintf(unsortedExplicitBoundsErr, "timer"),
+		},
 	}
 
 	for _, test := range tests {
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -30,6 +31,7 @@ const (
 	// The value of "type" key in configuration.
 	typeStr                    = "statsd"
 	stability                  = component.StabilityLevelBeta
+	logsStability              = component.StabilityLevelInDevelopment
 	defaultBindEndpoint        = "localhost:8125"
 	defaultTransport           = "udp"
 	defaultAggregationInterval = 60 * time.Second
@@ -47,6 +49,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiverAndStabilityLevel(createMetricsReceiver, stability),
+		component.WithLogsReceiverAndStabilityLevel(createLogsReceiver, logsStability),
 	)
 }
 
@@ -57,10 +60,11 @@ func createDefaultConfig() config.Receiver {
 			Endpoint:  defaultBindEndpoint,
 			Transport: defaultTransport,
 		},
-		AggregationInterval:   defaultAggregationInterval,
-		EnableMetricType:      defaultEnableMetricType,
-		IsMonotonicCounter:    defaultIsMonotonicCounter,
-		TimerHistogramMapping: defaultTimerHistogramMapping,
+		AggregationInterval: defaultAggregationInterval,
+		EnableMetricType:    defaultEnableMetricType,
+		IsMonotonicCounter:  defaultIsMonotonicCounter,
+		// Copied so that unmarshaling a configuration does not modify the defaults.
+		TimerHistogramMapping: append([]protocol.TimerHistogramMapping(nil), defaultTimerHistogramMapping...),
 	}
 }
 
@@ -70,10 +74,49 @@ func createMetricsReceiver(
 	cfg config.Receiver,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
-	c := cfg.(*Config)
-	err := c.validate()
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params, cfg)
 	if err != nil {
 		return nil, err
 	}
-	return New(params, *c, consumer)
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+	return r, nil
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+func getOrCreateReceiver(params component.ReceiverCreateSettings, cfg config.Receiver) (*sharedcomponent.SharedComponent, error) {
+	c := cfg.(*Config)
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv *statsdReceiver
+		rcv, err = newReceiver(params, *c)
+		return rcv
+	})
+	return r, err
+}
+
+// This is the map of already created StatsD receivers for particular configurations.
+// The metrics and logs receivers of a configuration share one statsdReceiver, since
+// they must listen on the same endpoint.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -19,10 +19,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -72,3 +74,31 @@ func TestCreateMetricsReceiverWithNilConsumer(t *testing.T) {
 	assert.Error(t, err, "nil consumer")
 	assert.Nil(t, receiver)
 }
+
+func TestCreateMetricsAndLogsReceiversShareInstance(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+	params := componenttest.NewNopReceiverCreateSettings()
+
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, mReceiver, lReceiver)
+
+	rcv := mReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*statsdReceiver)
+	assert.NotNil(t, rcv.nextConsumer)
+	assert.NotNil(t, rcv.logsConsumer)
+}
+
+func TestCreateLogsReceiverWithNilConsumer(t *testing.T) {
+	receiver, err := createLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		createDefaultConfig(),
+		nil,
+	)
+
+	assert.ErrorIs(t, err, component.ErrNilNextConsumer)
+	assert.Nil(t, receiver)
+}
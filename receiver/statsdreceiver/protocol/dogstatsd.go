@@ -0,0 +1,196 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+	containerIDPrefix  = "c:"
+	messagePrefix      = "m:"
+
+	attributeEventTitle          = "event.title"
+	attributeEventPriority       = "event.priority"
+	attributeEventAlertType      = "event.alert_type"
+	attributeEventAggregationKey = "event.aggregation_key"
+	attributeEventSourceTypeName = "event.source_type_name"
+	attributeServiceCheckName    = "service_check.name"
+	attributeServiceCheckStatus  = "service_check.status"
+)
+
+// serviceCheckStatuses maps the DogStatsD service check status codes to their names.
+var serviceCheckStatuses = []string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// parseEvent parses a DogStatsD event of the form
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|#<tags>
+// into lr.
+func parseEvent(line string, lr plog.LogRecord) error {
+	header := strings.TrimPrefix(line, eventPrefix)
+	headerEnd := strings.Index(header, "}:")
+	if headerEnd < 0 {
+		return fmt.Errorf("invalid event format: %s", line)
+	}
+	lengths := strings.Split(header[:headerEnd], ",")
+	if len(lengths) != 2 {
+		return fmt.Errorf("invalid event lengths: %s", header[:headerEnd])
+	}
+	titleLen, err := strconv.Atoi(lengths[0])
+	if err != nil || titleLen <= 0 {
+		return fmt.Errorf("invalid event title length: %s", lengths[0])
+	}
+	textLen, err := strconv.Atoi(lengths[1])
+	if err != nil || textLen < 0 {
+		return fmt.Errorf("invalid event text length: %s", lengths[1])
+	}
+
+	content := header[headerEnd+2:]
+	if len(content) < titleLen+1+textLen || content[titleLen] != '|' {
+		return fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := content[:titleLen]
+	text := content[titleLen+1 : titleLen+1+textLen]
+	rest := content[titleLen+1+textLen:]
+	if rest != "" && rest[0] != '|' {
+		return fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+	lr.SetSeverityNumber(plog.SeverityNumberINFO)
+	lr.SetSeverityText("info")
+	lr.Body().SetStringVal(strings.ReplaceAll(text, `\n`, "\n"))
+	attrs := lr.Attributes()
+	attrs.InsertString(attributeEventTitle, title)
+
+	if rest == "" {
+		return nil
+	}
+	for _, part := range strings.Split(rest[1:], "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			ts, err := parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return err
+			}
+			lr.SetTimestamp(ts)
+		case strings.HasPrefix(part, "h:"):
+			attrs.UpsertString(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "k:"):
+			attrs.UpsertString(attributeEventAggregationKey, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "p:"):
+			attrs.UpsertString(attributeEventPriority, strings.TrimPrefix(part, "p:"))
+		case strings.HasPrefix(part, "s:"):
+			attrs.UpsertString(attributeEventSourceTypeName, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, "t:"):
+			alertType := strings.TrimPrefix(part, "t:")
+			attrs.UpsertString(attributeEventAlertType, alertType)
+			switch alertType {
+			case "error":
+				lr.SetSeverityNumber(plog.SeverityNumberERROR)
+			case "warning":
+				lr.SetSeverityNumber(plog.SeverityNumberWARN)
+			}
+			lr.SetSeverityText(alertType)
+		case strings.HasPrefix(part, "#"):
+			if err := insertTags(strings.TrimPrefix(part, "#"), attrs); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+	return nil
+}
+
+// parseServiceCheck parses a DogStatsD service check of the form
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+// into lr.
+func parseServiceCheck(line string, lr plog.LogRecord) error {
+	parts := strings.Split(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if len(parts) < 2 || parts[0] == "" {
+		return fmt.Errorf("invalid service check format: %s", line)
+	}
+	status, err := strconv.Atoi(parts[1])
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return fmt.Errorf("invalid service check status: %s", parts[1])
+	}
+
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+	lr.SetSeverityText(serviceCheckStatuses[status])
+	switch status {
+	case 0:
+		lr.SetSeverityNumber(plog.SeverityNumberINFO)
+	case 1:
+		lr.SetSeverityNumber(plog.SeverityNumberWARN)
+	case 2:
+		lr.SetSeverityNumber(plog.SeverityNumberERROR)
+	}
+	attrs := lr.Attributes()
+	attrs.InsertString(attributeServiceCheckName, parts[0])
+	attrs.InsertString(attributeServiceCheckStatus, serviceCheckStatuses[status])
+
+	for i, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			ts, err := parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return err
+			}
+			lr.SetTimestamp(ts)
+		case strings.HasPrefix(part, "h:"):
+			attrs.UpsertString(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "#"):
+			if err := insertTags(strings.TrimPrefix(part, "#"), attrs); err != nil {
+				return err
+			}
+		case strings.HasPrefix(part, messagePrefix):
+			// The message is always the last field and may itself contain '|'.
+			message := strings.Join(parts[2+i:], "|")
+			lr.Body().SetStringVal(strings.TrimPrefix(message, messagePrefix))
+			return nil
+		default:
+			return fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+	return nil
+}
+
+func parseTimestamp(s string) (pcommon.Timestamp, error) {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse timestamp: %s", s)
+	}
+	return pcommon.NewTimestampFromTime(time.Unix(secs, 0)), nil
+}
+
+func insertTags(tagsStr string, attrs pcommon.Map) error {
+	tags, err := parseTags(tagsStr)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		attrs.UpsertString(string(tag.Key), tag.Value.AsString())
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestParseEvent(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		body     string
+		severity plog.SeverityNumber
+		attrs    map[string]interface{}
+		ts       pcommon.Timestamp
+		err      error
+	}{
+		{
+			name:     "title and text",
+			input:    "_e{5,9}:title|some text",
+			body:     "some text",
+			severity: plog.SeverityNumberINFO,
+			attrs:    map[string]interface{}{"event.title": "title"},
+		},
+		{
+			name:     "all fields",
+			input:    `_e{9,12}:dep|loyed|line1\nline2|d:1600000000|h:host1|k:key|p:low|s:src|t:error|#env:prod,team:a`,
+			body:     "line1\nline2",
+			severity: plog.SeverityNumberERROR,
+			attrs: map[string]interface{}{
+				"event.title":            "dep|loyed",
+				"host.name":              "host1",
+				"event.aggregation_key":  "key",
+				"event.priority":         "low",
+				"event.source_type_name": "src",
+				"event.alert_type":       "error",
+				"env":                    "prod",
+				"team":                   "a",
+			},
+			ts: pcommon.NewTimestampFromTime(time.Unix(1600000000, 0)),
+		},
+		{
+			name:  "missing header end",
+			input: "_e{5,4title|text",
+			err:   errors.New("invalid event format: _e{5,4title|text"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{x,4}:title|text",
+			err:   errors.New("invalid event title length: x"),
+		},
+		{
+			name:  "lengths not matching",
+			input: "_e{4,4}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{4,4}:title|text"),
+		},
+		{
+			name:  "text longer than length",
+			input: "_e{5,2}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,2}:title|text"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized event part: x:y"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:now",
+			err:   errors.New("parse timestamp: now"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			err := parseEvent(tt.input, lr)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, lr.Body().StringVal())
+			assert.Equal(t, tt.severity, lr.SeverityNumber())
+			assert.Equal(t, tt.attrs, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.ts, lr.Timestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), lr.ObservedTimestamp())
+		})
+	}
+}
+
+func TestParseServiceCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		body         string
+		severity     plog.SeverityNumber
+		severityText string
+		attrs        map[string]interface{}
+		ts           pcommon.Timestamp
+		err          error
+	}{
+		{
+			name:         "name and status",
+			input:        "_sc|my.check|0",
+			severity:     plog.SeverityNumberINFO,
+			severityText: "OK",
+			attrs:        map[string]interface{}{"service_check.name": "my.check", "service_check.status": "OK"},
+		},
+		{
+			name:         "all fields",
+			input:        "_sc|my.check|1|d:1600000000|h:host1|#env:prod|m:disk | almost full",
+			body:         "disk | almost full",
+			severity:     plog.SeverityNumberWARN,
+			severityText: "WARNING",
+			attrs: map[string]interface{}{
+				"service_check.name":   "my.check",
+				"service_check.status": "WARNING",
+				"host.name":            "host1",
+				"env":                  "prod",
+			},
+			ts: pcommon.NewTimestampFromTime(time.Unix(1600000000, 0)),
+		},
+		{
+			name:         "unknown status",
+			input:        "_sc|my.check|3",
+			severityText: "UNKNOWN",
+			attrs:        map[string]interface{}{"service_check.name": "my.check", "service_check.status": "UNKNOWN"},
+		},
+		{
+			name:  "missing status",
+			input: "_sc|my.check",
+			err:   errors.New("invalid service check format: _sc|my.check"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|my.check|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "invalid tags",
+			input: "_sc|my.check|0|#env",
+			err:   errors.New("invalid tag format: [env]"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_sc|my.check|0|x:y",
+			err:   errors.New("unrecognized service check part: x:y"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			err := parseServiceCheck(tt.input, lr)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, lr.Body().AsString())
+			assert.Equal(t, tt.severity, lr.SeverityNumber())
+			assert.Equal(t, tt.severityText, lr.SeverityText())
+			assert.Equal(t, tt.attrs, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.ts, lr.Timestamp())
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 
 import (
+	"math"
 	"sort"
 	"time"
 
@@ -102,6 +103,163 @@ func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, sta
 	}
 }
 
+func buildExplicitHistogramMetric(desc statsDMetricDescription, histogram summaryMetric, startTime, timeNow time.Time, bounds []float64, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	nm.SetDataType(pmetric.MetricDataTypeHistogram)
+	nm.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+
+	dp := nm.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().InsertString(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+
+	// Bucket i holds the values in (bounds[i-1], bounds[i]].
+	weights := make([]float64, len(bounds)+1)
+	sum := float64(0)
+	for i, point := range histogram.points {
+		weights[sort.SearchFloat64s(bounds, point)] += histogram.weights[i]
+		sum += point * histogram.weights[i]
+	}
+	counts, count := roundWeights(weights)
+
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(minFloat64(histogram.points))
+	dp.SetMax(maxFloat64(histogram.points))
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+}
+
+const (
+	exponentialHistogramMaxScale = 20
+	exponentialHistogramMinScale = -10
+)
+
+func buildExponentialHistogramMetric(desc statsDMetricDescription, histogram summaryMetric, startTime, timeNow time.Time, maxSize int32, ilm pmetric.ScopeMetrics) {
+	if maxSize <= 0 {
+		maxSize = DefaultHistogramMaxSize
+	}
+
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	nm.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	nm.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+
+	dp := nm.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().InsertString(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+
+	// Use the finest scale at which both the positive and the negative
+	// values fit in maxSize buckets.
+	scale := int32(exponentialHistogramMaxScale)
+	for ; scale > exponentialHistogramMinScale; scale-- {
+		_, positiveSize := exponentialBucketsRange(histogram.points, 1, scale)
+		_, negativeSize := exponentialBucketsRange(histogram.points, -1, scale)
+		if positiveSize <= maxSize && negativeSize <= maxSize {
+			break
+		}
+	}
+
+	zeroWeight := float64(0)
+	sum := float64(0)
+	for i, point := range histogram.points {
+		sum += point * histogram.weights[i]
+		if point == 0 {
+			zeroWeight += histogram.weights[i]
+		}
+	}
+	positiveCount := fillExponentialBuckets(dp.Positive(), histogram, 1, scale)
+	negativeCount := fillExponentialBuckets(dp.Negative(), histogram, -1, scale)
+	zeroCount := uint64(zeroWeight)
+
+	dp.SetScale(scale)
+	dp.SetZeroCount(zeroCount)
+	dp.SetCount(zeroCount + positiveCount + negativeCount)
+	dp.SetSum(sum)
+	dp.SetMin(minFloat64(histogram.points))
+	dp.SetMax(maxFloat64(histogram.points))
+}
+
+// exponentialIndex returns the index of the bucket holding the absolute value v,
+// bucket i holds the values in (base^i, base^(i+1)] where base = 2^(2^-scale).
+func exponentialIndex(v float64, scale int32) int32 {
+	return int32(math.Ceil(math.Log2(math.Abs(v))*math.Ldexp(1, int(scale)))) - 1
+}
+
+// exponentialBucketsRange returns the index of the first bucket and the number
+// of buckets needed at scale to hold the points with the given sign.
+func exponentialBucketsRange(points []float64, sign float64, scale int32) (int32, int32) {
+	lo, hi := int32(math.MaxInt32), int32(math.MinInt32)
+	for _, point := range points {
+		if point*sign <= 0 {
+			continue
+		}
+		idx := exponentialIndex(point, scale)
+		if idx < lo {
+			lo = idx
+		}
+		if idx > hi {
+			hi = idx
+		}
+	}
+	if hi < lo {
+		return 0, 0
+	}
+	return lo, hi - lo + 1
+}
+
+func fillExponentialBuckets(buckets pmetric.Buckets, histogram summaryMetric, sign float64, scale int32) uint64 {
+	offset, size := exponentialBucketsRange(histogram.points, sign, scale)
+	if size == 0 {
+		return 0
+	}
+	weights := make([]float64, size)
+	for i, point := range histogram.points {
+		if point*sign > 0 {
+			weights[exponentialIndex(point, scale)-offset] += histogram.weights[i]
+		}
+	}
+	counts, count := roundWeights(weights)
+	buckets.SetOffset(offset)
+	buckets.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+	return count
+}
+
+// roundWeights converts the bucket weights, which account for the sample rate,
+// to bucket counts and returns them along with their total.
+// Note: counts are rounded here, see note in counterValue().
+func roundWeights(weights []float64) ([]uint64, uint64) {
+	counts := make([]uint64, len(weights))
+	total := uint64(0)
+	for i, w := range weights {
+		counts[i] = uint64(w)
+		total += counts[i]
+	}
+	return counts, total
+}
+
+func minFloat64(values []float64) float64 {
+	m := math.Inf(1)
+	for _, v := range values {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func maxFloat64(values []float64) float64 {
+	m := math.Inf(-1)
+	for _, v := range values {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
 func (s statsDMetric) counterValue() int64 {
 	x := s.asFloat
 	// Note statds counters are always represented as integers.
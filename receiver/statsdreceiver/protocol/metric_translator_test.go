@@ -208,3 +208,94 @@ func TestBuildSummaryMetricSampled(t *testing.T) {
 		assert.Equal(t, expectedMetric, metric)
 	}
 }
+
+func TestBuildExplicitHistogramMetric(t *testing.T) {
+	timeNow := time.Now()
+	desc := statsDMetricDescription{
+		name:       "testHistogram",
+		metricType: DistributionType,
+		attrs:      attribute.NewSet(attribute.String("mykey", "myvalue")),
+	}
+	histogram := summaryMetric{
+		points:  []float64{0.5, 1, 5, 20},
+		weights: []float64{1, 1, 2, 1},
+	}
+
+	metric := pmetric.NewScopeMetrics()
+	buildExplicitHistogramMetric(desc, histogram, timeNow.Add(-time.Minute), timeNow, []float64{1, 10}, metric)
+
+	expectedMetric := pmetric.NewScopeMetrics()
+	m := expectedMetric.Metrics().AppendEmpty()
+	m.SetName("testHistogram")
+	m.SetDataType(pmetric.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(timeNow.Add(-time.Minute)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	dp.Attributes().InsertString("mykey", "myvalue")
+	dp.SetCount(5)
+	dp.SetSum(31.5)
+	dp.SetMin(0.5)
+	dp.SetMax(20)
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1, 10}))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{2, 2, 1}))
+
+	assert.Equal(t, expectedMetric, metric)
+}
+
+func TestBuildExponentialHistogramMetric(t *testing.T) {
+	timeNow := time.Now()
+	desc := statsDMetricDescription{
+		name:       "testHistogram",
+		metricType: DistributionType,
+		attrs:      attribute.NewSet(attribute.String("mykey", "myvalue")),
+	}
+	histogram := summaryMetric{
+		points:  []float64{-3, 0, 1, 2, 4},
+		weights: []float64{1, 1, 1, 1, 1},
+	}
+
+	metric := pmetric.NewScopeMetrics()
+	buildExponentialHistogramMetric(desc, histogram, timeNow.Add(-time.Minute), timeNow, 0, metric)
+
+	expectedMetric := pmetric.NewScopeMetrics()
+	m := expectedMetric.Metrics().AppendEmpty()
+	m.SetName("testHistogram")
+	m.SetDataType(pmetric.MetricDataTypeExponentialHistogram)
+	m.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(timeNow.Add(-time.Minute)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	dp.Attributes().InsertString("mykey", "myvalue")
+	// 1, 2 and 4 span 129 buckets at scale 6, the finest scale fitting in 160 buckets.
+	positive := make([]uint64, 129)
+	positive[0], positive[64], positive[128] = 1, 1, 1
+	dp.SetScale(6)
+	dp.Positive().SetOffset(-1)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(positive))
+	dp.Negative().SetOffset(101)
+	dp.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1}))
+	dp.SetZeroCount(1)
+	dp.SetCount(5)
+	dp.SetSum(4)
+	dp.SetMin(-3)
+	dp.SetMax(4)
+
+	assert.Equal(t, expectedMetric, metric)
+}
+
+func TestBuildExponentialHistogramMetricMaxSize(t *testing.T) {
+	histogram := summaryMetric{
+		points:  []float64{1, 2, 4},
+		weights: []float64{1, 1, 10},
+	}
+
+	metric := pmetric.NewScopeMetrics()
+	buildExponentialHistogramMetric(statsDMetricDescription{name: "testHistogram"}, histogram, time.Now(), time.Now(), 2, metric)
+
+	dp := metric.Metrics().At(0).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(-1), dp.Scale())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 11}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, uint64(12), dp.Count())
+}
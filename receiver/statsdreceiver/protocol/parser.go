@@ -15,12 +15,15 @@
 package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 
 import (
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
-// Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Parser is something that can map input StatsD strings to OTLP Metric representations,
+// and DogStatsD events and service checks to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error
 	GetMetrics() pmetric.Metrics
+	GetLogs() plog.Logs
 	Aggregate(line string) error
 }
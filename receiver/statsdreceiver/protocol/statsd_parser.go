@@ -21,7 +21,9 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -39,27 +41,49 @@ type (
 const (
 	tagMetricType = "metric_type"
 
-	CounterType   MetricType = "c"
-	GaugeType     MetricType = "g"
-	HistogramType MetricType = "h"
-	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
-
-	GaugeObserver   ObserverType = "gauge"
-	SummaryObserver ObserverType = "summary"
-	DisableObserver ObserverType = "disabled"
+	CounterType      MetricType = "c"
+	GaugeType        MetricType = "g"
+	HistogramType    MetricType = "h"
+	TimingType       MetricType = "ms"
+	DistributionType MetricType = "d"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	DistributionTypeName TypeName = "distribution"
+
+	GaugeObserver             ObserverType = "gauge"
+	SummaryObserver           ObserverType = "summary"
+	HistogramObserver         ObserverType = "histogram"
+	ExplicitHistogramObserver ObserverType = "explicit_histogram"
+	DisableObserver           ObserverType = "disabled"
 
 	DefaultObserverType = DisableObserver
+	// DefaultDistributionObserverType is the observer used for DogStatsD
+	// distributions when no mapping is configured for them.
+	DefaultDistributionObserverType = HistogramObserver
+
+	// DefaultHistogramMaxSize is the default maximum number of buckets
+	// of each range of an exponential histogram.
+	DefaultHistogramMaxSize = 160
 )
 
 type TimerHistogramMapping struct {
-	StatsdType   TypeName     `mapstructure:"statsd_type"`
-	ObserverType ObserverType `mapstructure:"observer_type"`
+	StatsdType   TypeName        `mapstructure:"statsd_type"`
+	ObserverType ObserverType    `mapstructure:"observer_type"`
+	Histogram    HistogramConfig `mapstructure:"histogram"`
+}
+
+// HistogramConfig configures the histograms built by the "histogram" and
+// "explicit_histogram" observers.
+type HistogramConfig struct {
+	// MaxSize is the maximum number of buckets of each range (positive and
+	// negative) of an exponential histogram, zero means DefaultHistogramMaxSize.
+	MaxSize int32 `mapstructure:"max_size"`
+	// ExplicitBounds are the bucket boundaries of an explicit histogram.
+	ExplicitBounds []float64 `mapstructure:"explicit_bounds"`
 }
 
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
@@ -67,11 +91,15 @@ type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
+	histograms             map[statsDMetricDescription]summaryMetric
 	timersAndDistributions []pmetric.ScopeMetrics
+	logs                   plog.Logs
 	enableMetricType       bool
 	isMonotonicCounter     bool
 	observeTimer           ObserverType
 	observeHistogram       ObserverType
+	observeDistribution    ObserverType
+	histogramConfigs       map[MetricType]HistogramConfig
 	lastIntervalTime       time.Time
 }
 
@@ -109,6 +137,8 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case DistributionType:
+		return DistributionTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -119,9 +149,13 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.timersAndDistributions = make([]pmetric.ScopeMetrics, 0)
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]summaryMetric)
+	p.logs = plog.NewLogs()
 
 	p.observeHistogram = DefaultObserverType
 	p.observeTimer = DefaultObserverType
+	p.observeDistribution = DefaultDistributionObserverType
+	p.histogramConfigs = make(map[MetricType]HistogramConfig)
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).vaidate()
@@ -129,8 +163,13 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		switch eachMap.StatsdType {
 		case HistogramTypeName:
 			p.observeHistogram = eachMap.ObserverType
+			p.histogramConfigs[HistogramType] = eachMap.Histogram
 		case TimingTypeName, TimingAltTypeName:
 			p.observeTimer = eachMap.ObserverType
+			p.histogramConfigs[TimingType] = eachMap.Histogram
+		case DistributionTypeName:
+			p.observeDistribution = eachMap.ObserverType
+			p.histogramConfigs[DistributionType] = eachMap.Histogram
 		}
 	}
 	return nil
@@ -164,13 +203,30 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 		)
 	}
 
+	for desc, histogramMetric := range p.histograms {
+		cfg := p.histogramConfigs[desc.metricType]
+		if p.observerTypeFor(desc.metricType) == ExplicitHistogramObserver {
+			buildExplicitHistogramMetric(desc, histogramMetric, p.lastIntervalTime, timeNowFunc(), cfg.ExplicitBounds, rm.ScopeMetrics().AppendEmpty())
+		} else {
+			buildExponentialHistogramMetric(desc, histogramMetric, p.lastIntervalTime, timeNowFunc(), cfg.MaxSize, rm.ScopeMetrics().AppendEmpty())
+		}
+	}
+
 	p.gauges = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.timersAndDistributions = make([]pmetric.ScopeMetrics, 0)
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]summaryMetric)
 	return metrics
 }
 
+// GetLogs gets the events and service checks received since the last call and resets the state.
+func (p *StatsDParser) GetLogs() plog.Logs {
+	logs := p.logs
+	p.logs = plog.NewLogs()
+	return logs
+}
+
 var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerTypeFor(t MetricType) ObserverType {
@@ -179,16 +235,45 @@ func (p *StatsDParser) observerTypeFor(t MetricType) ObserverType {
 		return p.observeHistogram
 	case TimingType:
 		return p.observeTimer
+	case DistributionType:
+		return p.observeDistribution
 	}
 	return DisableObserver
 }
 
-// Aggregate for each metric line.
+// Aggregate for each metric, event or service check line.
 func (p *StatsDParser) Aggregate(line string) error {
-	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.aggregateLogRecord(line, parseEvent)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.aggregateLogRecord(line, parseServiceCheck)
+	}
+
+	parsedMetrics, err := parseMessageToMetrics(line, p.enableMetricType)
 	if err != nil {
 		return err
 	}
+	for _, parsedMetric := range parsedMetrics {
+		p.aggregateMetric(parsedMetric)
+	}
+	return nil
+}
+
+func (p *StatsDParser) aggregateLogRecord(line string, parse func(string, plog.LogRecord) error) error {
+	lr := plog.NewLogRecord()
+	if err := parse(line, lr); err != nil {
+		return err
+	}
+	rls := p.logs.ResourceLogs()
+	if rls.Len() == 0 {
+		rls.AppendEmpty().ScopeLogs().AppendEmpty()
+	}
+	lr.MoveTo(rls.At(0).ScopeLogs().At(0).LogRecords().AppendEmpty())
+	return nil
+}
+
+func (p *StatsDParser) aggregateMetric(parsedMetric statsDMetric) {
 	switch parsedMetric.description.metricType {
 	case GaugeType:
 		_, ok := p.gauges[parsedMetric.description]
@@ -214,7 +299,7 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntVal(point.IntVal() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case TimingType, HistogramType, DistributionType:
 		switch p.observerTypeFor(parsedMetric.description.metricType) {
 		case GaugeObserver:
 			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
@@ -231,12 +316,45 @@ func (p *StatsDParser) Aggregate(line string) error {
 					weights: append(existing.weights, raw.count),
 				}
 			}
+		case HistogramObserver, ExplicitHistogramObserver:
+			raw := parsedMetric.summaryValue()
+			existing := p.histograms[parsedMetric.description]
+			p.histograms[parsedMetric.description] = summaryMetric{
+				points:  append(existing.points, raw.value),
+				weights: append(existing.weights, raw.count),
+			}
 		case DisableObserver:
 			// No action.
 		}
 	}
+}
 
-	return nil
+// parseMessageToMetrics parses a metric line which may hold several values,
+// e.g. "name:1:2:3|c", into one statsDMetric per value.
+func parseMessageToMetrics(line string, enableMetricType bool) ([]statsDMetric, error) {
+	nameAndValues, rest := line, ""
+	if i := strings.IndexByte(line, '|'); i >= 0 {
+		nameAndValues, rest = line[:i], line[i:]
+	}
+	values := strings.Split(nameAndValues, ":")
+	if len(values) <= 2 {
+		result, err := parseMessageToMetric(line, enableMetricType)
+		if err != nil {
+			return nil, err
+		}
+		return []statsDMetric{result}, nil
+	}
+
+	name := values[0]
+	results := make([]statsDMetric, 0, len(values)-1)
+	for _, value := range values[1:] {
+		result, err := parseMessageToMetric(name+":"+value+rest, enableMetricType)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
 }
 
 func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, error) {
@@ -266,7 +384,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -288,19 +406,13 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, containerIDPrefix):
+			kvs = append(kvs, attribute.String(conventions.AttributeContainerID, strings.TrimPrefix(part, containerIDPrefix)))
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
@@ -324,3 +436,15 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	return result, nil
 }
+
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if len(tagParts) != 2 {
+			return nil, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
+	}
+	return kvs, nil
+}
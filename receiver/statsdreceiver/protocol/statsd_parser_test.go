@@ -1008,3 +1008,138 @@ func TestTimeNowFunc(t *testing.T) {
 	timeNow := timeNowFunc()
 	assert.NotNil(t, timeNow)
 }
+
+func Test_ParseMessageToMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantMetrics []statsDMetric
+		err         error
+	}{
+		{
+			name:  "single value",
+			input: "test.metric:42|c",
+			wantMetrics: []statsDMetric{
+				testStatsDMetric("test.metric", 42, false, "c", 0, nil, nil),
+			},
+		},
+		{
+			name:  "multiple values",
+			input: "test.metric:1:+2:3|g|#key:value",
+			wantMetrics: []statsDMetric{
+				testStatsDMetric("test.metric", 1, false, "g", 0, []string{"key"}, []string{"value"}),
+				testStatsDMetric("test.metric", 2, true, "g", 0, []string{"key"}, []string{"value"}),
+				testStatsDMetric("test.metric", 3, false, "g", 0, []string{"key"}, []string{"value"}),
+			},
+		},
+		{
+			name:  "distribution with container id",
+			input: "test.metric:1.5:2|d|@0.5|#key:value|c:abc123",
+			wantMetrics: []statsDMetric{
+				testStatsDMetric("test.metric", 1.5, false, "d", 0.5, []string{"key", "container.id"}, []string{"value", "abc123"}),
+				testStatsDMetric("test.metric", 2, false, "d", 0.5, []string{"key", "container.id"}, []string{"value", "abc123"}),
+			},
+		},
+		{
+			name:  "empty value",
+			input: "test.metric:1::3|c",
+			err:   errEmptyMetricValue,
+		},
+		{
+			name:  "invalid value",
+			input: "test.metric:1:abc|c",
+			err:   errors.New("parse metric value string: abc"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMessageToMetrics(tt.input, false)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantMetrics, got)
+			}
+		})
+	}
+}
+
+func TestStatsDParser_AggregateDistribution(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	tests := []struct {
+		name     string
+		mapping  []TimerHistogramMapping
+		dataType pmetric.MetricDataType
+	}{
+		{
+			name:     "default",
+			dataType: pmetric.MetricDataTypeExponentialHistogram,
+		},
+		{
+			name: "explicit_histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "distribution", ObserverType: "explicit_histogram", Histogram: HistogramConfig{ExplicitBounds: []float64{1, 10}}},
+			},
+			dataType: pmetric.MetricDataTypeHistogram,
+		},
+		{
+			name: "summary",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "distribution", ObserverType: "summary"},
+			},
+			dataType: pmetric.MetricDataTypeSummary,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StatsDParser{}
+			p.Initialize(false, false, tt.mapping)
+			assert.NoError(t, p.Aggregate("test.distribution:1:2:4|d|#mykey:myvalue"))
+			assert.NoError(t, p.Aggregate("test.distribution:8|d|#mykey:myvalue"))
+
+			metrics := p.GetMetrics()
+			assert.Equal(t, 1, metrics.MetricCount())
+			m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "test.distribution", m.Name())
+			assert.Equal(t, tt.dataType, m.DataType())
+			switch tt.dataType {
+			case pmetric.MetricDataTypeExponentialHistogram:
+				assert.Equal(t, uint64(4), m.ExponentialHistogram().DataPoints().At(0).Count())
+				assert.Equal(t, float64(15), m.ExponentialHistogram().DataPoints().At(0).Sum())
+			case pmetric.MetricDataTypeHistogram:
+				assert.Equal(t, []uint64{1, 3, 0}, m.Histogram().DataPoints().At(0).BucketCounts().AsRaw())
+			case pmetric.MetricDataTypeSummary:
+				assert.Equal(t, uint64(4), m.Summary().DataPoints().At(0).Count())
+			}
+
+			// The state is reset after each call
+			assert.Equal(t, 0, p.GetMetrics().MetricCount())
+		})
+	}
+}
+
+func TestStatsDParser_AggregateEventsAndServiceChecks(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, nil)
+	assert.NoError(t, p.Aggregate("_e{5,4}:title|text|#env:prod"))
+	assert.NoError(t, p.Aggregate("_sc|my.check|2|m:down"))
+	assert.Error(t, p.Aggregate("_sc|my.check|5"))
+	assert.NoError(t, p.Aggregate("test.metric:42|c"))
+
+	assert.Equal(t, 1, p.GetMetrics().MetricCount())
+	logs := p.GetLogs()
+	assert.Equal(t, 2, logs.LogRecordCount())
+	lrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "text", lrs.At(0).Body().StringVal())
+	assert.Equal(t, "down", lrs.At(1).Body().StringVal())
+	assert.Equal(t, 0, p.GetLogs().LogRecordCount())
+}
@@ -32,8 +32,10 @@ import (
 )
 
 var _ component.MetricsReceiver = (*statsdReceiver)(nil)
+var _ component.LogsReceiver = (*statsdReceiver)(nil)
 
-// statsdReceiver implements the component.MetricsReceiver for StatsD protocol.
+// statsdReceiver implements the component.MetricsReceiver for StatsD protocol,
+// and the component.LogsReceiver for DogStatsD events and service checks.
 type statsdReceiver struct {
 	settings component.ReceiverCreateSettings
 	config   *Config
@@ -42,6 +44,7 @@ type statsdReceiver struct {
 	reporter     transport.Reporter
 	parser       protocol.Parser
 	nextConsumer consumer.Metrics
+	logsConsumer consumer.Logs
 	cancel       context.CancelFunc
 }
 
@@ -55,6 +58,17 @@ func New(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	r, err := newReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.nextConsumer = nextConsumer
+	return r, nil
+}
+
+// newReceiver creates the StatsD receiver without any consumer, they are
+// set by the factory once the receiver is shared by the metrics and logs pipelines.
+func newReceiver(set component.ReceiverCreateSettings, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
@@ -65,34 +79,36 @@ func New(
 	}
 
 	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		server:       server,
-		reporter:     newReporter(config.ID(), set),
-		parser:       &protocol.StatsDParser{},
+		settings: set,
+		config:   &config,
+		server:   server,
+		reporter: newReporter(config.ID(), set),
+		parser:   &protocol.StatsDParser{},
 	}
 	return r, nil
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
 	case "", "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint, config.TCPIdleTimeout, config.TCPMaxConnections)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q for receiver %v", config.NetAddr.Transport, config.ID())
 }
 
-// Start starts a UDP server that can process StatsD messages.
+// Start starts a server that can process StatsD messages.
 func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	ctx, r.cancel = context.WithCancel(ctx)
 	var transferChan = make(chan string, 10)
 	ticker := time.NewTicker(r.config.AggregationInterval)
 	r.parser.Initialize(r.config.EnableMetricType, r.config.IsMonotonicCounter, r.config.TimerHistogramMapping)
 	go func() {
-		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.parser, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				host.ReportFatalError(err)
 			}
@@ -103,9 +119,13 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			select {
 			case <-ticker.C:
 				metrics := r.parser.GetMetrics()
-				if metrics.ResourceMetrics().At(0).ScopeMetrics().Len() > 0 {
+				if r.nextConsumer != nil && metrics.ResourceMetrics().At(0).ScopeMetrics().Len() > 0 {
 					r.Flush(ctx, metrics, r.nextConsumer)
 				}
+				logs := r.parser.GetLogs()
+				if r.logsConsumer != nil && logs.LogRecordCount() > 0 {
+					r.logsConsumer.ConsumeLogs(ctx, logs)
+				}
 			case rawMetric := <-transferChan:
 				r.parser.Aggregate(rawMetric)
 			case <-ctx.Done():
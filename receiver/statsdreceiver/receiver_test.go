@@ -19,6 +19,8 @@ import (
 	"context"
 	"errors"
 	"net"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"testing"
 	"time"
@@ -161,3 +163,101 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func Test_statsdreceiver_Transports(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport string
+		endpoint  func(t *testing.T) string
+	}{
+		{
+			name:      "tcp",
+			transport: "tcp",
+			endpoint: func(t *testing.T) string {
+				return testutil.GetAvailableLocalAddress(t)
+			},
+		},
+		{
+			name:      "unixgram",
+			transport: "unixgram",
+			endpoint: func(t *testing.T) string {
+				if runtime.GOOS == "windows" {
+					t.Skip("Unix domain datagram sockets are not supported on Windows")
+				}
+				return filepath.Join(t.TempDir(), "statsd.sock")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.NetAddr = confignet.NetAddr{
+				Endpoint:  tt.endpoint(t),
+				Transport: tt.transport,
+			}
+			cfg.AggregationInterval = 100 * time.Millisecond
+			sink := new(consumertest.MetricsSink)
+			rcv, err := createMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, rcv.Shutdown(context.Background()))
+			}()
+
+			conn, err := net.Dial(tt.transport, cfg.NetAddr.Endpoint)
+			require.NoError(t, err)
+			statsdClient := &client.StatsD{Conn: conn}
+			require.NoError(t, statsdClient.SendMetric(client.Metric{
+				Name:  "test.metric",
+				Value: "42",
+				Type:  "c",
+			}))
+			require.NoError(t, statsdClient.Disconnect())
+
+			require.Eventually(t, func() bool {
+				return sink.DataPointCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			metric := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "test.metric", metric.Name())
+			assert.Equal(t, pmetric.MetricDataTypeSum, metric.DataType())
+		})
+	}
+}
+
+func Test_statsdreceiver_MetricsAndLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr = confignet.NetAddr{
+		Endpoint:  testutil.GetAvailableLocalAddress(t),
+		Transport: "tcp",
+	}
+	cfg.AggregationInterval = 100 * time.Millisecond
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	mRcv, err := createMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, metricsSink)
+	require.NoError(t, err)
+	lRcv, err := createLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, logsSink)
+	require.NoError(t, err)
+	require.NoError(t, mRcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lRcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, mRcv.Shutdown(context.Background()))
+		assert.NoError(t, lRcv.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("tcp", cfg.NetAddr.Endpoint)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test.distribution:1:2:3|d|c:abc123\n_e{5,4}:title|text\n_sc|my.check|2\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return metricsSink.DataPointCount() == 1 && logsSink.LogRecordCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	metric := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "test.distribution", metric.Name())
+	assert.Equal(t, pmetric.MetricDataTypeExponentialHistogram, metric.DataType())
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), dp.Count())
+	containerID, _ := dp.Attributes().Get("container.id")
+	assert.Equal(t, "abc123", containerID.StringVal())
+}
@@ -63,8 +63,15 @@ func (s *StatsD) connect(transport Transport) error {
 	var err error
 	switch transport {
 	case TCP:
-		// TODO: implement TCP support
-		return fmt.Errorf("TCP unsupported")
+		var tcpAddr *net.TCPAddr
+		tcpAddr, err = net.ResolveTCPAddr("tcp", address)
+		if err != nil {
+			return err
+		}
+		s.Conn, err = net.DialTCP("tcp", nil, tcpAddr)
+		if err != nil {
+			return err
+		}
 	case UDP:
 		var udpAddr *net.UDPAddr
 		udpAddr, err = net.ResolveUDPAddr("udp", address)
@@ -93,8 +100,9 @@ func (s *StatsD) Disconnect() error {
 }
 
 // SendMetric sends the input metric to the StatsD connection.
+// The message is terminated by a newline, as required by stream transports.
 func (s *StatsD) SendMetric(metric Metric) error {
-	_, err := fmt.Fprint(s.Conn, metric.String())
+	_, err := fmt.Fprintln(s.Conn, metric.String())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,93 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// packetServer implements a transport.Server for datagram oriented transports,
+// where each packet holds one or more newline separated messages.
+type packetServer struct {
+	packetConn    net.PacketConn
+	transportName string
+	bufferSize    int
+	reporter      Reporter
+}
+
+func (p *packetServer) ListenAndServe(
+	parser protocol.Parser,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	p.reporter = reporter
+
+	buf := make([]byte, p.bufferSize)
+	for {
+		n, _, err := p.packetConn.ReadFrom(buf)
+		if n > 0 {
+			bufCopy := make([]byte, n)
+			copy(bufCopy, buf)
+			p.handlePacket(bufCopy, transferChan)
+		}
+		if err != nil {
+			p.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				p.transportName,
+				p.packetConn.LocalAddr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+	}
+}
+
+func (p *packetServer) Close() error {
+	return p.packetConn.Close()
+}
+
+func (p *packetServer) handlePacket(
+	data []byte,
+	transferChan chan<- string,
+) {
+	buf := bytes.NewBuffer(data)
+	for {
+		bytes, err := buf.ReadBytes((byte)('\n'))
+		if errors.Is(err, io.EOF) {
+			if len(bytes) == 0 {
+				// Completed without errors.
+				break
+			}
+		}
+		line := strings.TrimSpace(string(bytes))
+		if line != "" {
+			transferChan <- line
+		}
+	}
+}
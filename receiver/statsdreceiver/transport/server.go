@@ -18,8 +18,6 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/collector/consumer"
-
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -32,10 +30,9 @@ var (
 type Server interface {
 	// ListenAndServe is a blocking call that starts to listen for client messages
 	// on the specific transport, and prepares the message to be processed by
-	// the Parser and passed to the next consumer.
+	// the Parser.
 	ListenAndServe(
 		p protocol.Parser,
-		mc consumer.Metrics,
 		r Reporter,
 		transferChan chan<- string,
 	) error
@@ -15,15 +15,20 @@
 package transport
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
@@ -58,7 +63,6 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			port, err := strconv.Atoi(portStr)
 			require.NoError(t, err)
 
-			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
 			require.NoError(t, err)
 			mr := NewMockReporter(1)
@@ -68,7 +72,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(p, mc, mr, transferChan))
+				assert.Error(t, srv.ListenAndServe(p, mr, transferChan))
 			}()
 
 			runtime.Gosched()
@@ -96,3 +100,190 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+// serve starts srv and returns a channel with the messages it received,
+// and a function stopping the server.
+func serve(t *testing.T, srv Server) (<-chan string, func()) {
+	transferChan := make(chan string, 10)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, NewMockReporter(0), transferChan))
+	}()
+	return transferChan, func() {
+		assert.NoError(t, srv.Close())
+		wg.Wait()
+	}
+}
+
+func receive(t *testing.T, c <-chan string) string {
+	select {
+	case msg := <-c:
+		return msg
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for message")
+		return ""
+	}
+}
+
+func Test_TCPServer_NewlineFraming(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 0, 0)
+	require.NoError(t, err)
+	transferChan, stop := serve(t, srv)
+	defer stop()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(conn, "test.metric:42|c\ntest.gauge:1|g\ntest.")
+	require.NoError(t, err)
+	assert.Equal(t, "test.metric:42|c", receive(t, transferChan))
+	assert.Equal(t, "test.gauge:1|g", receive(t, transferChan))
+
+	// A message split across writes is received once complete
+	_, err = fmt.Fprint(conn, "timer:320|ms\n")
+	require.NoError(t, err)
+	assert.Equal(t, "test.timer:320|ms", receive(t, transferChan))
+
+	// The last message doesn't need a newline when the connection is closed
+	_, err = fmt.Fprint(conn, "test.set:a|s")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	assert.Equal(t, "test.set:a|s", receive(t, transferChan))
+}
+
+func Test_TCPServer_LineTooLong(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 0, 0)
+	require.NoError(t, err)
+	transferChan, stop := serve(t, srv)
+	defer stop()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = fmt.Fprintln(conn, "first:1|c")
+	require.NoError(t, err)
+	assert.Equal(t, "first:1|c", receive(t, transferChan))
+
+	// The connection is closed by the server once the line exceeds the limit
+	_, _ = conn.Write(bytes.Repeat([]byte("a"), tcpMaxLineSize+1))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		assert.False(t, netErr.Timeout(), "connection should have been closed by the server")
+	}
+	select {
+	case msg := <-transferChan:
+		assert.Fail(t, "unexpected message", msg)
+	default:
+	}
+}
+
+func Test_TCPServer_MaxConnections(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 0, 1)
+	require.NoError(t, err)
+	transferChan, stop := serve(t, srv)
+	defer stop()
+
+	conn1, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn1.Close()
+	_, err = fmt.Fprintln(conn1, "first:1|c")
+	require.NoError(t, err)
+	assert.Equal(t, "first:1|c", receive(t, transferChan))
+
+	// The second connection is closed by the server
+	conn2, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn2.Close()
+	require.NoError(t, conn2.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn2.Read(make([]byte, 1))
+	assert.Error(t, err)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		assert.False(t, netErr.Timeout(), "connection should have been closed by the server")
+	}
+
+	// Once the first connection is closed, new connections are accepted again
+	require.NoError(t, conn1.Close())
+	assert.Eventually(t, func() bool {
+		conn3, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		defer conn3.Close()
+		if _, err = fmt.Fprintln(conn3, "third:1|c"); err != nil {
+			return false
+		}
+		select {
+		case msg := <-transferChan:
+			return msg == "third:1|c"
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func Test_TCPServer_IdleTimeout(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 50*time.Millisecond, 0)
+	require.NoError(t, err)
+	_, stop := serve(t, srv)
+	defer stop()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func Test_NewTCPServer_Invalid(t *testing.T) {
+	_, err := NewTCPServer("localhost:0", -1, 0)
+	assert.EqualError(t, err, "invalid idle timeout: -1ns")
+	_, err = NewTCPServer("localhost:0", 0, -1)
+	assert.EqualError(t, err, "invalid max connections: -1")
+}
+
+func Test_UnixgramServer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain datagram sockets are not supported on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	srv, err := NewUnixgramServer(path)
+	require.NoError(t, err)
+	transferChan, stop := serve(t, srv)
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Packets larger than the max UDP packet size are received whole
+	large := make([]byte, 0, 2*udpMaxPacketSize)
+	for len(large) < udpMaxPacketSize {
+		large = append(large, []byte("test.metric:42|c\n")...)
+	}
+	_, err = conn.Write(large)
+	require.NoError(t, err)
+	for i := 0; i < len(large)/len("test.metric:42|c\n"); i++ {
+		require.Equal(t, "test.metric:42|c", receive(t, transferChan))
+	}
+
+	stop()
+	assert.NoFileExists(t, path)
+
+	// A stale socket is replaced
+	staleConn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	require.NoError(t, staleConn.Close())
+	require.FileExists(t, path)
+	srv, err = NewUnixgramServer(path)
+	require.NoError(t, err)
+	require.NoError(t, srv.Close())
+}
@@ -0,0 +1,199 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+const (
+	// TCPIdleTimeoutDefault is the default timeout for idle TCP connections.
+	TCPIdleTimeoutDefault = 30 * time.Second
+
+	// tcpMaxLineSize bounds the size of a message, connections sending longer
+	// lines are closed. Messages can't be larger over UDP either.
+	tcpMaxLineSize = udpMaxPacketSize
+)
+
+type tcpServer struct {
+	ln             net.Listener
+	wg             sync.WaitGroup
+	idleTimeout    time.Duration
+	maxConnections int
+	reporter       Reporter
+}
+
+var _ Server = (*tcpServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport.
+// Messages are separated by newlines. When maxConnections is positive, new
+// connections are closed right away while that many connections are open.
+func NewTCPServer(
+	addr string,
+	idleTimeout time.Duration,
+	maxConnections int,
+) (Server, error) {
+	if idleTimeout < 0 {
+		return nil, fmt.Errorf("invalid idle timeout: %v", idleTimeout)
+	}
+
+	if idleTimeout == 0 {
+		idleTimeout = TCPIdleTimeoutDefault
+	}
+
+	if maxConnections < 0 {
+		return nil, fmt.Errorf("invalid max connections: %v", maxConnections)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	t := tcpServer{
+		ln:             ln,
+		idleTimeout:    idleTimeout,
+		maxConnections: maxConnections,
+	}
+	return &t, nil
+}
+
+func (t *tcpServer) ListenAndServe(
+	parser protocol.Parser,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	acceptedConnMap := make(map[net.Conn]struct{})
+	connMapMtx := &sync.Mutex{}
+
+	t.reporter = reporter
+	var err error
+	for {
+		conn, acceptErr := t.ln.Accept()
+		if acceptErr == nil {
+			connMapMtx.Lock()
+			if t.maxConnections > 0 && len(acceptedConnMap) >= t.maxConnections {
+				connMapMtx.Unlock()
+				t.reporter.OnDebugf(
+					"TCP Transport (%s) - rejecting connection from %s: max connections (%d) reached",
+					t.ln.Addr(),
+					conn.RemoteAddr(),
+					t.maxConnections)
+				conn.Close()
+				continue
+			}
+			acceptedConnMap[conn] = struct{}{}
+			connMapMtx.Unlock()
+			t.wg.Add(1)
+			go func(c net.Conn) {
+				t.handleConnection(c, transferChan)
+				connMapMtx.Lock()
+				delete(acceptedConnMap, c)
+				connMapMtx.Unlock()
+				t.wg.Done()
+			}(conn)
+			continue
+		}
+
+		var netErr net.Error
+		if errors.As(acceptErr, &netErr) {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - Accept (temporary=%v) net.Error: %v",
+				t.ln.Addr().String(),
+				netErr.Timeout(),
+				netErr)
+			if netErr.Timeout() {
+				continue
+			}
+		}
+
+		err = acceptErr
+		break
+	}
+
+	t.reporter.OnDebugf(
+		"TCP Transport (%s) exiting Accept loop error: %v",
+		t.ln.Addr().String(),
+		err)
+
+	// Close any lingering connection
+	connMapMtx.Lock()
+	for conn := range acceptedConnMap {
+		conn.Close()
+	}
+	connMapMtx.Unlock()
+
+	return err
+}
+
+func (t *tcpServer) Close() error {
+	err := t.ln.Close()
+	t.wg.Wait()
+	return err
+}
+
+func (t *tcpServer) handleConnection(
+	conn net.Conn,
+	transferChan chan<- string,
+) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), tcpMaxLineSize)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		// scanner.Scan call below will block until either:
+		//
+		// * a '\n' char is read
+		// * the connection is closed (either by client or server)
+		// * an idle timeout happens (see call to conn.SetDeadline above)
+		// * more than tcpMaxLineSize bytes are read without a '\n' char
+		//
+		// The last line of a connection closed by the client doesn't need to
+		// end with a '\n' char.
+		if !scanner.Scan() {
+			// Connections are closed on read errors, including idle timeouts
+			// and too long lines, so that idle or misbehaving connections are
+			// purged. A nil error means the client closed the connection.
+			if err := scanner.Err(); err != nil {
+				t.reporter.OnDebugf("TCP Transport (%s) - read error: %v", t.ln.Addr(), err)
+			}
+			return
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			transferChan <- line
+		}
+	}
+}
@@ -15,20 +15,14 @@
 package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
 
 import (
-	"bytes"
-	"errors"
-	"io"
 	"net"
-	"strings"
-
-	"go.opentelemetry.io/collector/consumer"
-
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
+// udpMaxPacketSize is the max size for udp packet body (assuming ipv6)
+const udpMaxPacketSize = 65527
+
 type udpServer struct {
-	packetConn net.PacketConn
-	reporter   Reporter
+	packetServer
 }
 
 var _ (Server) = (*udpServer)(nil)
@@ -41,66 +35,11 @@ func NewUDPServer(addr string) (Server, error) {
 	}
 
 	u := udpServer{
-		packetConn: packetConn,
+		packetServer: packetServer{
+			packetConn:    packetConn,
+			transportName: "UDP",
+			bufferSize:    udpMaxPacketSize,
+		},
 	}
 	return &u, nil
 }
-
-func (u *udpServer) ListenAndServe(
-	parser protocol.Parser,
-	nextConsumer consumer.Metrics,
-	reporter Reporter,
-	transferChan chan<- string,
-) error {
-	if parser == nil || nextConsumer == nil || reporter == nil {
-		return errNilListenAndServeParameters
-	}
-
-	u.reporter = reporter
-
-	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
-	for {
-		n, _, err := u.packetConn.ReadFrom(buf)
-		if n > 0 {
-			bufCopy := make([]byte, n)
-			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, transferChan)
-		}
-		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
-				u.packetConn.LocalAddr(),
-				err)
-			var netErr net.Error
-			if errors.As(err, &netErr) {
-				if netErr.Timeout() {
-					continue
-				}
-			}
-			return err
-		}
-	}
-}
-
-func (u *udpServer) Close() error {
-	return u.packetConn.Close()
-}
-
-func (u *udpServer) handlePacket(
-	data []byte,
-	transferChan chan<- string,
-) {
-	buf := bytes.NewBuffer(data)
-	for {
-		bytes, err := buf.ReadBytes((byte)('\n'))
-		if errors.Is(err, io.EOF) {
-			if len(bytes) == 0 {
-				// Completed without errors.
-				break
-			}
-		}
-		line := strings.TrimSpace(string(bytes))
-		if line != "" {
-			transferChan <- line
-		}
-	}
-}
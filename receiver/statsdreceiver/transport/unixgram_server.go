@@ -0,0 +1,82 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// unixgramMaxPacketSize is the max size of a packet received over a Unix
+// domain datagram socket. Unlike UDP, packets are not limited by the network
+// MTU, so clients can send larger payloads without fragmentation.
+const unixgramMaxPacketSize = 1 << 18
+
+type unixgramServer struct {
+	packetServer
+	path string
+}
+
+var _ (Server) = (*unixgramServer)(nil)
+
+// NewUnixgramServer creates a transport.Server using a Unix domain datagram
+// socket as its transport. A stale socket left at path is removed first.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeSocket(path); err != nil {
+		return nil, err
+	}
+
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := unixgramServer{
+		packetServer: packetServer{
+			packetConn:    packetConn,
+			transportName: "Unixgram",
+			bufferSize:    unixgramMaxPacketSize,
+		},
+		path: path,
+	}
+	return &u, nil
+}
+
+func (u *unixgramServer) Close() error {
+	err := u.packetServer.Close()
+	if rmErr := removeSocket(u.path); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// removeSocket removes the socket file at path, if any. Other kinds of
+// files are left untouched.
+func removeSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%q exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}